@@ -0,0 +1,112 @@
+package batcher
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
+)
+
+// altDAFailover decides whether batcher transactions are posted to the Alt-DA server or, while
+// the DA server is failing, directly to Ethereum DA. Derivation forwards all batcher inbox data
+// that isn't an Alt-DA commitment to the regular frame parsing, so both can be mixed freely.
+//
+// The failover is activated after Threshold consecutive failed DA requests, or after requests
+// kept failing for Timeout. While active, a single request is sent to the DA server every
+// ProbeInterval to probe whether it is healthy again. The first successful request ends the
+// failover. A zero Threshold and Timeout disable failover.
+type altDAFailover struct {
+	Threshold     uint64
+	Timeout       time.Duration
+	ProbeInterval time.Duration
+	// DAType is the data availability type to use while failed over, blobs or calldata.
+	DAType flags.DataAvailabilityType
+
+	mu sync.Mutex
+	// number of consecutive failed requests and the time of the first of them
+	failures     uint64
+	firstFailure time.Time
+	// active is true while failed over, since the given time
+	active bool
+	since  time.Time
+	// time of the last probe request to the DA server while failed over
+	lastProbe time.Time
+}
+
+func newAltDAFailover(cfg BatcherConfig) *altDAFailover {
+	return &altDAFailover{
+		Threshold:     cfg.AltDAFailoverThreshold,
+		Timeout:       cfg.AltDAFailoverTimeout,
+		ProbeInterval: cfg.AltDAFailoverProbeInterval,
+		DAType:        cfg.AltDAFailoverDAType,
+	}
+}
+
+func (f *altDAFailover) enabled() bool {
+	return f.Threshold > 0 || f.Timeout > 0
+}
+
+// UseL1 returns whether the next transaction should be posted to Ethereum DA instead of the
+// DA server. It returns false once per ProbeInterval while failed over, to probe the DA server.
+func (f *altDAFailover) UseL1(now time.Time) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.active {
+		return false
+	}
+	if now.Sub(f.lastProbe) >= f.ProbeInterval {
+		f.lastProbe = now
+		return false
+	}
+	return true
+}
+
+// RecordFailure records a failed DA request. It returns true if this activated the failover.
+func (f *altDAFailover) RecordFailure(now time.Time) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures == 0 {
+		f.firstFailure = now
+	}
+	f.failures++
+	if f.active || !f.enabled() {
+		return false
+	}
+	if (f.Threshold > 0 && f.failures >= f.Threshold) ||
+		(f.Timeout > 0 && now.Sub(f.firstFailure) >= f.Timeout) {
+		f.active = true
+		f.since = now
+		f.lastProbe = now
+		return true
+	}
+	return false
+}
+
+// RecordSuccess records a successful DA request. It returns true if this ended the failover.
+func (f *altDAFailover) RecordSuccess() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = 0
+	if !f.active {
+		return false
+	}
+	f.active = false
+	f.since = time.Time{}
+	return true
+}
+
+// State returns a snapshot of the failover state for inspection over RPC.
+func (f *altDAFailover) State() rpc.AltDAState {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	st := rpc.AltDAState{
+		Mode:                rpc.AltDAModeAltDA,
+		ConsecutiveFailures: f.failures,
+	}
+	if f.active {
+		st.Mode = string(f.DAType)
+		st.FailedOverSince = uint64(f.since.Unix())
+	}
+	return st
+}
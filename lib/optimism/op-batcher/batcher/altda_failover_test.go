@@ -0,0 +1,66 @@
+package batcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAltDAFailover_Disabled(t *testing.T) {
+	f := newAltDAFailover(BatcherConfig{})
+	now := time.Now()
+	for i := 0; i < 100; i++ {
+		require.False(t, f.RecordFailure(now))
+		now = now.Add(time.Hour)
+	}
+	require.False(t, f.UseL1(now))
+	require.Equal(t, rpc.AltDAState{Mode: rpc.AltDAModeAltDA, ConsecutiveFailures: 100}, f.State())
+}
+
+func TestAltDAFailover_Threshold(t *testing.T) {
+	f := newAltDAFailover(BatcherConfig{
+		AltDAFailoverThreshold:     3,
+		AltDAFailoverDAType:        flags.BlobsType,
+		AltDAFailoverProbeInterval: time.Minute,
+	})
+	now := time.Now()
+	require.False(t, f.RecordFailure(now))
+	require.False(t, f.RecordFailure(now))
+	// a success resets the consecutive failures
+	require.False(t, f.RecordSuccess())
+	require.False(t, f.RecordFailure(now))
+	require.False(t, f.RecordFailure(now))
+	require.False(t, f.UseL1(now))
+	require.True(t, f.RecordFailure(now))
+	require.Equal(t, rpc.AltDAState{Mode: "blobs", FailedOverSince: uint64(now.Unix()), ConsecutiveFailures: 3}, f.State())
+
+	// while failed over, the DA server is probed once per probe interval
+	require.True(t, f.UseL1(now))
+	require.True(t, f.UseL1(now.Add(30*time.Second)))
+	require.False(t, f.UseL1(now.Add(time.Minute)))
+	require.True(t, f.UseL1(now.Add(time.Minute)))
+	// a failing probe doesn't end the failover
+	require.False(t, f.RecordFailure(now.Add(time.Minute)))
+	require.True(t, f.UseL1(now.Add(90*time.Second)))
+
+	// a successful probe does
+	require.True(t, f.RecordSuccess())
+	require.False(t, f.UseL1(now.Add(90*time.Second)))
+	require.Equal(t, rpc.AltDAState{Mode: rpc.AltDAModeAltDA}, f.State())
+}
+
+func TestAltDAFailover_Timeout(t *testing.T) {
+	f := newAltDAFailover(BatcherConfig{
+		AltDAFailoverTimeout:       time.Minute,
+		AltDAFailoverDAType:        flags.CalldataType,
+		AltDAFailoverProbeInterval: time.Minute,
+	})
+	now := time.Now()
+	require.False(t, f.RecordFailure(now))
+	require.False(t, f.RecordFailure(now.Add(59*time.Second)))
+	require.True(t, f.RecordFailure(now.Add(time.Minute)))
+	require.Equal(t, "calldata", f.State().Mode)
+}
@@ -1,12 +1,18 @@
 package batcher
 
 import (
+	"cmp"
 	"math"
+	"math/big"
+	"slices"
+	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -24,11 +30,37 @@ type channel struct {
 	pendingTransactions map[string]txData
 	// Set of confirmed txID -> inclusion block. For determining if the channel is timed out
 	confirmedTransactions map[string]eth.BlockID
+	// Set of confirmed txID -> frame IDs of the tx. For journaling confirmed transactions
+	confirmedTxIDs map[string]txID
 
 	// Inclusion block number of first confirmed TX
 	minInclusionBlock uint64
 	// Inclusion block number of last confirmed TX
 	maxInclusionBlock uint64
+
+	// L1 cost of the confirmed txs, see RecordTxCost
+	cost txCost
+}
+
+// txCost accumulates the L1 cost of confirmed transactions.
+type txCost struct {
+	numTxs        int
+	gasUsed       uint64
+	blobGasUsed   uint64
+	executionCost big.Int
+	blobCost      big.Int
+}
+
+func (tc *txCost) add(receipt *types.Receipt) {
+	tc.numTxs++
+	tc.gasUsed += receipt.GasUsed
+	if receipt.EffectiveGasPrice != nil {
+		tc.executionCost.Add(&tc.executionCost, new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice))
+	}
+	tc.blobGasUsed += receipt.BlobGasUsed
+	if receipt.BlobGasPrice != nil {
+		tc.blobCost.Add(&tc.blobCost, new(big.Int).Mul(new(big.Int).SetUint64(receipt.BlobGasUsed), receipt.BlobGasPrice))
+	}
 }
 
 func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rollupCfg *rollup.Config, latestL1OriginBlockNum uint64, channelOut derive.ChannelOut) *channel {
@@ -40,6 +72,7 @@ func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rollup
 		channelBuilder:        cb,
 		pendingTransactions:   make(map[string]txData),
 		confirmedTransactions: make(map[string]eth.BlockID),
+		confirmedTxIDs:        make(map[string]txID),
 		minInclusionBlock:     math.MaxUint64,
 	}
 }
@@ -61,17 +94,58 @@ func (c *channel) TxFailed(id string) {
 	c.metr.RecordBatchTxFailed()
 }
 
+// RecordTxCost adds the L1 cost of the given receipt to the channel's cost.
+// It should be called once per transaction, before the transaction is marked as confirmed.
+func (c *channel) RecordTxCost(receipt *types.Receipt) {
+	c.cost.add(receipt)
+}
+
+// Cost returns the L1 cost of the channel's confirmed transactions.
+func (c *channel) Cost() rpc.ChannelCost {
+	cost := rpc.ChannelCost{
+		ID:                c.ID(),
+		Incomplete:        c.cost.numTxs < len(c.confirmedTransactions),
+		MinInclusionBlock: c.minInclusionBlock,
+		MaxInclusionBlock: c.maxInclusionBlock,
+		OldestL2:          c.OldestL2(),
+		LatestL2:          c.LatestL2(),
+		NumL2Blocks:       len(c.channelBuilder.Blocks()),
+		InputBytes:        c.InputBytes(),
+		OutputBytes:       c.OutputBytes(),
+		NumL1Txs:          c.cost.numTxs,
+		GasUsed:           c.cost.gasUsed,
+		BlobGasUsed:       c.cost.blobGasUsed,
+		ExecutionCost:     (*hexutil.Big)(new(big.Int).Set(&c.cost.executionCost)),
+		BlobCost:          (*hexutil.Big)(new(big.Int).Set(&c.cost.blobCost)),
+	}
+	for _, block := range c.channelBuilder.Blocks() {
+		for _, tx := range block.Transactions() {
+			if !tx.IsDepositTx() {
+				cost.NumL2Txs++
+			}
+		}
+	}
+	total := new(big.Int).Add(&c.cost.executionCost, &c.cost.blobCost)
+	cost.TotalCost = (*hexutil.Big)(total)
+	if cost.InputBytes > 0 {
+		cost.CostPerL2Byte, _ = new(big.Float).Quo(new(big.Float).SetInt(total), big.NewFloat(float64(cost.InputBytes))).Float64()
+	}
+	return cost
+}
+
 // TxConfirmed marks a transaction as confirmed on L1. Returns a bool indicating
 // whether the channel timed out on chain.
 func (c *channel) TxConfirmed(id string, inclusionBlock eth.BlockID) bool {
 	c.metr.RecordBatchTxSuccess()
 	c.log.Debug("marked transaction as confirmed", "id", id, "block", inclusionBlock)
-	if _, ok := c.pendingTransactions[id]; !ok {
+	td, ok := c.pendingTransactions[id]
+	if !ok {
 		c.log.Warn("unknown transaction marked as confirmed", "id", id, "block", inclusionBlock)
 		// TODO: This can occur if we clear the channel while there are still pending transactions
 		// We need to keep track of stale transactions instead
 		return false
 	}
+	c.confirmedTxIDs[id] = td.ID()
 	delete(c.pendingTransactions, id)
 	c.confirmedTransactions[id] = inclusionBlock
 	c.channelBuilder.FramePublished(inclusionBlock.Number)
@@ -221,3 +295,40 @@ func (c *channel) Close() {
 func (c *channel) MaxInclusionBlock() uint64 {
 	return c.maxInclusionBlock
 }
+
+// State returns a snapshot of the channel for inspection over RPC.
+func (c *channel) State() rpc.ChannelState {
+	st := rpc.ChannelState{
+		ID:             c.ID(),
+		IsFull:         c.IsFull(),
+		UseBlobs:       c.cfg.UseBlobs,
+		TotalFrames:    c.TotalFrames(),
+		PendingFrames:  c.PendingFrames(),
+		InputBytes:     c.InputBytes(),
+		OutputBytes:    c.OutputBytes(),
+		PendingTxs:     make([]string, 0, len(c.pendingTransactions)),
+		ConfirmedTxs:   make([]rpc.ConfirmedTx, 0, len(c.confirmedTransactions)),
+		OldestL1Origin: c.OldestL1Origin(),
+		LatestL1Origin: c.LatestL1Origin(),
+		OldestL2:       c.OldestL2(),
+		LatestL2:       c.LatestL2(),
+		Timeout:        c.Timeout(),
+	}
+	if err := c.FullErr(); err != nil {
+		st.FullReason = err.Error()
+	}
+	for id := range c.pendingTransactions {
+		st.PendingTxs = append(st.PendingTxs, id)
+	}
+	slices.Sort(st.PendingTxs)
+	for id, block := range c.confirmedTransactions {
+		st.ConfirmedTxs = append(st.ConfirmedTxs, rpc.ConfirmedTx{ID: id, InclusionBlock: block})
+	}
+	slices.SortFunc(st.ConfirmedTxs, func(a, b rpc.ConfirmedTx) int {
+		if a.InclusionBlock.Number != b.InclusionBlock.Number {
+			return cmp.Compare(a.InclusionBlock.Number, b.InclusionBlock.Number)
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return st
+}
@@ -458,6 +458,8 @@ func ChannelBuilder_OutputFrames_SpanBatch(t *testing.T, algo derive.Compression
 	channelConfig.MaxFrameSize = 20 + derive.FrameV0OverHeadSize
 	if algo.IsBrotli() {
 		channelConfig.TargetNumFrames = 3
+	} else if algo == derive.Zstd {
+		channelConfig.TargetNumFrames = 1
 	} else {
 		channelConfig.TargetNumFrames = 5
 	}
@@ -2,9 +2,12 @@ package batcher
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
@@ -27,6 +30,10 @@ type (
 		blobConfig     ChannelConfig
 		calldataConfig ChannelConfig
 		lastConfig     *ChannelConfig
+
+		pinMutex    sync.Mutex // guards pinned and pinnedUntil
+		pinned      *ChannelConfig
+		pinnedUntil time.Time // zero if the pin doesn't expire
 	}
 )
 
@@ -51,6 +58,12 @@ func NewDynamicEthChannelConfig(lgr log.Logger,
 // the appropriate ChannelConfig depending on which is cheaper. It makes
 // assumptions about the typical makeup of channel data.
 func (dec *DynamicEthChannelConfig) ChannelConfig(isPectra bool) ChannelConfig {
+	if pinned := dec.pinnedConfig(); pinned != nil {
+		dec.log.Debug("Using pinned channel config", "use_blobs", pinned.UseBlobs)
+		dec.lastConfig = pinned
+		return *pinned
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), dec.timeout)
 	defer cancel()
 	tipCap, baseFee, blobBaseFee, err := dec.gasPricer.SuggestGasPriceCaps(ctx)
@@ -101,6 +114,45 @@ func (dec *DynamicEthChannelConfig) ChannelConfig(isPectra bool) ChannelConfig {
 	return dec.blobConfig
 }
 
+// PinDAType makes ChannelConfig return the blob or calldata config, regardless of market
+// conditions, until the given duration has passed. A zero duration pins indefinitely.
+// Pinning to [flags.AutoType] removes the pin.
+func (dec *DynamicEthChannelConfig) PinDAType(daType flags.DataAvailabilityType, duration time.Duration) error {
+	dec.pinMutex.Lock()
+	defer dec.pinMutex.Unlock()
+	switch daType {
+	case flags.BlobsType:
+		dec.pinned = &dec.blobConfig
+	case flags.CalldataType:
+		dec.pinned = &dec.calldataConfig
+	case flags.AutoType:
+		dec.pinned = nil
+		dec.pinnedUntil = time.Time{}
+		dec.log.Info("Unpinned data availability type")
+		return nil
+	default:
+		return fmt.Errorf("unknown data availability type: %q", daType)
+	}
+	dec.pinnedUntil = time.Time{}
+	if duration > 0 {
+		dec.pinnedUntil = time.Now().Add(duration)
+	}
+	dec.log.Info("Pinned data availability type", "da_type", daType, "duration", duration)
+	return nil
+}
+
+// pinnedConfig returns the pinned config, or nil if there is no pin or it expired.
+func (dec *DynamicEthChannelConfig) pinnedConfig() *ChannelConfig {
+	dec.pinMutex.Lock()
+	defer dec.pinMutex.Unlock()
+	if dec.pinned != nil && !dec.pinnedUntil.IsZero() && time.Now().After(dec.pinnedUntil) {
+		dec.log.Info("Data availability type pin expired", "use_blobs", dec.pinned.UseBlobs)
+		dec.pinned = nil
+		dec.pinnedUntil = time.Time{}
+	}
+	return dec.pinned
+}
+
 func computeSingleCalldataTxCost(numTokens uint64, baseFee, tipCap *big.Int, isPectra bool) *big.Int {
 	// We assume isContractCreation = false and execution_gas_used = 0 in https://eips.ethereum.org/EIPS/eip-7623
 	// This is a safe assumption given how batcher transactions are constructed.
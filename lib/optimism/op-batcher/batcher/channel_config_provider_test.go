@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/stretchr/testify/require"
@@ -156,6 +157,29 @@ func TestDynamicEthChannelConfig_ChannelConfig(t *testing.T) {
 	})
 }
 
+func TestDynamicEthChannelConfig_PinDAType(t *testing.T) {
+	calldataCfg := ChannelConfig{MaxFrameSize: 120_000 - 1, TargetNumFrames: 1}
+	blobCfg := ChannelConfig{MaxFrameSize: eth.MaxBlobDataSize - 1, TargetNumFrames: 3, UseBlobs: true}
+	// blobs are much cheaper
+	gp := &mockGasPricer{tipCap: 1e3, baseFee: 1e6, blobBaseFee: 1}
+	dec := NewDynamicEthChannelConfig(testlog.Logger(t, slog.LevelInfo), 1*time.Second, gp, blobCfg, calldataCfg)
+	require.Equal(t, blobCfg, dec.ChannelConfig(false))
+
+	require.NoError(t, dec.PinDAType(flags.CalldataType, 0))
+	require.Equal(t, calldataCfg, dec.ChannelConfig(false))
+	require.Same(t, &dec.calldataConfig, dec.lastConfig)
+
+	require.NoError(t, dec.PinDAType(flags.AutoType, 0))
+	require.Equal(t, blobCfg, dec.ChannelConfig(false))
+
+	require.NoError(t, dec.PinDAType(flags.CalldataType, time.Nanosecond))
+	time.Sleep(time.Millisecond)
+	require.Equal(t, blobCfg, dec.ChannelConfig(false), "pin should have expired")
+	require.Nil(t, dec.pinned)
+
+	require.Error(t, dec.PinDAType("foo", 0))
+}
+
 func TestComputeSingleCalldataTxCost(t *testing.T) {
 	// 30KB of data
 	got := computeSingleCalldataTxCost(120_000, big.NewInt(1), big.NewInt(1), false)
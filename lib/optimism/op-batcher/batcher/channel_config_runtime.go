@@ -0,0 +1,274 @@
+package batcher
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+var (
+	// ErrDATypeNotDynamic is returned when pinning the data availability type of a batcher that was not started
+	// with data availability type auto, or when switching such a batcher back to auto. A static batcher can switch
+	// its data availability type with a channel config update instead.
+	ErrDATypeNotDynamic = errors.New("data availability type can only be pinned if the batcher runs with --data-availability-type=auto")
+	// ErrDATypeUnavailable is returned when switching to a data availability type that the batcher has no channel config for,
+	// like blobs before Ecotone or with Alt-DA.
+	ErrDATypeUnavailable = errors.New("data availability type is not available")
+)
+
+// RuntimeChannelConfig is a ChannelConfigProvider that wraps another provider
+// and applies channel config overrides that are set at runtime, e.g. over the
+// admin RPC. Overrides are applied to every config returned by the wrapped
+// provider, so they take effect from the next channel onward.
+//
+// The data availability type can be switched with an update to any type that there is a channel config for.
+// Once switched, the channel config of that type is used instead of the wrapped provider.
+type RuntimeChannelConfig struct {
+	log       log.Logger
+	inner     ChannelConfigProvider
+	rollupCfg *rollup.Config
+
+	mutex     sync.Mutex // guards overrides and daConfigs
+	overrides rpc.ChannelConfigUpdate
+	daConfigs map[flags.DataAvailabilityType]ChannelConfig
+}
+
+func NewRuntimeChannelConfig(lgr log.Logger, inner ChannelConfigProvider, rollupCfg *rollup.Config) *RuntimeChannelConfig {
+	daConfigs := make(map[flags.DataAvailabilityType]ChannelConfig)
+	for _, cc := range baseChannelConfigs(inner) {
+		daConfigs[daTypeOf(cc)] = cc
+	}
+	return &RuntimeChannelConfig{
+		log:       lgr,
+		inner:     inner,
+		rollupCfg: rollupCfg,
+		daConfigs: daConfigs,
+	}
+}
+
+// SetDAChannelConfigs sets the channel configs of the data availability types that can be switched to.
+// By default, these are the configs of the wrapped provider.
+func (r *RuntimeChannelConfig) SetDAChannelConfigs(configs map[flags.DataAvailabilityType]ChannelConfig) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.daConfigs = configs
+}
+
+// ChannelConfig returns the config of the wrapped provider, or of the data availability type that was switched to,
+// with the current overrides applied.
+func (r *RuntimeChannelConfig) ChannelConfig(isPectra bool) ChannelConfig {
+	r.mutex.Lock()
+	overrides := r.overrides
+	var cc ChannelConfig
+	if overrides.DAType != nil {
+		cc = r.daConfigs[flags.DataAvailabilityType(*overrides.DAType)]
+	}
+	r.mutex.Unlock()
+	if overrides.DAType == nil {
+		cc = r.inner.ChannelConfig(isPectra)
+	}
+	return applyChannelConfigUpdate(cc, overrides)
+}
+
+// Overrides returns the currently applied overrides.
+func (r *RuntimeChannelConfig) Overrides() rpc.ChannelConfigUpdate {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.overrides
+}
+
+// Update merges the given update into the current overrides. The resulting
+// overrides are validated against the configs of all data availability types
+// and are only applied if they are valid for all of them.
+func (r *RuntimeChannelConfig) Update(update rpc.ChannelConfigUpdate) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := r.checkDAType(update.DAType); err != nil {
+		return err
+	}
+	merged := mergeChannelConfigUpdates(r.overrides, update)
+	if merged.DAType != nil && flags.DataAvailabilityType(*merged.DAType) == flags.AutoType {
+		merged.DAType = nil
+	}
+	var bases []ChannelConfig
+	for _, daType := range r.availableDATypes() {
+		bases = append(bases, r.daConfigs[daType])
+	}
+	for _, base := range bases {
+		if err := r.check(applyChannelConfigUpdate(base, merged)); err != nil {
+			return fmt.Errorf("invalid channel config update (use_blobs=%t): %w", base.UseBlobs, err)
+		}
+	}
+	for _, base := range bases {
+		cc := applyChannelConfigUpdate(base, merged)
+		r.log.Info("Updated channel-config",
+			"use_blobs", cc.UseBlobs,
+			"target_num_frames", cc.TargetNumFrames,
+			"compressor", cc.CompressorConfig.Kind,
+			"compression_algo", cc.CompressorConfig.CompressionAlgo,
+			"approx_compr_ratio", cc.CompressorConfig.ApproxComprRatio,
+			"max_channel_duration", cc.MaxChannelDuration,
+			"sub_safety_margin", cc.SubSafetyMargin)
+	}
+	if update.DAType != nil {
+		r.log.Info("Switched data availability type", "da_type", *update.DAType)
+	}
+	r.overrides = merged
+	return nil
+}
+
+// checkDAType checks that the data availability type can be switched to.
+func (r *RuntimeChannelConfig) checkDAType(daType *string) error {
+	if daType == nil {
+		return nil
+	}
+	switch t := flags.DataAvailabilityType(*daType); t {
+	case flags.AutoType:
+		if _, ok := r.inner.(*DynamicEthChannelConfig); !ok {
+			return fmt.Errorf("%w, switch to %s or %s instead", ErrDATypeNotDynamic, flags.BlobsType, flags.CalldataType)
+		}
+	case flags.BlobsType, flags.CalldataType:
+		if _, ok := r.daConfigs[t]; !ok {
+			return fmt.Errorf("%w: %s, the batcher can use %v", ErrDATypeUnavailable, t, r.availableDATypes())
+		}
+	default:
+		return fmt.Errorf("unknown data availability type: %q", t)
+	}
+	return nil
+}
+
+func (r *RuntimeChannelConfig) availableDATypes() []flags.DataAvailabilityType {
+	var out []flags.DataAvailabilityType
+	for _, daType := range []flags.DataAvailabilityType{flags.BlobsType, flags.CalldataType} {
+		if _, ok := r.daConfigs[daType]; ok {
+			out = append(out, daType)
+		}
+	}
+	return out
+}
+
+// PinDAType pins the data availability type of the wrapped provider,
+// see [DynamicEthChannelConfig.PinDAType]. It returns ErrDATypeNotDynamic
+// if the wrapped provider doesn't choose the DA type dynamically.
+// A data availability type that was switched to with Update takes precedence over the pin.
+func (r *RuntimeChannelConfig) PinDAType(daType flags.DataAvailabilityType, duration time.Duration) error {
+	dec, ok := r.inner.(*DynamicEthChannelConfig)
+	if !ok {
+		current := flags.CalldataType
+		if r.inner.ChannelConfig(false).UseBlobs {
+			current = flags.BlobsType
+		}
+		return fmt.Errorf("%w, but runs with static type %s", ErrDATypeNotDynamic, current)
+	}
+	return dec.PinDAType(daType, duration)
+}
+
+func (r *RuntimeChannelConfig) check(cc ChannelConfig) error {
+	if err := cc.Check(); err != nil {
+		return err
+	}
+	if _, ok := compressor.Kinds[cc.CompressorConfig.Kind]; !ok && cc.CompressorConfig.Kind != "" {
+		return fmt.Errorf("unknown compressor kind %q", cc.CompressorConfig.Kind)
+	}
+	if cc.CompressorConfig.Kind == compressor.RatioKind &&
+		(cc.CompressorConfig.ApproxComprRatio <= 0 || cc.CompressorConfig.ApproxComprRatio > 1) {
+		return fmt.Errorf("invalid ApproxComprRatio %v for ratio compressor", cc.CompressorConfig.ApproxComprRatio)
+	}
+	if !derive.ValidCompressionAlgo(cc.CompressorConfig.CompressionAlgo) {
+		return fmt.Errorf("invalid compression algo %v", cc.CompressorConfig.CompressionAlgo)
+	}
+	if cc.CompressorConfig.CompressionAlgo.IsBrotli() && !r.rollupCfg.IsFjord(uint64(time.Now().Unix())) {
+		return errors.New("cannot use brotli compression before Fjord")
+	}
+	if cc.CompressorConfig.CompressionAlgo == derive.Zstd && !r.rollupCfg.IsZstdChannel(uint64(time.Now().Unix())) {
+		return errors.New("cannot use zstd compression before zstd channel activation")
+	}
+	if cc.UseBlobs && cc.TargetNumFrames > maxBlobsPerBlock {
+		return fmt.Errorf("too many frames for blob transactions, max %d", maxBlobsPerBlock)
+	}
+	return nil
+}
+
+func daTypeOf(cc ChannelConfig) flags.DataAvailabilityType {
+	if cc.UseBlobs {
+		return flags.BlobsType
+	}
+	return flags.CalldataType
+}
+
+// baseChannelConfigs returns all configs the given provider may return.
+func baseChannelConfigs(p ChannelConfigProvider) []ChannelConfig {
+	switch p := p.(type) {
+	case ChannelConfig:
+		return []ChannelConfig{p}
+	case *DynamicEthChannelConfig:
+		return []ChannelConfig{p.blobConfig, p.calldataConfig}
+	default:
+		return []ChannelConfig{p.ChannelConfig(false)}
+	}
+}
+
+// mergeChannelConfigUpdates returns the update a, with all fields set in b replaced.
+func mergeChannelConfigUpdates(a, b rpc.ChannelConfigUpdate) rpc.ChannelConfigUpdate {
+	if b.CompressorKind != nil {
+		a.CompressorKind = b.CompressorKind
+	}
+	if b.CompressionAlgo != nil {
+		a.CompressionAlgo = b.CompressionAlgo
+	}
+	if b.ApproxComprRatio != nil {
+		a.ApproxComprRatio = b.ApproxComprRatio
+	}
+	if b.TargetNumFrames != nil {
+		a.TargetNumFrames = b.TargetNumFrames
+	}
+	if b.MaxChannelDuration != nil {
+		a.MaxChannelDuration = b.MaxChannelDuration
+	}
+	if b.SubSafetyMargin != nil {
+		a.SubSafetyMargin = b.SubSafetyMargin
+	}
+	if b.DAType != nil {
+		a.DAType = b.DAType
+	}
+	return a
+}
+
+// applyChannelConfigUpdate returns a copy of cc with the update applied.
+// The compressor config is only reinitialized if the update affects it.
+func applyChannelConfigUpdate(cc ChannelConfig, u rpc.ChannelConfigUpdate) ChannelConfig {
+	if u.MaxChannelDuration != nil {
+		cc.MaxChannelDuration = *u.MaxChannelDuration
+	}
+	if u.SubSafetyMargin != nil {
+		cc.SubSafetyMargin = *u.SubSafetyMargin
+	}
+	if u.TargetNumFrames == nil && u.CompressorKind == nil && u.CompressionAlgo == nil && u.ApproxComprRatio == nil {
+		return cc
+	}
+	if u.TargetNumFrames != nil {
+		cc.TargetNumFrames = *u.TargetNumFrames
+	}
+	comprCfg := cc.CompressorConfig
+	if u.CompressorKind != nil {
+		comprCfg.Kind = *u.CompressorKind
+	}
+	if u.CompressionAlgo != nil {
+		comprCfg.CompressionAlgo = *u.CompressionAlgo
+	}
+	if u.ApproxComprRatio != nil {
+		comprCfg.ApproxComprRatio = *u.ApproxComprRatio
+	}
+	cc.InitCompressorConfig(comprCfg.ApproxComprRatio, comprCfg.Kind, comprCfg.CompressionAlgo)
+	return cc
+}
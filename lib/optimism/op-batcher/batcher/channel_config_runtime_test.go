@@ -0,0 +1,150 @@
+package batcher
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func runtimeTestChannelConfig() ChannelConfig {
+	cfg := ChannelConfig{
+		ChannelTimeout:  50,
+		SubSafetyMargin: 4,
+		MaxFrameSize:    eth.MaxBlobDataSize - 1,
+		TargetNumFrames: 3,
+		UseBlobs:        true,
+	}
+	cfg.InitRatioCompressor(0.4, derive.Zlib)
+	return cfg
+}
+
+func TestRuntimeChannelConfig_Update(t *testing.T) {
+	base := runtimeTestChannelConfig()
+	r := NewRuntimeChannelConfig(testlog.Logger(t, slog.LevelInfo), base, defaultTestRollupConfig)
+
+	// no overrides returns the wrapped config unchanged
+	require.Equal(t, base, r.ChannelConfig(false))
+
+	numFrames, duration := 5, uint64(10)
+	require.NoError(t, r.Update(rpc.ChannelConfigUpdate{
+		TargetNumFrames:    &numFrames,
+		MaxChannelDuration: &duration,
+	}))
+	cc := r.ChannelConfig(false)
+	require.Equal(t, 5, cc.TargetNumFrames)
+	require.Equal(t, uint64(10), cc.MaxChannelDuration)
+	require.Equal(t, MaxDataSize(5, base.MaxFrameSize), cc.CompressorConfig.TargetOutputSize)
+	require.Equal(t, base.CompressorConfig.ApproxComprRatio, cc.CompressorConfig.ApproxComprRatio)
+
+	// updates are merged into previous ones
+	kind := compressor.ShadowKind
+	require.NoError(t, r.Update(rpc.ChannelConfigUpdate{CompressorKind: &kind}))
+	cc = r.ChannelConfig(false)
+	require.Equal(t, 5, cc.TargetNumFrames)
+	require.Equal(t, compressor.ShadowKind, cc.CompressorConfig.Kind)
+
+	// invalid updates are rejected and leave the overrides untouched
+	margin := uint64(100) // larger than channel timeout
+	require.Error(t, r.Update(rpc.ChannelConfigUpdate{SubSafetyMargin: &margin}))
+	tooManyFrames := maxBlobsPerBlock + 1
+	require.Error(t, r.Update(rpc.ChannelConfigUpdate{TargetNumFrames: &tooManyFrames}))
+	badKind := "foo"
+	require.Error(t, r.Update(rpc.ChannelConfigUpdate{CompressorKind: &badKind}))
+	badAlgo := derive.CompressionAlgo("foo")
+	require.Error(t, r.Update(rpc.ChannelConfigUpdate{CompressionAlgo: &badAlgo}))
+	require.Equal(t, cc, r.ChannelConfig(false))
+	require.Equal(t, numFrames, *r.Overrides().TargetNumFrames)
+	require.Nil(t, r.Overrides().SubSafetyMargin)
+}
+
+func TestRuntimeChannelConfig_PinDAType(t *testing.T) {
+	lgr := testlog.Logger(t, slog.LevelInfo)
+	blobCfg := runtimeTestChannelConfig()
+	calldataCfg := blobCfg
+	calldataCfg.MaxFrameSize = 120_000 - 1
+	calldataCfg.TargetNumFrames = 1
+	calldataCfg.UseBlobs = false
+	calldataCfg.ReinitCompressorConfig()
+
+	static := NewRuntimeChannelConfig(lgr, blobCfg, defaultTestRollupConfig)
+	err := static.PinDAType(flags.CalldataType, 0)
+	require.ErrorIs(t, err, ErrDATypeNotDynamic)
+	require.ErrorContains(t, err, "static type blobs")
+
+	// blobs are much cheaper
+	gp := &mockGasPricer{tipCap: 1e3, baseFee: 1e6, blobBaseFee: 1}
+	dec := NewDynamicEthChannelConfig(lgr, time.Second, gp, blobCfg, calldataCfg)
+	r := NewRuntimeChannelConfig(lgr, dec, defaultTestRollupConfig)
+	require.True(t, r.ChannelConfig(false).UseBlobs)
+	require.NoError(t, r.PinDAType(flags.CalldataType, 0))
+	require.False(t, r.ChannelConfig(false).UseBlobs)
+
+	// updates are validated against both, the blob and calldata config
+	numFrames := maxBlobsPerBlock + 1
+	require.Error(t, r.Update(rpc.ChannelConfigUpdate{TargetNumFrames: &numFrames}))
+}
+
+func TestRuntimeChannelConfig_UpdateDAType(t *testing.T) {
+	lgr := testlog.Logger(t, slog.LevelInfo)
+	blobCfg := runtimeTestChannelConfig()
+	calldataCfg := blobCfg
+	calldataCfg.MaxFrameSize = 120_000 - 1
+	calldataCfg.TargetNumFrames = 1
+	calldataCfg.UseBlobs = false
+	calldataCfg.ReinitCompressorConfig()
+	daType := func(t flags.DataAvailabilityType) *string {
+		s := string(t)
+		return &s
+	}
+
+	t.Run("Static", func(t *testing.T) {
+		r := NewRuntimeChannelConfig(lgr, blobCfg, defaultTestRollupConfig)
+		// without other configs, only the static type is available
+		err := r.Update(rpc.ChannelConfigUpdate{DAType: daType(flags.CalldataType)})
+		require.ErrorIs(t, err, ErrDATypeUnavailable)
+		require.ErrorContains(t, err, "[blobs]")
+
+		r.SetDAChannelConfigs(map[flags.DataAvailabilityType]ChannelConfig{
+			flags.BlobsType:    blobCfg,
+			flags.CalldataType: calldataCfg,
+		})
+		require.NoError(t, r.Update(rpc.ChannelConfigUpdate{DAType: daType(flags.CalldataType)}))
+		require.Equal(t, calldataCfg, r.ChannelConfig(false))
+
+		// overrides apply to the config of the switched type
+		duration := uint64(10)
+		require.NoError(t, r.Update(rpc.ChannelConfigUpdate{MaxChannelDuration: &duration}))
+		cc := r.ChannelConfig(false)
+		require.False(t, cc.UseBlobs)
+		require.Equal(t, duration, cc.MaxChannelDuration)
+
+		require.ErrorIs(t, r.Update(rpc.ChannelConfigUpdate{DAType: daType(flags.AutoType)}), ErrDATypeNotDynamic)
+		require.ErrorContains(t, r.Update(rpc.ChannelConfigUpdate{DAType: daType("foo")}), "unknown data availability type")
+		require.NoError(t, r.Update(rpc.ChannelConfigUpdate{DAType: daType(flags.BlobsType)}))
+		require.True(t, r.ChannelConfig(false).UseBlobs)
+	})
+
+	t.Run("Dynamic", func(t *testing.T) {
+		// blobs are much cheaper
+		gp := &mockGasPricer{tipCap: 1e3, baseFee: 1e6, blobBaseFee: 1}
+		dec := NewDynamicEthChannelConfig(lgr, time.Second, gp, blobCfg, calldataCfg)
+		r := NewRuntimeChannelConfig(lgr, dec, defaultTestRollupConfig)
+		require.NoError(t, r.Update(rpc.ChannelConfigUpdate{DAType: daType(flags.CalldataType)}))
+		require.False(t, r.ChannelConfig(false).UseBlobs)
+		require.Equal(t, string(flags.CalldataType), *r.Overrides().DAType)
+
+		// switching to auto chooses the type by fees again
+		require.NoError(t, r.Update(rpc.ChannelConfigUpdate{DAType: daType(flags.AutoType)}))
+		require.Nil(t, r.Overrides().DAType)
+		require.True(t, r.ChannelConfig(false).UseBlobs)
+	})
+}
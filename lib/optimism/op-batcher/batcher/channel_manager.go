@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"slices"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -18,6 +20,9 @@ import (
 
 var ErrReorg = errors.New("block does not extend existing chain")
 
+// maxChannelCosts is the number of most recent channel costs kept for inspection over RPC.
+const maxChannelCosts = 1000
+
 type ChannelOutFactory func(cfg ChannelConfig, rollupCfg *rollup.Config) (derive.ChannelOut, error)
 
 // channelManager stores a contiguous set of blocks & turns them into channels.
@@ -55,6 +60,12 @@ type channelManager struct {
 	channelQueue []*channel
 	// used to lookup channels by tx ID upon tx success / failure
 	txChannels map[string]*channel
+
+	// journal persists full channels to disk, nil if journaling is disabled
+	journal *channelJournal
+
+	// L1 costs of the most recently fully submitted or timed out channels, oldest first
+	channelCosts []rpc.ChannelCost
 }
 
 func NewChannelManager(log log.Logger, metr metrics.Metricer, cfgProvider ChannelConfigProvider, rollupCfg *rollup.Config) *channelManager {
@@ -73,6 +84,11 @@ func (s *channelManager) SetChannelOutFactory(outFactory ChannelOutFactory) {
 	s.outFactory = outFactory
 }
 
+// SetJournal enables journaling of full channels to the given directory.
+func (s *channelManager) SetJournal(dir string) {
+	s.journal = newChannelJournal(s.log, dir)
+}
+
 // Clear clears the entire state of the channel manager.
 // It is intended to be used before launching op-batcher and after an L2 reorg.
 func (s *channelManager) Clear(l1OriginLastSubmittedChannel eth.BlockID) {
@@ -88,6 +104,79 @@ func (s *channelManager) Clear(l1OriginLastSubmittedChannel eth.BlockID) {
 	s.metr.ClearAllStateMetrics()
 
 	s.txChannels = make(map[string]*channel)
+	// The journal is kept, so that channels of L2 blocks that weren't reorged can be restored.
+}
+
+// journalChannel writes the given channel to the journal, if journaling is enabled.
+// Journal errors are not fatal, they only affect resuming the channel after a restart.
+func (s *channelManager) journalChannel(c *channel) {
+	if s.journal != nil {
+		s.journal.WriteChannel(c)
+	}
+}
+
+// unjournalChannel removes the given channel from the journal, if journaling is enabled.
+func (s *channelManager) unjournalChannel(c *channel) {
+	if s.journal != nil {
+		s.journal.DeleteChannel(c.ID())
+	}
+}
+
+// RecordTxPublished records the L1 tx hash of a published version of the given pending
+// transaction in the journal, so it can be found on L1 after a restart.
+// It doesn't access the channel manager state, so it's safe to call without holding its lock,
+// and it doesn't block on disk I/O.
+func (s *channelManager) RecordTxPublished(id txID, hash common.Hash) {
+	if s.journal != nil {
+		s.journal.AddSentTxHash(id, hash)
+	}
+}
+
+// RecordTxHash records the L1 tx hash of the given transaction in the journal.
+// It should be called before the transaction is marked as confirmed.
+func (s *channelManager) RecordTxHash(id txID, hash common.Hash) {
+	if s.journal != nil {
+		s.journal.SetTxHash(id, hash)
+	}
+}
+
+// RecordTxCost records the L1 cost of the given transaction with its channel.
+// It should be called before the transaction is marked as confirmed.
+func (s *channelManager) RecordTxCost(_id txID, receipt *types.Receipt) {
+	if channel, ok := s.txChannels[_id.String()]; ok {
+		channel.RecordTxCost(receipt)
+	}
+}
+
+// recordChannelCost logs, records metrics for and stores the L1 cost of the given channel.
+func (s *channelManager) recordChannelCost(c *channel, timedOut bool) {
+	cost := c.Cost()
+	cost.TimedOut = timedOut
+	s.log.Info("Channel L1 cost",
+		"id", cost.ID,
+		"timed_out", cost.TimedOut,
+		"incomplete", cost.Incomplete,
+		"num_l1_txs", cost.NumL1Txs,
+		"gas_used", cost.GasUsed,
+		"blob_gas_used", cost.BlobGasUsed,
+		"execution_cost", cost.ExecutionCost.ToInt(),
+		"blob_cost", cost.BlobCost.ToInt(),
+		"total_cost", cost.TotalCost.ToInt(),
+		"num_l2_blocks", cost.NumL2Blocks,
+		"num_l2_txs", cost.NumL2Txs,
+		"input_bytes", cost.InputBytes,
+		"cost_per_l2_byte", cost.CostPerL2Byte,
+	)
+	s.metr.RecordChannelCost(cost.ExecutionCost.ToInt(), cost.BlobCost.ToInt(), cost.CostPerL2Byte)
+	if len(s.channelCosts) >= maxChannelCosts {
+		s.channelCosts = s.channelCosts[1:]
+	}
+	s.channelCosts = append(s.channelCosts, cost)
+}
+
+// ChannelCosts returns the L1 costs of the most recently fully submitted or timed out channels, oldest first.
+func (s *channelManager) ChannelCosts() []rpc.ChannelCost {
+	return slices.Clone(s.channelCosts)
 }
 
 func (s *channelManager) pendingBlocks() int {
@@ -101,6 +190,10 @@ func (s *channelManager) TxFailed(_id txID) {
 	if channel, ok := s.txChannels[id]; ok {
 		delete(s.txChannels, id)
 		channel.TxFailed(id)
+		if s.journal != nil {
+			s.journal.ForgetTx(_id)
+		}
+		s.journalChannel(channel)
 	} else {
 		s.log.Warn("transaction from unknown channel marked as failed", "id", id)
 	}
@@ -115,7 +208,13 @@ func (s *channelManager) TxConfirmed(_id txID, inclusionBlock eth.BlockID) {
 		delete(s.txChannels, id)
 		if timedOut := channel.TxConfirmed(id, inclusionBlock); timedOut {
 			s.log.Warn("channel timed out on chain", "channel_id", channel.ID(), "tx_id", id)
+			s.recordChannelCost(channel, true)
 			s.handleChannelInvalidated(channel)
+		} else {
+			if channel.isFullySubmitted() {
+				s.recordChannelCost(channel, false)
+			}
+			s.journalChannel(channel)
 		}
 	} else {
 		s.log.Warn("transaction from unknown channel marked as confirmed", "id", id)
@@ -189,6 +288,7 @@ func (s *channelManager) handleChannelInvalidated(c *channel) {
 				delete(s.txChannels, txID)
 			}
 		}
+		s.unjournalChannel(s.channelQueue[i])
 	}
 	s.channelQueue = s.channelQueue[:invalidatedChannelIdx]
 
@@ -213,6 +313,7 @@ func (s *channelManager) nextTxData(channel *channel) (txData, error) {
 		s.l1OriginLastSubmittedChannel = channel.LatestL1Origin()
 	}
 	s.txChannels[tx.ID().String()] = channel
+	s.journalChannel(channel)
 	return tx, nil
 }
 
@@ -243,6 +344,9 @@ func (s *channelManager) TxData(l1Head eth.BlockID, isPectra bool) (txData, erro
 	if newCfg.UseBlobs == s.defaultCfg.UseBlobs {
 		s.log.Debug("Recomputing optimal ChannelConfig: no need to switch DA type",
 			"useBlobs", s.defaultCfg.UseBlobs)
+		// Pick up any other config changes for the next channel,
+		// the current one keeps its config.
+		s.defaultCfg = newCfg
 		return s.nextTxData(channel)
 	}
 
@@ -459,6 +563,7 @@ func (s *channelManager) outputFrames() error {
 		"full_reason", s.currentChannel.FullErr(),
 		"compr_ratio", comprRatio,
 	)
+	s.journalChannel(s.currentChannel)
 	return nil
 }
 
@@ -509,6 +614,7 @@ func (s *channelManager) PruneChannels(num int) {
 		if s.channelQueue[i] == s.currentChannel {
 			clearCurrentChannel = true
 		}
+		s.unjournalChannel(s.channelQueue[i])
 	}
 	s.channelQueue = s.channelQueue[num:]
 	s.metr.RecordChannelQueueLength(len(s.channelQueue))
@@ -537,3 +643,26 @@ func (m *channelManager) LastStoredBlock() eth.BlockID {
 	}
 	return eth.ToBlockID(m.blocks[m.blocks.Len()-1])
 }
+
+// State returns a snapshot of the channel manager's block queue and channels for inspection over RPC.
+func (s *channelManager) State() rpc.ChannelManagerState {
+	st := rpc.ChannelManagerState{
+		Blocks: rpc.BlockQueueState{
+			Total:   s.blocks.Len(),
+			Pending: s.pendingBlocks(),
+		},
+		L1OriginLastSubmittedChannel: s.l1OriginLastSubmittedChannel,
+		Channels:                     make([]rpc.ChannelState, 0, len(s.channelQueue)),
+		PendingDABytes:               s.PendingDABytes(),
+	}
+	if s.blocks.Len() > 0 {
+		st.Blocks.Oldest = eth.ToBlockID(s.blocks[0])
+		st.Blocks.Latest = s.LastStoredBlock()
+	}
+	for _, ch := range s.channelQueue {
+		cs := ch.State()
+		cs.IsCurrent = ch == s.currentChannel
+		st.Channels = append(st.Channels, cs)
+	}
+	return st
+}
@@ -670,3 +670,107 @@ func TestChannelManager_ChannelOutFactory(t *testing.T) {
 
 	require.IsType(t, &ChannelOutWrapper{}, m.currentChannel.channelBuilder.co)
 }
+
+func TestChannelManager_State(t *testing.T) {
+	l := testlog.Logger(t, log.LevelCrit)
+	cfg := channelManagerTestConfig(derive.FrameV0OverHeadSize+1, derive.SingularBatchType)
+	cfg.ChannelTimeout = 10
+	m := NewChannelManager(l, metrics.NoopMetrics, cfg, defaultTestRollupConfig)
+
+	st := m.State()
+	require.Zero(t, st.Blocks.Total)
+	require.Equal(t, eth.BlockID{}, st.Blocks.Oldest)
+	require.Empty(t, st.Channels)
+
+	rng := rand.New(rand.NewSource(99))
+	blockA := derivetest.RandomL2BlockWithChainId(rng, 10, defaultTestRollupConfig.L2ChainID)
+	blockB := derivetest.RandomL2BlockWithChainId(rng, 10, defaultTestRollupConfig.L2ChainID)
+	m.blocks = queue.Queue[*types.Block]{blockA, blockB}
+
+	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	require.NoError(t, m.processBlocks())
+	require.NoError(t, m.outputFrames())
+	txdata, err := m.nextTxData(m.currentChannel)
+	require.NoError(t, err)
+	inclusion := eth.BlockID{Number: 3, Hash: common.Hash{0x03}}
+	m.TxConfirmed(txdata.ID(), inclusion)
+	txdata2, err := m.nextTxData(m.currentChannel)
+	require.NoError(t, err)
+
+	st = m.State()
+	require.Equal(t, 2, st.Blocks.Total)
+	require.Equal(t, 2-m.blockCursor, st.Blocks.Pending)
+	require.Equal(t, eth.ToBlockID(blockA), st.Blocks.Oldest)
+	require.Equal(t, eth.ToBlockID(blockB), st.Blocks.Latest)
+	require.Len(t, st.Channels, 1)
+
+	cs := st.Channels[0]
+	require.Equal(t, m.currentChannel.ID(), cs.ID)
+	require.True(t, cs.IsCurrent)
+	require.Equal(t, m.currentChannel.TotalFrames(), cs.TotalFrames)
+	require.Equal(t, m.currentChannel.PendingFrames(), cs.PendingFrames)
+	require.Equal(t, []string{txdata2.ID().String()}, cs.PendingTxs)
+	require.Len(t, cs.ConfirmedTxs, 1)
+	require.Equal(t, txdata.ID().String(), cs.ConfirmedTxs[0].ID)
+	require.Equal(t, inclusion, cs.ConfirmedTxs[0].InclusionBlock)
+	require.Equal(t, m.currentChannel.Timeout(), cs.Timeout)
+}
+
+func TestChannelManager_ChannelCosts(t *testing.T) {
+	l := testlog.Logger(t, log.LevelCrit)
+	cfg := channelManagerTestConfig(100, derive.SingularBatchType)
+	cfg.ChannelTimeout = 100
+	m := NewChannelManager(l, metrics.NoopMetrics, cfg, defaultTestRollupConfig)
+
+	rng := rand.New(rand.NewSource(123))
+	blockA := derivetest.RandomL2BlockWithChainId(rng, 10, defaultTestRollupConfig.L2ChainID)
+	blockB := derivetest.RandomL2BlockWithChainId(rng, 10, defaultTestRollupConfig.L2ChainID)
+	m.blocks = queue.Queue[*types.Block]{blockA, blockB}
+
+	// drain all tx data of the first channel
+	var txs []txData
+	for {
+		txdata, err := m.TxData(eth.BlockID{}, false)
+		require.NoError(t, err)
+		txs = append(txs, txdata)
+		if !m.channelQueue[0].HasTxData() {
+			break
+		}
+	}
+	ch := m.channelQueue[0]
+	require.True(t, ch.IsFull())
+
+	for i, tx := range txs {
+		receipt := &types.Receipt{
+			GasUsed:           21_000,
+			EffectiveGasPrice: big.NewInt(2),
+			BlobGasUsed:       10,
+			BlobGasPrice:      big.NewInt(3),
+		}
+		m.RecordTxCost(tx.ID(), receipt)
+		require.Empty(t, m.ChannelCosts(), "no cost before the channel is fully submitted")
+		m.TxConfirmed(tx.ID(), eth.BlockID{Number: uint64(i + 1)})
+	}
+
+	costs := m.ChannelCosts()
+	require.Len(t, costs, 1)
+	cost := costs[0]
+	n := uint64(len(txs))
+	require.Equal(t, ch.ID(), cost.ID)
+	require.False(t, cost.TimedOut)
+	require.False(t, cost.Incomplete)
+	require.Equal(t, len(txs), cost.NumL1Txs)
+	require.Equal(t, n*21_000, cost.GasUsed)
+	require.Equal(t, n*10, cost.BlobGasUsed)
+	require.Equal(t, new(big.Int).SetUint64(n*42_000), cost.ExecutionCost.ToInt())
+	require.Equal(t, new(big.Int).SetUint64(n*30), cost.BlobCost.ToInt())
+	require.Equal(t, new(big.Int).SetUint64(n*42_030), cost.TotalCost.ToInt())
+	require.Equal(t, len(ch.channelBuilder.Blocks()), cost.NumL2Blocks)
+	numL2Txs := 0
+	for _, b := range ch.channelBuilder.Blocks() {
+		numL2Txs += len(b.Transactions()) - 1 // L1 info deposit
+	}
+	require.Equal(t, numL2Txs, cost.NumL2Txs)
+	require.Equal(t, ch.InputBytes(), cost.InputBytes)
+	require.InDelta(t, float64(n*42_030)/float64(ch.InputBytes()), cost.CostPerL2Byte, 1e-9)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -28,6 +29,12 @@ type CLIConfig struct {
 	// L1EthRpc is the HTTP provider URL for L1.
 	L1EthRpc string
 
+	// L1ExtraEthRpcs are the HTTP provider URLs of additional L1 endpoints of the same chain, used together with L1EthRpc.
+	// See client.MultiClient for how L1MultiMode and L1Quorum are used.
+	L1ExtraEthRpcs []string
+	L1MultiMode    client.MultiMode
+	L1Quorum       int
+
 	// L2EthRpc is the HTTP provider URL for the L2 execution engine. A comma-separated list enables the active L2 provider. Such a list needs to match the number of RollupRpcs provided.
 	L2EthRpc string
 
@@ -109,10 +116,27 @@ type CLIConfig struct {
 	ThrottleBlockSize uint64
 	// ThrottleAlwaysBlockSize is the total per-block DA limit to always imposing on block building.
 	ThrottleAlwaysBlockSize uint64
+	// ThrottleController is one of the values defined in op-batcher/flags/types.go and selects the
+	// controller computing the DA limits from the pending bytes. Defaults to the step controller if empty.
+	ThrottleController flags.ThrottleControllerType
+	// ThrottlePIDKp, ThrottlePIDKi and ThrottlePIDKd are the gains of the pid throttle controller.
+	ThrottlePIDKp, ThrottlePIDKi, ThrottlePIDKd float64
 
 	// PreferLocalSafeL2 triggers the batcher to load blocks from the sequencer based on the LocalSafeL2 SyncStatus field (instead of the SafeL2 field).
 	PreferLocalSafeL2 bool
 
+	// DataDir is the directory to journal full channels to. Journaling is disabled if empty.
+	DataDir string
+
+	// AltDAFailoverThreshold and AltDAFailoverTimeout configure after how many consecutive failed
+	// requests, or how long of consecutively failing requests, to the Alt-DA server the batcher
+	// fails over to Ethereum DA of type AltDAFailoverDAType. Zero values disable them.
+	AltDAFailoverThreshold uint64
+	AltDAFailoverTimeout   time.Duration
+	AltDAFailoverDAType    flags.DataAvailabilityType
+	// AltDAFailoverProbeInterval is the interval at which the Alt-DA server is probed while failed over.
+	AltDAFailoverProbeInterval time.Duration
+
 	// TestUseMaxTxSizeForBlobs allows to set the blob size with MaxL1TxSize.
 	// Should only be used for testing purposes.
 	TestUseMaxTxSizeForBlobs bool
@@ -129,6 +153,11 @@ func (c *CLIConfig) Check() error {
 	if c.L1EthRpc == "" {
 		return errors.New("empty L1 RPC URL")
 	}
+	if len(c.L1ExtraEthRpcs) > 0 {
+		if err := c.L1MultiConfig().Check(1 + len(c.L1ExtraEthRpcs)); err != nil {
+			return fmt.Errorf("invalid L1 RPC endpoints: %w", err)
+		}
+	}
 	if c.L2EthRpc == "" {
 		return errors.New("empty L2 RPC URL")
 	}
@@ -168,6 +197,25 @@ func (c *CLIConfig) Check() error {
 	if c.DataAvailabilityType != flags.CalldataType && c.TargetNumFrames > maxBlobsPerBlock {
 		return fmt.Errorf("too many frames for blob transactions, max %d", maxBlobsPerBlock)
 	}
+	if c.ThrottleController != "" && !flags.ValidThrottleControllerType(c.ThrottleController) {
+		return fmt.Errorf("unknown throttle controller type: %q", c.ThrottleController)
+	}
+	if c.ThrottleController == flags.PIDControllerType && c.ThrottleThreshold > 0 {
+		if c.ThrottleBlockSize == 0 || c.ThrottleAlwaysBlockSize <= c.ThrottleBlockSize {
+			return errors.New("pid throttle controller requires ThrottleAlwaysBlockSize > ThrottleBlockSize > 0")
+		}
+		if c.ThrottlePIDKp < 0 || c.ThrottlePIDKi < 0 || c.ThrottlePIDKd < 0 {
+			return errors.New("pid throttle controller gains must not be negative")
+		}
+	}
+	if c.AltDAFailoverThreshold > 0 || c.AltDAFailoverTimeout > 0 {
+		if c.AltDAFailoverDAType != flags.BlobsType && c.AltDAFailoverDAType != flags.CalldataType {
+			return fmt.Errorf("invalid Alt-DA failover data availability type: %q", c.AltDAFailoverDAType)
+		}
+		if c.AltDAFailoverProbeInterval <= 0 {
+			return errors.New("AltDAFailoverProbeInterval must be positive")
+		}
+	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
 	}
@@ -183,11 +231,19 @@ func (c *CLIConfig) Check() error {
 	return nil
 }
 
+// L1MultiConfig returns the configuration of the L1 MultiClient, used if there are extra L1 endpoints.
+func (c *CLIConfig) L1MultiConfig() client.MultiConfig {
+	return client.NewMultiConfig(c.L1MultiMode, c.L1Quorum, 1+len(c.L1ExtraEthRpcs))
+}
+
 // NewConfig parses the Config from the provided flags or environment variables.
 func NewConfig(ctx *cli.Context) *CLIConfig {
 	return &CLIConfig{
 		/* Required Flags */
 		L1EthRpc:        ctx.String(flags.L1EthRpcFlag.Name),
+		L1ExtraEthRpcs:  ctx.StringSlice(flags.L1ExtraEthRpcsFlag.Name),
+		L1MultiMode:     client.MultiMode(ctx.String(flags.L1MultiModeFlag.Name)),
+		L1Quorum:        ctx.Int(flags.L1QuorumFlag.Name),
 		L2EthRpc:        ctx.String(flags.L2EthRpcFlag.Name),
 		RollupRpc:       ctx.String(flags.RollupRpcFlag.Name),
 		SubSafetyMargin: ctx.Uint64(flags.SubSafetyMarginFlag.Name),
@@ -218,6 +274,15 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		ThrottleTxSize:               ctx.Uint64(flags.ThrottleTxSizeFlag.Name),
 		ThrottleBlockSize:            ctx.Uint64(flags.ThrottleBlockSizeFlag.Name),
 		ThrottleAlwaysBlockSize:      ctx.Uint64(flags.ThrottleAlwaysBlockSizeFlag.Name),
+		ThrottleController:           flags.ThrottleControllerType(ctx.String(flags.ThrottleControllerFlag.Name)),
+		ThrottlePIDKp:                ctx.Float64(flags.ThrottlePIDKpFlag.Name),
+		ThrottlePIDKi:                ctx.Float64(flags.ThrottlePIDKiFlag.Name),
+		ThrottlePIDKd:                ctx.Float64(flags.ThrottlePIDKdFlag.Name),
 		PreferLocalSafeL2:            ctx.Bool(flags.PreferLocalSafeL2Flag.Name),
+		DataDir:                      ctx.String(flags.DataDirFlag.Name),
+		AltDAFailoverThreshold:       ctx.Uint64(flags.AltDAFailoverThresholdFlag.Name),
+		AltDAFailoverTimeout:         ctx.Duration(flags.AltDAFailoverTimeoutFlag.Name),
+		AltDAFailoverDAType:          flags.DataAvailabilityType(ctx.String(flags.AltDAFailoverDATypeFlag.Name)),
+		AltDAFailoverProbeInterval:   ctx.Duration(flags.AltDAFailoverProbeIntervalFlag.Name),
 	}
 }
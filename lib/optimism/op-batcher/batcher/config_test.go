@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -64,6 +65,14 @@ func TestBatcherConfig(t *testing.T) {
 			override:  func(c *batcher.CLIConfig) { c.L1EthRpc = "" },
 			errString: "empty L1 RPC URL",
 		},
+		{
+			name: "L1 quorum with two endpoints",
+			override: func(c *batcher.CLIConfig) {
+				c.L1ExtraEthRpcs = []string{"fake"}
+				c.L1MultiMode = client.MultiQuorum
+			},
+			errString: "invalid L1 RPC endpoints: quorum mode requires at least 3 endpoints",
+		},
 		{
 			name:      "empty L2",
 			override:  func(c *batcher.CLIConfig) { c.L2EthRpc = "" },
@@ -120,6 +129,30 @@ func TestBatcherConfig(t *testing.T) {
 			},
 			errString: "invalid ApproxComprRatio 4.2 for ratio compressor",
 		},
+		{
+			name:      "invalid throttle controller",
+			override:  func(c *batcher.CLIConfig) { c.ThrottleController = "foo" },
+			errString: "unknown throttle controller type: \"foo\"",
+		},
+		{
+			name: "pid throttle controller without block size range",
+			override: func(c *batcher.CLIConfig) {
+				c.ThrottleController = flags.PIDControllerType
+				c.ThrottleThreshold = 1_000_000
+				c.ThrottleBlockSize = 21_000
+				c.ThrottleAlwaysBlockSize = 21_000
+			},
+			errString: "pid throttle controller requires ThrottleAlwaysBlockSize > ThrottleBlockSize > 0",
+		},
+		{
+			name: "auto altda failover da type",
+			override: func(c *batcher.CLIConfig) {
+				c.AltDAFailoverThreshold = 3
+				c.AltDAFailoverDAType = flags.AutoType
+				c.AltDAFailoverProbeInterval = time.Minute
+			},
+			errString: "invalid Alt-DA failover data availability type: \"auto\"",
+		},
 	}
 
 	for _, test := range tests {
@@ -21,7 +21,9 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	batcherrpc "github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
@@ -69,6 +71,7 @@ func (r txRef) string(txIDStringer func(txID) string) string {
 type L1Client interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
 }
 
 type L2Client interface {
@@ -89,6 +92,7 @@ type DriverSetup struct {
 	L1Client          L1Client
 	EndpointProvider  dial.L2EndpointProvider
 	ChannelConfig     ChannelConfigProvider
+	DAChannelConfigs  map[flags.DataAvailabilityType]ChannelConfig // optional, derived from ChannelConfig if nil
 	AltDA             *altda.DAClient
 	ChannelOutFactory ChannelOutFactory
 	ActiveSeqChanged  chan struct{} // optional
@@ -113,18 +117,31 @@ type BatchSubmitter struct {
 	channelMgrMutex sync.Mutex // guards channelMgr and prevCurrentL1
 	channelMgr      *channelManager
 	prevCurrentL1   eth.L1BlockRef // cached CurrentL1 from the last syncStatus
+
+	channelConfig *RuntimeChannelConfig
+
+	altDAFailover *altDAFailover
 }
 
 // NewBatchSubmitter initializes the BatchSubmitter driver from a preconfigured DriverSetup
 func NewBatchSubmitter(setup DriverSetup) *BatchSubmitter {
-	state := NewChannelManager(setup.Log, setup.Metr, setup.ChannelConfig, setup.RollupConfig)
+	channelConfig := NewRuntimeChannelConfig(setup.Log, setup.ChannelConfig, setup.RollupConfig)
+	if setup.DAChannelConfigs != nil {
+		channelConfig.SetDAChannelConfigs(setup.DAChannelConfigs)
+	}
+	state := NewChannelManager(setup.Log, setup.Metr, channelConfig, setup.RollupConfig)
 	if setup.ChannelOutFactory != nil {
 		state.SetChannelOutFactory(setup.ChannelOutFactory)
 	}
+	if setup.Config.DataDir != "" {
+		state.SetJournal(setup.Config.DataDir)
+	}
 
 	return &BatchSubmitter{
-		DriverSetup: setup,
-		channelMgr:  state,
+		DriverSetup:   setup,
+		channelMgr:    state,
+		channelConfig: channelConfig,
+		altDAFailover: newAltDAFailover(setup.Config),
 	}
 }
 
@@ -155,6 +172,8 @@ func (l *BatchSubmitter) StartBatchSubmitting() error {
 		}
 	}
 
+	l.restoreJournal(l.shutdownCtx)
+
 	receiptsCh := make(chan txmgr.TxReceipt[txRef])
 
 	l.txpoolState = TxpoolGood // no need to lock mutex as no other routines yet exist
@@ -247,6 +266,45 @@ func (l *BatchSubmitter) StopBatchSubmitting(ctx context.Context) error {
 	return nil
 }
 
+// ChannelManagerState returns a snapshot of the channel manager state.
+// It is safe to call whether or not the batcher is running.
+func (l *BatchSubmitter) ChannelManagerState() batcherrpc.ChannelManagerState {
+	l.channelMgrMutex.Lock()
+	defer l.channelMgrMutex.Unlock()
+	return l.channelMgr.State()
+}
+
+// ChannelCosts returns the L1 costs of the most recently fully submitted or timed out channels.
+// It is safe to call whether or not the batcher is running.
+func (l *BatchSubmitter) ChannelCosts() []batcherrpc.ChannelCost {
+	l.channelMgrMutex.Lock()
+	defer l.channelMgrMutex.Unlock()
+	return l.channelMgr.ChannelCosts()
+}
+
+// AltDAState returns whether the batcher posts to the Alt-DA server or is failed over to Ethereum DA.
+func (l *BatchSubmitter) AltDAState() (batcherrpc.AltDAState, error) {
+	if !l.Config.UseAltDA {
+		return batcherrpc.AltDAState{}, errors.New("alt-DA is not enabled")
+	}
+	return l.altDAFailover.State(), nil
+}
+
+// UpdateChannelConfig applies the given update to the channel config, from the next channel onward.
+func (l *BatchSubmitter) UpdateChannelConfig(update batcherrpc.ChannelConfigUpdate) error {
+	return l.channelConfig.Update(update)
+}
+
+// ChannelConfigOverrides returns the channel config values overridden at runtime.
+func (l *BatchSubmitter) ChannelConfigOverrides() batcherrpc.ChannelConfigUpdate {
+	return l.channelConfig.Overrides()
+}
+
+// PinDAType pins the data availability type if it is chosen dynamically.
+func (l *BatchSubmitter) PinDAType(daType flags.DataAvailabilityType, duration time.Duration) error {
+	return l.channelConfig.PinDAType(daType, duration)
+}
+
 // loadBlocksIntoState loads the blocks between start and end (inclusive).
 // If there is a reorg, it will return an error.
 func (l *BatchSubmitter) loadBlocksIntoState(ctx context.Context, start, end uint64) error {
@@ -416,8 +474,8 @@ func (l *BatchSubmitter) setTxPoolState(txPoolState TxPoolState, txPoolBlockedBl
 }
 
 // syncAndPrune computes actions to take based on the current sync status, prunes the channel manager state
-// and returns blocks to load.
-func (l *BatchSubmitter) syncAndPrune(syncStatus *eth.SyncStatus) *inclusiveBlockRange {
+// and returns blocks to load, and whether the state was cleared.
+func (l *BatchSubmitter) syncAndPrune(syncStatus *eth.SyncStatus) (*inclusiveBlockRange, bool) {
 	l.channelMgrMutex.Lock()
 	defer l.channelMgrMutex.Unlock()
 
@@ -429,7 +487,7 @@ func (l *BatchSubmitter) syncAndPrune(syncStatus *eth.SyncStatus) *inclusiveBloc
 		// do nothing and wait to see if it has
 		// got in sync on the next tick.
 		l.Log.Warn("Sequencer is out of sync, retrying next tick.")
-		return syncActions.blocksToLoad
+		return syncActions.blocksToLoad, false
 	}
 
 	l.prevCurrentL1 = syncStatus.CurrentL1
@@ -437,11 +495,11 @@ func (l *BatchSubmitter) syncAndPrune(syncStatus *eth.SyncStatus) *inclusiveBloc
 	// Manage existing state / garbage collection
 	if syncActions.clearState != nil {
 		l.channelMgr.Clear(*syncActions.clearState)
-	} else {
-		l.channelMgr.PruneSafeBlocks(syncActions.blocksToPrune)
-		l.channelMgr.PruneChannels(syncActions.channelsToPrune)
+		return syncActions.blocksToLoad, true
 	}
-	return syncActions.blocksToLoad
+	l.channelMgr.PruneSafeBlocks(syncActions.blocksToPrune)
+	l.channelMgr.PruneChannels(syncActions.channelsToPrune)
+	return syncActions.blocksToLoad, false
 }
 
 // publishingLoop:
@@ -502,7 +560,11 @@ func (l *BatchSubmitter) blockLoadingLoop(ctx context.Context, wg *sync.WaitGrou
 				continue
 			}
 
-			blocksToLoad := l.syncAndPrune(syncStatus)
+			blocksToLoad, cleared := l.syncAndPrune(syncStatus)
+			if cleared {
+				// Resume the channels of L2 blocks that weren't reorged.
+				l.restoreJournal(ctx)
+			}
 
 			if blocksToLoad != nil {
 				// Get fresh unsafe blocks
@@ -554,7 +616,9 @@ func (l *BatchSubmitter) throttlingLoop(wg *sync.WaitGroup, pendingBytesUpdated
 	retryTimer := time.NewTimer(retryInterval)
 	retryTimer.Stop()
 
-	updateParams := func(pendingBytes int64) {
+	controller := NewThrottleController(l.Config)
+
+	setParams := func(params ThrottleParams) {
 		retryTimer.Stop()
 		ctx, cancel := context.WithTimeout(l.shutdownCtx, l.Config.NetworkTimeout)
 		defer cancel()
@@ -564,21 +628,12 @@ func (l *BatchSubmitter) throttlingLoop(wg *sync.WaitGroup, pendingBytesUpdated
 			return
 		}
 
-		maxTxSize := uint64(0)
-		maxBlockSize := l.Config.ThrottleAlwaysBlockSize
-		if pendingBytes > int64(l.Config.ThrottleThreshold) {
-			l.Log.Warn("Pending bytes over limit, throttling DA", "bytes", pendingBytes, "limit", l.Config.ThrottleThreshold)
-			maxTxSize = l.Config.ThrottleTxSize
-			if maxBlockSize == 0 || (l.Config.ThrottleBlockSize != 0 && l.Config.ThrottleBlockSize < maxBlockSize) {
-				maxBlockSize = l.Config.ThrottleBlockSize
-			}
-		}
 		var (
 			success bool
 			rpcErr  rpc.Error
 		)
 		err = cl.Client().CallContext(
-			ctx, &success, SetMaxDASizeMethod, hexutil.Uint64(maxTxSize), hexutil.Uint64(maxBlockSize),
+			ctx, &success, SetMaxDASizeMethod, hexutil.Uint64(params.MaxTxSize), hexutil.Uint64(params.MaxBlockSize),
 		)
 		if errors.Is(ctx.Err(), context.Canceled) {
 			// If the context was cancelled, our work is done and we expect an error here:
@@ -608,7 +663,9 @@ func (l *BatchSubmitter) throttlingLoop(wg *sync.WaitGroup, pendingBytesUpdated
 		}
 	}
 
-	cachedPendingBytes := int64(0)
+	// Only new pending bytes are fed to the controller, so that a sequencer change
+	// or retry doesn't advance the controller's state.
+	cachedParams := controller.Update(time.Now(), 0)
 
 	for {
 		select {
@@ -618,12 +675,17 @@ func (l *BatchSubmitter) throttlingLoop(wg *sync.WaitGroup, pendingBytesUpdated
 				l.Log.Info("throttlingLoop returning")
 				return
 			}
-			updateParams(pendingBytes)
-			cachedPendingBytes = pendingBytes
+			cachedParams = controller.Update(time.Now(), pendingBytes)
+			l.Metr.RecordThrottleParams(cachedParams.Intensity, cachedParams.MaxTxSize, cachedParams.MaxBlockSize)
+			if cachedParams.Intensity > 0 {
+				l.Log.Warn("Pending bytes over limit, throttling DA", "bytes", pendingBytes, "limit", l.Config.ThrottleThreshold,
+					"intensity", cachedParams.Intensity, "max_tx_size", cachedParams.MaxTxSize, "max_block_size", cachedParams.MaxBlockSize)
+			}
+			setParams(cachedParams)
 		case <-l.ActiveSeqChanged:
-			updateParams(cachedPendingBytes)
+			setParams(cachedParams)
 		case <-retryTimer.C:
-			updateParams(cachedPendingBytes)
+			setParams(cachedParams)
 		}
 	}
 }
@@ -637,6 +699,7 @@ func (l *BatchSubmitter) waitNodeSyncAndClearState() {
 		l.Log.Warn("error waiting for node sync", "err", err)
 	}
 	l.clearState(l.shutdownCtx)
+	l.restoreJournal(l.shutdownCtx)
 }
 
 // waitNodeSync Check to see if there was a batcher tx sent recently that
@@ -837,12 +900,20 @@ func (l *BatchSubmitter) publishToAltDAAndL1(txdata txData, queue *txmgr.Queue[t
 				l.recordFailedDARequest(txdata.ID(), nil)
 			} else {
 				l.Log.Error("Failed to post input to Alt DA", "error", err)
+				if l.altDAFailover.RecordFailure(time.Now()) {
+					l.Log.Warn("Alt DA server failing, failing over to Ethereum DA", "da_type", l.altDAFailover.DAType)
+					l.Metr.RecordAltDAFailover(true)
+				}
 				// requeue frame if we fail to post to the DA Provider so it can be retried
 				// note: this assumes that the da server caches requests, otherwise it might lead to resubmissions of the blobs
 				l.recordFailedDARequest(txdata.ID(), err)
 			}
 			return nil
 		}
+		if l.altDAFailover.RecordSuccess() {
+			l.Log.Info("Alt DA server recovered, ending failover to Ethereum DA")
+			l.Metr.RecordAltDAFailover(false)
+		}
 		l.Log.Info("Set altda input", "commitment", comm, "tx", txdata.ID())
 		candidate := l.calldataTxCandidate(comm.TxData())
 		l.sendTx(txdata, false, candidate, queue, receiptsCh)
@@ -864,9 +935,13 @@ func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txRef
 
 	// if Alt DA is enabled we post the txdata to the DA Provider and replace it with the commitment.
 	if l.Config.UseAltDA {
-		l.publishToAltDAAndL1(txdata, queue, receiptsCh, daGroup)
-		// we return nil to allow publishStateToL1 to keep processing the next txdata
-		return nil
+		if !l.altDAFailover.UseL1(time.Now()) {
+			l.publishToAltDAAndL1(txdata, queue, receiptsCh, daGroup)
+			// we return nil to allow publishStateToL1 to keep processing the next txdata
+			return nil
+		}
+		// failed over to Ethereum DA, the single frame of the txdata fits into a single blob
+		txdata.asBlob = l.altDAFailover.DAType == flags.BlobsType
 	}
 
 	var candidate *txmgr.TxCandidate
@@ -905,6 +980,13 @@ func (l *BatchSubmitter) sendTx(txdata txData, isCancel bool, candidate *txmgr.T
 		candidate.GasLimit = floorDataGas
 	}
 
+	if !isCancel && l.channelMgr.journal != nil {
+		candidate.OnPublished = func(tx *types.Transaction) {
+			// The journal write happens in the background and doesn't need the
+			// channel manager lock, so this doesn't block the txmgr.
+			l.channelMgr.RecordTxPublished(txdata.ID(), tx.Hash())
+		}
+	}
 	queue.Send(txRef{id: txdata.ID(), isCancel: isCancel, isBlob: txdata.asBlob}, *candidate, receiptsCh)
 }
 
@@ -963,6 +1045,8 @@ func (l *BatchSubmitter) recordConfirmedTx(id txID, receipt *types.Receipt) {
 	defer l.channelMgrMutex.Unlock()
 	l.Log.Info("Transaction confirmed", logFields(id, receipt)...)
 	l1block := eth.ReceiptBlockID(receipt)
+	l.channelMgr.RecordTxHash(id, receipt.TxHash)
+	l.channelMgr.RecordTxCost(id, receipt)
 	l.channelMgr.TxConfirmed(id, l1block)
 }
 
@@ -0,0 +1,694 @@
+package batcher
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+const (
+	journalMetaSuffix   = ".json"
+	journalFramesSuffix = ".frames.json"
+)
+
+// journaledChannel is the on-disk record of a full channel.
+// Its frames are stored separately, see journaledFrames.
+type journaledChannel struct {
+	ID     derive.ChannelID `json:"id"`
+	Config ChannelConfig    `json:"config"`
+	// FullReason is the reason the channel got full, see [ChannelBuilder.FullErr].
+	FullReason string `json:"fullReason"`
+	Timeout    uint64 `json:"timeout"`
+	InputBytes int    `json:"inputBytes"`
+
+	OldestL1Origin eth.BlockID `json:"oldestL1Origin"`
+	LatestL1Origin eth.BlockID `json:"latestL1Origin"`
+	OldestL2       eth.BlockID `json:"oldestL2"`
+	LatestL2       eth.BlockID `json:"latestL2"`
+
+	// Txs lists all transactions sent for this channel that didn't fail.
+	Txs []journaledTx `json:"txs"`
+
+	// Frames is not part of the metadata file, it is read from the frames file on load.
+	Frames []hexutil.Bytes `json:"-"`
+}
+
+// journaledTx is the on-disk record of a batcher transaction.
+type journaledTx struct {
+	// Frames are the numbers of the channel's frames that were sent in the tx.
+	Frames []uint16 `json:"frames"`
+	// TxHash is the hash of the L1 transaction that got confirmed. Nil if the tx is still pending.
+	TxHash *common.Hash `json:"txHash,omitempty"`
+	// SentTxHashes are the hashes of all published versions of a pending tx, including fee bumps,
+	// so the tx can be found on L1 if it got confirmed while the batcher wasn't running.
+	SentTxHashes []common.Hash `json:"sentTxHashes,omitempty"`
+	// InclusionBlock is the L1 block the tx got included in. Nil if the tx is still pending.
+	InclusionBlock *eth.BlockID `json:"inclusionBlock,omitempty"`
+}
+
+// journaledFrames is the on-disk record of a full channel's frames.
+// Frames are immutable once a channel is full, so they are written only once.
+type journaledFrames struct {
+	ID     derive.ChannelID `json:"id"`
+	Frames []hexutil.Bytes  `json:"frames"`
+}
+
+// channelJournal persists full channels, together with the status of their
+// transactions, to a data directory so they can be resumed after a restart.
+// Each channel is stored in two files, named after the channel ID: one for
+// the immutable frames and one for the metadata, which is rewritten atomically
+// on every status change.
+//
+// Channels are only journaled once they are full, because open channels can't
+// be resumed without the state of their compressor.
+//
+// The journal keeps the latest state of every journaled channel in memory, and
+// writes it to disk in the background, so journaling never blocks the caller on disk I/O.
+// Writes and deletions of a channel are applied in order. Write errors are logged.
+type channelJournal struct {
+	log log.Logger
+	dir string
+
+	mu sync.Mutex // guards all fields below
+	// txHashes holds the L1 tx hashes of confirmed txs of journaled channels, by tx ID.
+	txHashes map[string]common.Hash
+	// sentTxHashes holds the L1 tx hashes of all published versions of pending txs, by tx ID.
+	sentTxHashes map[string][]common.Hash
+	// channels holds the latest state of the journaled channels, to be written to disk.
+	channels map[derive.ChannelID]*journalEntry
+	// dirty is the set of channels whose state changed since it was last written.
+	dirty map[derive.ChannelID]struct{}
+	// writing is set while the background writer runs.
+	writing bool
+
+	writeMu sync.Mutex // serializes writes to disk
+}
+
+// journalEntry is the in-memory state of a journaled channel.
+type journalEntry struct {
+	meta journaledChannel
+	// txIDs are the IDs of the txs of meta.Txs, in the same order.
+	txIDs []string
+	// frames are the frames of the channel, until they are written to disk.
+	frames []hexutil.Bytes
+}
+
+func newChannelJournal(lgr log.Logger, dir string) *channelJournal {
+	return &channelJournal{
+		log:          lgr,
+		dir:          dir,
+		txHashes:     make(map[string]common.Hash),
+		sentTxHashes: make(map[string][]common.Hash),
+		channels:     make(map[derive.ChannelID]*journalEntry),
+		dirty:        make(map[derive.ChannelID]struct{}),
+	}
+}
+
+// AddSentTxHash records the L1 tx hash of a published version of a pending transaction,
+// and schedules the write of its channel, if journaled. It doesn't block on disk I/O.
+func (j *channelJournal) AddSentTxHash(id txID, hash common.Hash) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	key := id.String()
+	if slices.Contains(j.sentTxHashes[key], hash) {
+		return
+	}
+	j.sentTxHashes[key] = append(j.sentTxHashes[key], hash)
+	if len(id) == 0 {
+		return
+	}
+	chID := id[0].chID
+	entry, ok := j.channels[chID]
+	if !ok {
+		return
+	}
+	if i := slices.Index(entry.txIDs, key); i >= 0 {
+		entry.meta.Txs[i].SentTxHashes = slices.Clone(j.sentTxHashes[key])
+		j.markDirty(chID)
+	}
+}
+
+// ForgetTx removes the recorded L1 tx hashes of a transaction, e.g. because it failed.
+func (j *channelJournal) ForgetTx(id txID) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	delete(j.txHashes, id.String())
+	delete(j.sentTxHashes, id.String())
+}
+
+// SetTxHash records the L1 tx hash of a confirmed transaction.
+// It must be called before the channel of the tx is written.
+func (j *channelJournal) SetTxHash(id txID, hash common.Hash) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.txHashes[id.String()] = hash
+	delete(j.sentTxHashes, id.String())
+}
+
+// WriteChannel schedules the write of the given channel to the journal. It does nothing if the channel isn't full yet.
+func (j *channelJournal) WriteChannel(c *channel) {
+	if !c.IsFull() {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	id := c.ID()
+	entry, ok := j.channels[id]
+	if !ok {
+		entry = &journalEntry{frames: make([]hexutil.Bytes, 0, c.channelBuilder.frames.Len())}
+		for _, f := range c.channelBuilder.frames {
+			entry.frames = append(entry.frames, f.data)
+		}
+		j.channels[id] = entry
+	}
+
+	entry.meta = journaledChannel{
+		ID:             id,
+		Config:         c.cfg,
+		FullReason:     errors.Unwrap(c.FullErr()).Error(),
+		Timeout:        c.Timeout(),
+		InputBytes:     c.InputBytes(),
+		OldestL1Origin: c.OldestL1Origin(),
+		LatestL1Origin: c.LatestL1Origin(),
+		OldestL2:       c.OldestL2(),
+		LatestL2:       c.LatestL2(),
+	}
+	type keyedTx struct {
+		id string
+		tx journaledTx
+	}
+	var txs []keyedTx
+	for id, td := range c.pendingTransactions {
+		txs = append(txs, keyedTx{id, journaledTx{Frames: frameNumbers(td.ID()), SentTxHashes: slices.Clone(j.sentTxHashes[id])}})
+	}
+	for id, block := range c.confirmedTransactions {
+		tx := journaledTx{Frames: frameNumbers(c.confirmedTxIDs[id]), InclusionBlock: &block}
+		if hash, ok := j.txHashes[id]; ok {
+			tx.TxHash = &hash
+		}
+		txs = append(txs, keyedTx{id, tx})
+	}
+	slices.SortFunc(txs, func(a, b keyedTx) int { return cmp.Compare(a.tx.Frames[0], b.tx.Frames[0]) })
+	entry.txIDs = entry.txIDs[:0]
+	for _, tx := range txs {
+		entry.meta.Txs = append(entry.meta.Txs, tx.tx)
+		entry.txIDs = append(entry.txIDs, tx.id)
+	}
+	j.markDirty(id)
+}
+
+// DeleteChannel schedules the removal of the channel with the given ID from the journal.
+func (j *channelJournal) DeleteChannel(id derive.ChannelID) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	delete(j.channels, id)
+	prefix := id.String() + ":"
+	for txID := range j.txHashes {
+		if strings.HasPrefix(txID, prefix) {
+			delete(j.txHashes, txID)
+		}
+	}
+	for txID := range j.sentTxHashes {
+		if strings.HasPrefix(txID, prefix) {
+			delete(j.sentTxHashes, txID)
+		}
+	}
+	j.markDirty(id)
+}
+
+// markDirty schedules the write of the channel, and starts the background writer if it isn't running.
+// It must be called with j.mu held.
+func (j *channelJournal) markDirty(id derive.ChannelID) {
+	j.dirty[id] = struct{}{}
+	if !j.writing {
+		j.writing = true
+		go j.writeLoop()
+	}
+}
+
+func (j *channelJournal) writeLoop() {
+	for {
+		j.Flush()
+		j.mu.Lock()
+		if len(j.dirty) == 0 {
+			j.writing = false
+			j.mu.Unlock()
+			return
+		}
+		j.mu.Unlock()
+	}
+}
+
+// journalOp is a write or deletion of a channel on disk.
+type journalOp struct {
+	id     derive.ChannelID
+	delete bool
+	meta   journaledChannel
+	frames []hexutil.Bytes // nil if already written
+}
+
+// Flush writes all scheduled changes to disk.
+func (j *channelJournal) Flush() {
+	j.writeMu.Lock()
+	defer j.writeMu.Unlock()
+
+	j.mu.Lock()
+	ops := make([]journalOp, 0, len(j.dirty))
+	for id := range j.dirty {
+		entry, ok := j.channels[id]
+		if !ok {
+			ops = append(ops, journalOp{id: id, delete: true})
+			continue
+		}
+		op := journalOp{id: id, meta: entry.meta, frames: entry.frames}
+		op.meta.Txs = slices.Clone(entry.meta.Txs)
+		entry.frames = nil
+		ops = append(ops, op)
+	}
+	clear(j.dirty)
+	j.mu.Unlock()
+
+	for _, op := range ops {
+		if op.delete {
+			if err := j.remove(op.id); err != nil {
+				j.log.Warn("Failed to remove channel from journal", "id", op.id, "err", err)
+			}
+			continue
+		}
+		if err := j.write(&op); err != nil {
+			j.log.Warn("Failed to journal channel", "id", op.id, "err", err)
+			if op.frames != nil {
+				// retry writing the frames with the next change of the channel
+				j.mu.Lock()
+				if entry, ok := j.channels[op.id]; ok && entry.frames == nil {
+					entry.frames = op.frames
+				}
+				j.mu.Unlock()
+			}
+		}
+	}
+}
+
+func (j *channelJournal) write(op *journalOp) error {
+	if op.frames != nil {
+		if err := os.MkdirAll(j.dir, 0o755); err != nil {
+			return fmt.Errorf("creating journal dir: %w", err)
+		}
+		frames := journaledFrames{ID: op.id, Frames: op.frames}
+		if err := jsonutil.WriteJSON(frames, ioutil.ToAtomicFile(j.path(op.id, journalFramesSuffix), 0o644)); err != nil {
+			return fmt.Errorf("writing frames of channel %s: %w", op.id, err)
+		}
+		op.frames = nil
+	}
+	if err := jsonutil.WriteJSON(op.meta, ioutil.ToAtomicFile(j.path(op.id, journalMetaSuffix), 0o644)); err != nil {
+		return fmt.Errorf("writing channel %s: %w", op.id, err)
+	}
+	return nil
+}
+
+func (j *channelJournal) remove(id derive.ChannelID) error {
+	// Remove the metadata first, a channel without metadata is ignored on load.
+	var result error
+	for _, suffix := range []string{journalMetaSuffix, journalFramesSuffix} {
+		if err := os.Remove(j.path(id, suffix)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			result = errors.Join(result, err)
+		}
+	}
+	return result
+}
+
+// Load reads all channels from the journal, ordered by their oldest L2 block.
+// Channels that can't be read are skipped.
+// Scheduled changes are written first.
+func (j *channelJournal) Load() ([]*journaledChannel, error) {
+	j.Flush()
+	entries, err := os.ReadDir(j.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading journal dir: %w", err)
+	}
+	var chs []*journaledChannel
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, journalMetaSuffix) || strings.HasSuffix(name, journalFramesSuffix) {
+			continue
+		}
+		jc, err := jsonutil.LoadJSON[journaledChannel](filepath.Join(j.dir, name))
+		if err != nil {
+			j.log.Warn("Skipping unreadable journaled channel", "file", name, "err", err)
+			continue
+		}
+		frames, err := jsonutil.LoadJSON[journaledFrames](j.path(jc.ID, journalFramesSuffix))
+		if err != nil || frames.ID != jc.ID {
+			j.log.Warn("Skipping journaled channel with unreadable frames", "id", jc.ID, "err", err)
+			continue
+		}
+		jc.Frames = frames.Frames
+		chs = append(chs, jc)
+	}
+	slices.SortFunc(chs, func(a, b *journaledChannel) int {
+		return cmp.Compare(a.OldestL2.Number, b.OldestL2.Number)
+	})
+	return chs, nil
+}
+
+func (j *channelJournal) path(id derive.ChannelID, suffix string) string {
+	return filepath.Join(j.dir, id.String()+suffix)
+}
+
+func frameNumbers(id txID) []uint16 {
+	fns := make([]uint16, 0, len(id))
+	for _, f := range id {
+		fns = append(fns, f.frameNumber)
+	}
+	return fns
+}
+
+var errRestoredChannelOut = errors.New("channel was restored from journal and is closed")
+
+// restoredChannelOut stands in for the ChannelOut of a channel restored from
+// the journal. The channel is full and all its frames are already output, so
+// it only needs to report its ID and input size.
+type restoredChannelOut struct {
+	id         derive.ChannelID
+	inputBytes int
+}
+
+var _ derive.ChannelOut = (*restoredChannelOut)(nil)
+
+func (co *restoredChannelOut) ID() derive.ChannelID { return co.id }
+func (co *restoredChannelOut) Reset() error         { return errRestoredChannelOut }
+func (co *restoredChannelOut) AddBlock(*rollup.Config, *types.Block) (*derive.L1BlockInfo, error) {
+	return nil, errRestoredChannelOut
+}
+func (co *restoredChannelOut) InputBytes() int { return co.inputBytes }
+func (co *restoredChannelOut) ReadyBytes() int { return 0 }
+func (co *restoredChannelOut) Flush() error    { return nil }
+func (co *restoredChannelOut) FullErr() error  { return nil }
+func (co *restoredChannelOut) Close() error    { return nil }
+func (co *restoredChannelOut) OutputFrame(*bytes.Buffer, uint64) (uint16, error) {
+	return 0, io.EOF
+}
+
+// maxJournalReceiptFetches is the maximum number of concurrent L1 receipt requests
+// when reconciling the transactions of a journaled channel.
+const maxJournalReceiptFetches = 8
+
+// errJournalReorged is returned if the L2 blocks of a journaled channel are no longer canonical.
+var errJournalReorged = errors.New("journaled L2 blocks were reorged")
+
+// restoreJournal restores the channels of the journal, if journaling is enabled.
+// It must be called on a cleared channel manager, see restoreJournaledChannels.
+func (l *BatchSubmitter) restoreJournal(ctx context.Context) {
+	if chs := l.loadJournal(); len(chs) > 0 {
+		l.restoreJournaledChannels(ctx, chs)
+	}
+}
+
+// loadJournal returns all channels from the journal, or nil if the journal is disabled.
+func (l *BatchSubmitter) loadJournal() []*journaledChannel {
+	if l.channelMgr.journal == nil {
+		return nil
+	}
+	l.channelMgrMutex.Lock()
+	chs, err := l.channelMgr.journal.Load()
+	l.channelMgrMutex.Unlock()
+	if err != nil {
+		l.Log.Warn("Failed to load channel journal, channels will be rebuilt", "err", err)
+		return nil
+	}
+	l.Log.Info("Loaded channel journal", "channels", len(chs))
+	return chs
+}
+
+// restoreJournaledChannels resumes the given journaled channels, which must be
+// ordered by their L2 blocks. Only channels that continue the current safe
+// chain are restored, starting with the channel right after the safe head.
+// The L2 blocks of restored channels are loaded into the channel manager and
+// the channel's transactions are reconciled against L1 receipts. Frames of
+// transactions that can't be confirmed on L1 will be resubmitted.
+//
+// Channels that are already safe, that were reorged, or that overlap a restored
+// channel are removed from the journal. Channels that can't be restored for other
+// reasons, e.g. RPC errors, are kept, to try again on the next restore.
+//
+// It must be called on a cleared channel manager.
+func (l *BatchSubmitter) restoreJournaledChannels(ctx context.Context, chs []*journaledChannel) {
+	syncStatus, err := l.getSyncStatus(ctx)
+	if err != nil {
+		l.Log.Warn("Failed to get sync status, not restoring journaled channels", "err", err)
+		return
+	}
+	safeL2 := syncStatus.SafeL2
+	if l.Config.PreferLocalSafeL2 {
+		safeL2 = syncStatus.LocalSafeL2
+	}
+
+	parent := safeL2.ID()
+	for i, jc := range chs {
+		if jc.LatestL2.Number <= safeL2.Number {
+			l.Log.Debug("Dropping journaled channel that is already safe", "id", jc.ID, "latest_l2", jc.LatestL2)
+			l.unjournalChannels(jc)
+			continue
+		}
+		if jc.OldestL2.Number <= parent.Number {
+			l.Log.Info("Dropping journaled channel that overlaps a restored channel", "id", jc.ID, "oldest_l2", jc.OldestL2, "parent", parent)
+			l.unjournalChannels(jc)
+			continue
+		}
+		if jc.OldestL2.Number != parent.Number+1 {
+			l.Log.Warn("Journaled channel doesn't continue safe chain, not restoring remaining channels",
+				"id", jc.ID, "oldest_l2", jc.OldestL2, "parent", parent)
+			break
+		}
+		blocks, err := l.fetchJournaledBlocks(ctx, jc, parent)
+		if errors.Is(err, errJournalReorged) {
+			// All later channels build on the reorged blocks.
+			l.Log.Warn("Journaled channel was reorged, dropping remaining channels", "id", jc.ID, "err", err)
+			l.unjournalChannels(chs[i:]...)
+			break
+		} else if err != nil {
+			l.Log.Warn("Failed to fetch L2 blocks of journaled channel, not restoring remaining channels", "id", jc.ID, "err", err)
+			break
+		}
+		confirmed := l.reconcileJournaledTxs(ctx, jc)
+
+		l.channelMgrMutex.Lock()
+		err = l.channelMgr.restoreChannel(jc, blocks, confirmed)
+		l.channelMgrMutex.Unlock()
+		if err != nil {
+			l.Log.Warn("Failed to restore journaled channel, not restoring remaining channels", "id", jc.ID, "err", err)
+			break
+		}
+		parent = jc.LatestL2
+	}
+}
+
+// unjournalChannels removes the given journaled channels from the journal.
+func (l *BatchSubmitter) unjournalChannels(chs ...*journaledChannel) {
+	for _, jc := range chs {
+		l.channelMgr.journal.DeleteChannel(jc.ID)
+	}
+}
+
+// fetchJournaledBlocks fetches the L2 blocks of the given journaled channel and verifies
+// that they match the journal and build on the given parent block.
+// It returns an errJournalReorged error if the blocks don't match.
+func (l *BatchSubmitter) fetchJournaledBlocks(ctx context.Context, jc *journaledChannel, parent eth.BlockID) ([]*types.Block, error) {
+	l2Client, err := l.EndpointProvider.EthClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting L2 client: %w", err)
+	}
+	blocks := make([]*types.Block, 0, jc.LatestL2.Number-jc.OldestL2.Number+1)
+	for n := jc.OldestL2.Number; n <= jc.LatestL2.Number; n++ {
+		cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+		block, err := l2Client.BlockByNumber(cCtx, new(big.Int).SetUint64(n))
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("getting L2 block %d: %w", n, err)
+		}
+		if block.ParentHash() != parent.Hash {
+			return nil, fmt.Errorf("%w: L2 block %s doesn't build on %s", errJournalReorged, eth.ToBlockID(block), parent)
+		}
+		blocks = append(blocks, block)
+		parent = eth.ToBlockID(block)
+	}
+	if oldest := eth.ToBlockID(blocks[0]); oldest != jc.OldestL2 {
+		return nil, fmt.Errorf("%w: oldest L2 block %s doesn't match journal %s", errJournalReorged, oldest, jc.OldestL2)
+	}
+	if parent != jc.LatestL2 {
+		return nil, fmt.Errorf("%w: latest L2 block %s doesn't match journal %s", errJournalReorged, parent, jc.LatestL2)
+	}
+	return blocks, nil
+}
+
+// reconcileJournaledTxs returns the transactions of the given journaled channel
+// that are confirmed in the canonical L1 chain, with their current inclusion block.
+// This includes pending txs of which a published version got confirmed while the
+// batcher wasn't running. Receipts are fetched concurrently, see maxJournalReceiptFetches.
+func (l *BatchSubmitter) reconcileJournaledTxs(ctx context.Context, jc *journaledChannel) []journaledTx {
+	results := make([]*journaledTx, len(jc.Txs))
+	var g errgroup.Group
+	g.SetLimit(maxJournalReceiptFetches)
+	for i, tx := range jc.Txs {
+		g.Go(func() error {
+			results[i] = l.reconcileJournaledTx(ctx, tx)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	var confirmed []journaledTx
+	for _, tx := range results {
+		if tx != nil {
+			confirmed = append(confirmed, *tx)
+		}
+	}
+	return confirmed
+}
+
+// reconcileJournaledTx returns the given journaled tx with its inclusion block
+// if any of its versions is confirmed on L1, or nil otherwise.
+func (l *BatchSubmitter) reconcileJournaledTx(ctx context.Context, tx journaledTx) *journaledTx {
+	hashes := tx.SentTxHashes
+	if tx.TxHash != nil && !slices.Contains(hashes, *tx.TxHash) {
+		hashes = append([]common.Hash{*tx.TxHash}, hashes...)
+	}
+	for _, hash := range hashes {
+		cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+		receipt, err := l.L1Client.TransactionReceipt(cCtx, hash)
+		cancel()
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		} else if err != nil {
+			l.Log.Warn("Failed to get receipt of journaled tx", "tx", hash, "err", err)
+			continue
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			l.Log.Warn("Journaled tx failed, frames will be resubmitted", "tx", hash, "block", eth.ReceiptBlockID(receipt))
+			return nil
+		}
+		block := eth.ReceiptBlockID(receipt)
+		if tx.InclusionBlock != nil && *tx.InclusionBlock != block {
+			l.Log.Info("Journaled tx was reincluded", "tx", hash, "block", block, "journaled_block", *tx.InclusionBlock)
+		}
+		tx.TxHash, tx.InclusionBlock, tx.SentTxHashes = &hash, &block, nil
+		return &tx
+	}
+	if len(hashes) > 0 {
+		l.Log.Warn("Journaled tx is not confirmed, frames will be resubmitted", "frames", tx.Frames, "tx_hashes", len(hashes))
+	}
+	return nil
+}
+
+// restoreChannel adds the given journaled channel with its blocks to the channel manager.
+// The frames of the given confirmed transactions are marked as confirmed, as long as they
+// form a contiguous sequence from the first frame. All other frames will be resubmitted.
+func (s *channelManager) restoreChannel(jc *journaledChannel, blocks []*types.Block, confirmed []journaledTx) error {
+	cb := &ChannelBuilder{
+		cfg:            jc.Config,
+		rollupCfg:      s.rollupCfg,
+		timeout:        jc.Timeout,
+		timeoutReason:  ErrChannelTimeoutClose,
+		co:             &restoredChannelOut{id: jc.ID, inputBytes: jc.InputBytes},
+		latestL1Origin: jc.LatestL1Origin,
+		oldestL1Origin: jc.OldestL1Origin,
+		latestL2:       jc.LatestL2,
+		oldestL2:       jc.OldestL2,
+	}
+	cb.setFullErr(errors.New(jc.FullReason))
+	for _, b := range blocks {
+		cb.blocks.Enqueue(b)
+	}
+	if len(jc.Frames) > math.MaxUint16+1 {
+		return fmt.Errorf("too many frames: %d", len(jc.Frames))
+	}
+	for i, data := range jc.Frames {
+		cb.frames.Enqueue(frameData{id: frameID{chID: jc.ID, frameNumber: uint16(i)}, data: data})
+		cb.numFrames++
+		cb.outputBytes += len(data)
+	}
+
+	c := &channel{
+		log:                   s.log,
+		metr:                  s.metr,
+		cfg:                   jc.Config,
+		channelBuilder:        cb,
+		pendingTransactions:   make(map[string]txData),
+		confirmedTransactions: make(map[string]eth.BlockID),
+		confirmedTxIDs:        make(map[string]txID),
+		minInclusionBlock:     math.MaxUint64,
+	}
+
+	slices.SortFunc(confirmed, func(a, b journaledTx) int { return cmp.Compare(a.Frames[0], b.Frames[0]) })
+	for _, tx := range confirmed {
+		id := make(txID, 0, len(tx.Frames))
+		for i, fn := range tx.Frames {
+			if int(fn) != cb.frameCursor+i {
+				break
+			}
+			id = append(id, frameID{chID: jc.ID, frameNumber: fn})
+		}
+		if len(id) != len(tx.Frames) || cb.frameCursor+len(id) > cb.frames.Len() {
+			break
+		}
+		cb.frameCursor += len(id)
+		c.confirmedTransactions[id.String()] = *tx.InclusionBlock
+		c.confirmedTxIDs[id.String()] = id
+		cb.FramePublished(tx.InclusionBlock.Number)
+		c.minInclusionBlock = min(c.minInclusionBlock, tx.InclusionBlock.Number)
+		c.maxInclusionBlock = max(c.maxInclusionBlock, tx.InclusionBlock.Number)
+		if s.journal != nil {
+			s.journal.SetTxHash(id, *tx.TxHash)
+		}
+	}
+	if c.isTimedOut() {
+		return errors.New("channel timed out")
+	}
+
+	for _, b := range blocks {
+		if err := s.AddL2Block(b); err != nil {
+			return fmt.Errorf("adding L2 block to state: %w", err)
+		}
+		s.metr.RecordL2BlockInChannel(b)
+	}
+	s.blockCursor = s.blocks.Len()
+	s.channelQueue = append(s.channelQueue, c)
+	s.metr.RecordChannelQueueLength(len(s.channelQueue))
+	if !c.NoneSubmitted() && c.LatestL1Origin().Number > s.l1OriginLastSubmittedChannel.Number {
+		s.l1OriginLastSubmittedChannel = c.LatestL1Origin()
+	}
+	s.journalChannel(c)
+
+	s.log.Info("Restored channel from journal",
+		"id", c.ID(),
+		"num_frames", c.TotalFrames(),
+		"confirmed_frames", cb.frameCursor,
+		"oldest_l2", c.OldestL2(),
+		"latest_l2", c.LatestL2(),
+	)
+	return nil
+}
@@ -0,0 +1,227 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/queue"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelJournal_WriteRestore(t *testing.T) {
+	l := testlog.Logger(t, log.LevelCrit)
+	dir := t.TempDir()
+	cfg := channelManagerTestConfig(100, derive.SingularBatchType)
+	cfg.ChannelTimeout = 100
+	m := NewChannelManager(l, metrics.NoopMetrics, cfg, defaultTestRollupConfig)
+	m.SetJournal(dir)
+
+	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	m.journal.WriteChannel(m.currentChannel)
+	chs, err := m.journal.Load()
+	require.NoError(t, err)
+	require.Empty(t, chs, "open channel must not be journaled")
+
+	rng := rand.New(rand.NewSource(42))
+	block := derivetest.RandomL2BlockWithChainId(rng, 10, defaultTestRollupConfig.L2ChainID)
+	m.blocks = queue.Queue[*types.Block]{block}
+	require.NoError(t, m.processBlocks())
+	require.NoError(t, m.outputFrames())
+	c := m.currentChannel
+	require.True(t, c.IsFull())
+	require.Greater(t, c.TotalFrames(), 2)
+
+	tx1, err := m.nextTxData(c)
+	require.NoError(t, err)
+	inclusion := eth.BlockID{Number: 21, Hash: common.Hash{0x21}}
+	txHash := common.Hash{0xaa}
+	m.RecordTxHash(tx1.ID(), txHash)
+	m.TxConfirmed(tx1.ID(), inclusion)
+	tx2, err := m.nextTxData(c)
+	require.NoError(t, err)
+	sentHashes := []common.Hash{{0xbb}, {0xbc}}
+	for _, h := range sentHashes {
+		m.RecordTxPublished(tx2.ID(), h)
+	}
+
+	chs, err = m.journal.Load()
+	require.NoError(t, err)
+	require.Len(t, chs, 1)
+	jc := chs[0]
+	require.Equal(t, c.ID(), jc.ID)
+	require.Equal(t, c.Timeout(), jc.Timeout)
+	require.Equal(t, eth.ToBlockID(block), jc.OldestL2)
+	require.Equal(t, eth.ToBlockID(block), jc.LatestL2)
+	require.Equal(t, errors.Unwrap(c.FullErr()).Error(), jc.FullReason)
+	require.Len(t, jc.Frames, c.TotalFrames())
+	for i, f := range c.channelBuilder.frames {
+		require.Equal(t, f.data, []byte(jc.Frames[i]))
+	}
+	require.Len(t, jc.Txs, 2)
+	require.Equal(t, frameNumbers(tx1.ID()), jc.Txs[0].Frames)
+	require.Equal(t, &txHash, jc.Txs[0].TxHash)
+	require.Equal(t, &inclusion, jc.Txs[0].InclusionBlock)
+	require.Empty(t, jc.Txs[0].SentTxHashes)
+	require.Equal(t, frameNumbers(tx2.ID()), jc.Txs[1].Frames)
+	require.Nil(t, jc.Txs[1].InclusionBlock)
+	require.Nil(t, jc.Txs[1].TxHash)
+	require.Equal(t, sentHashes, jc.Txs[1].SentTxHashes)
+
+	// clearing the state keeps the journal, to restore channels that weren't reorged
+	m.Clear(eth.BlockID{})
+	chs, err = m.journal.Load()
+	require.NoError(t, err)
+	require.Len(t, chs, 1)
+
+	// restore into a fresh channel manager, the pending tx must be resent
+	m2 := NewChannelManager(l, metrics.NoopMetrics, cfg, defaultTestRollupConfig)
+	m2.SetJournal(dir)
+	require.NoError(t, m2.restoreChannel(jc, []*types.Block{block}, jc.Txs[:1]))
+	require.Len(t, m2.channelQueue, 1)
+	rc := m2.channelQueue[0]
+	require.Equal(t, c.ID(), rc.ID())
+	require.True(t, rc.IsFull())
+	require.Equal(t, c.TotalFrames(), rc.TotalFrames())
+	require.Equal(t, c.Timeout(), rc.Timeout())
+	require.Equal(t, 1, m2.blocks.Len())
+	require.Zero(t, m2.pendingBlocks())
+	require.Equal(t, c.LatestL1Origin(), m2.l1OriginLastSubmittedChannel)
+
+	rtx, err := m2.TxData(eth.BlockID{Number: 22}, false)
+	require.NoError(t, err)
+	require.Equal(t, tx2.ID().String(), rtx.ID().String())
+	require.Equal(t, tx2.CallData(), rtx.CallData())
+
+	m2.PruneChannels(1)
+	chs, err = m2.journal.Load()
+	require.NoError(t, err)
+	require.Empty(t, chs)
+}
+
+func TestChannelJournal_PublishDoesntBlock(t *testing.T) {
+	l := testlog.Logger(t, log.LevelCrit)
+	cfg := channelManagerTestConfig(100, derive.SingularBatchType)
+	m := NewChannelManager(l, metrics.NoopMetrics, cfg, defaultTestRollupConfig)
+	m.SetJournal(t.TempDir())
+
+	rng := rand.New(rand.NewSource(42))
+	m.blocks = queue.Queue[*types.Block]{derivetest.RandomL2BlockWithChainId(rng, 10, defaultTestRollupConfig.L2ChainID)}
+	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	require.NoError(t, m.processBlocks())
+	require.NoError(t, m.outputFrames())
+	c := m.currentChannel
+	require.True(t, c.IsFull())
+	tx, err := m.nextTxData(c)
+	require.NoError(t, err)
+	m.journalChannel(c)
+
+	// simulate a slow disk write in progress
+	m.journal.writeMu.Lock()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.RecordTxPublished(tx.ID(), common.Hash{0xbb})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publishing a tx blocked on the journal write")
+	}
+	m.journal.writeMu.Unlock()
+
+	chs, err := m.journal.Load()
+	require.NoError(t, err)
+	require.Len(t, chs, 1)
+	require.Len(t, chs[0].Txs, 1)
+	require.Equal(t, []common.Hash{{0xbb}}, chs[0].Txs[0].SentTxHashes)
+}
+
+func TestChannelManager_restoreChannelTimedOut(t *testing.T) {
+	l := testlog.Logger(t, log.LevelCrit)
+	cfg := channelManagerTestConfig(100, derive.SingularBatchType)
+	cfg.ChannelTimeout = 10
+	m := NewChannelManager(l, metrics.NoopMetrics, cfg, defaultTestRollupConfig)
+
+	rng := rand.New(rand.NewSource(42))
+	block := derivetest.RandomL2BlockWithChainId(rng, 1, defaultTestRollupConfig.L2ChainID)
+	id := derive.ChannelID{0x01}
+	jc := &journaledChannel{
+		ID:         id,
+		Config:     cfg,
+		FullReason: ErrMaxFrameIndex.Error(),
+		OldestL2:   eth.ToBlockID(block),
+		LatestL2:   eth.ToBlockID(block),
+		Frames:     []hexutil.Bytes{{0x01}, {0x02}},
+	}
+	first, last := eth.BlockID{Number: 1}, eth.BlockID{Number: 20}
+	confirmed := []journaledTx{
+		{Frames: []uint16{0}, TxHash: &common.Hash{0x01}, InclusionBlock: &first},
+		{Frames: []uint16{1}, TxHash: &common.Hash{0x02}, InclusionBlock: &last},
+	}
+	require.ErrorContains(t, m.restoreChannel(jc, []*types.Block{block}, confirmed), "timed out")
+	require.Empty(t, m.channelQueue)
+	require.Zero(t, m.blocks.Len())
+}
+
+type receiptsL1Client struct {
+	L1Client
+	receipts map[common.Hash]*types.Receipt
+	requests atomic.Int64
+}
+
+func (c *receiptsL1Client) TransactionReceipt(_ context.Context, hash common.Hash) (*types.Receipt, error) {
+	c.requests.Add(1)
+	if r, ok := c.receipts[hash]; ok {
+		return r, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func TestBatchSubmitter_reconcileJournaledTxs(t *testing.T) {
+	confirmedBlock := eth.BlockID{Number: 10, Hash: common.Hash{0x10}}
+	reincludedBlock := eth.BlockID{Number: 11, Hash: common.Hash{0x11}}
+	l1 := &receiptsL1Client{receipts: map[common.Hash]*types.Receipt{
+		{0x01}: {TxHash: common.Hash{0x01}, Status: types.ReceiptStatusSuccessful, BlockHash: reincludedBlock.Hash, BlockNumber: big.NewInt(11)},
+		{0x03}: {TxHash: common.Hash{0x03}, Status: types.ReceiptStatusSuccessful, BlockHash: confirmedBlock.Hash, BlockNumber: big.NewInt(10)},
+		{0x04}: {TxHash: common.Hash{0x04}, Status: types.ReceiptStatusFailed, BlockHash: confirmedBlock.Hash, BlockNumber: big.NewInt(10)},
+	}}
+	l := &BatchSubmitter{DriverSetup: DriverSetup{
+		Log:      testlog.Logger(t, log.LevelCrit),
+		L1Client: l1,
+		Config:   BatcherConfig{NetworkTimeout: time.Second},
+	}}
+	jc := &journaledChannel{Txs: []journaledTx{
+		// confirmed, but reincluded in another block
+		{Frames: []uint16{0}, TxHash: &common.Hash{0x01}, InclusionBlock: &confirmedBlock},
+		// pending, a fee-bumped version got confirmed
+		{Frames: []uint16{1}, SentTxHashes: []common.Hash{{0x02}, {0x03}}},
+		// pending, failed
+		{Frames: []uint16{2}, SentTxHashes: []common.Hash{{0x04}}},
+		// pending, not found
+		{Frames: []uint16{3}, SentTxHashes: []common.Hash{{0x05}}},
+		// pending, never published
+		{Frames: []uint16{4}},
+	}}
+
+	confirmed := l.reconcileJournaledTxs(context.Background(), jc)
+	require.Equal(t, []journaledTx{
+		{Frames: []uint16{0}, TxHash: &common.Hash{0x01}, InclusionBlock: &reincludedBlock},
+		{Frames: []uint16{1}, TxHash: &common.Hash{0x03}, InclusionBlock: &confirmedBlock},
+	}, confirmed)
+	require.EqualValues(t, 5, l1.requests.Load())
+}
@@ -19,7 +19,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/params"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
@@ -49,15 +51,29 @@ type BatcherConfig struct {
 	ThrottleThreshold, ThrottleTxSize          uint64
 	ThrottleBlockSize, ThrottleAlwaysBlockSize uint64
 
+	ThrottleController                          flags.ThrottleControllerType
+	ThrottlePIDKp, ThrottlePIDKi, ThrottlePIDKd float64
+
 	PreferLocalSafeL2 bool
+
+	// DataDir is the directory to journal full channels to. Journaling is disabled if empty.
+	DataDir string
+
+	// Failover from Alt-DA to Ethereum DA. See CLIConfig in config.go for details on these parameters.
+	AltDAFailoverThreshold     uint64
+	AltDAFailoverTimeout       time.Duration
+	AltDAFailoverDAType        flags.DataAvailabilityType
+	AltDAFailoverProbeInterval time.Duration
 }
 
 // BatcherService represents a full batch-submitter instance and its resources,
 // and conforms to the op-service CLI Lifecycle interface.
 type BatcherService struct {
-	Log              log.Logger
-	Metrics          metrics.Metricer
-	L1Client         *ethclient.Client
+	Log      log.Logger
+	Metrics  metrics.Metricer
+	L1Client *ethclient.Client
+	// l1Multi spreads the L1Client requests over multiple L1 endpoints, if configured.
+	l1Multi          *client.MultiClient
 	EndpointProvider dial.L2EndpointProvider
 	TxManager        txmgr.TxManager
 	AltDA            *altda.DAClient
@@ -65,7 +81,9 @@ type BatcherService struct {
 	BatcherConfig
 
 	ChannelConfig ChannelConfigProvider
-	RollupConfig  *rollup.Config
+	// DAChannelConfigs are the channel configs of the data availability types that can be switched to at runtime.
+	DAChannelConfigs map[flags.DataAvailabilityType]ChannelConfig
+	RollupConfig     *rollup.Config
 
 	driver *BatchSubmitter
 
@@ -112,8 +130,18 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	bs.ThrottleTxSize = cfg.ThrottleTxSize
 	bs.ThrottleBlockSize = cfg.ThrottleBlockSize
 	bs.ThrottleAlwaysBlockSize = cfg.ThrottleAlwaysBlockSize
+	bs.ThrottleController = cfg.ThrottleController
+	bs.ThrottlePIDKp = cfg.ThrottlePIDKp
+	bs.ThrottlePIDKi = cfg.ThrottlePIDKi
+	bs.ThrottlePIDKd = cfg.ThrottlePIDKd
 
 	bs.PreferLocalSafeL2 = cfg.PreferLocalSafeL2
+	bs.DataDir = cfg.DataDir
+
+	bs.AltDAFailoverThreshold = cfg.AltDAFailoverThreshold
+	bs.AltDAFailoverTimeout = cfg.AltDAFailoverTimeout
+	bs.AltDAFailoverDAType = cfg.AltDAFailoverDAType
+	bs.AltDAFailoverProbeInterval = cfg.AltDAFailoverProbeInterval
 
 	optsFromRPC, err := bs.initRPCClients(ctx, cfg)
 	if err != nil {
@@ -152,11 +180,19 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 }
 
 func (bs *BatcherService) initRPCClients(ctx context.Context, cfg *CLIConfig) (opts []DriverSetupOption, _ error) {
-	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, bs.Log, cfg.L1EthRpc)
-	if err != nil {
-		return nil, fmt.Errorf("failed to dial L1 RPC: %w", err)
+	var err error
+	if len(cfg.L1ExtraEthRpcs) > 0 {
+		urls := append([]string{cfg.L1EthRpc}, cfg.L1ExtraEthRpcs...)
+		bs.L1Client, bs.l1Multi, err = dial.DialMultiEthClientWithTimeout(ctx, dial.DefaultDialTimeout, bs.Log, urls, cfg.L1MultiConfig())
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial L1 RPCs: %w", err)
+		}
+	} else {
+		bs.L1Client, err = dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, bs.Log, cfg.L1EthRpc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial L1 RPC: %w", err)
+		}
 	}
-	bs.L1Client = l1Client
 
 	var endpointProvider dial.L2EndpointProvider
 	if strings.Contains(cfg.RollupRpc, ",") && strings.Contains(cfg.L2EthRpc, ",") {
@@ -268,6 +304,16 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 		return fmt.Errorf("max frame size %d exceeds altDA max input size %d", cc.MaxFrameSize, altda.MaxInputSize)
 	}
 
+	if bs.UseAltDA && (cfg.AltDAFailoverThreshold > 0 || cfg.AltDAFailoverTimeout > 0) && cfg.AltDAFailoverDAType == flags.BlobsType {
+		// while failed over, every frame is sent in its own blob
+		if cc.MaxFrameSize > eth.MaxBlobDataSize-1 {
+			return fmt.Errorf("max frame size %d exceeds blob size for Alt-DA failover to blobs", cc.MaxFrameSize)
+		}
+		if !bs.RollupConfig.IsEcotone(uint64(time.Now().Unix())) {
+			return errors.New("cannot fail over from Alt-DA to blobs before Ecotone")
+		}
+	}
+
 	cc.InitCompressorConfig(cfg.ApproxComprRatio, cfg.Compressor, cfg.CompressionAlgo)
 
 	if cc.UseBlobs && !bs.RollupConfig.IsEcotone(uint64(time.Now().Unix())) {
@@ -281,6 +327,9 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 	if cc.CompressorConfig.CompressionAlgo.IsBrotli() && !bs.RollupConfig.IsFjord(uint64(time.Now().Unix())) {
 		return errors.New("cannot use brotli compression before Fjord")
 	}
+	if cc.CompressorConfig.CompressionAlgo == derive.Zstd && !bs.RollupConfig.IsZstdChannel(uint64(time.Now().Unix())) {
+		return errors.New("cannot use zstd compression before zstd channel activation")
+	}
 
 	if err := cc.Check(); err != nil {
 		return fmt.Errorf("invalid channel configuration: %w", err)
@@ -300,7 +349,8 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 		bs.Log.Warn("Alt-DA Mode is a Beta feature of the MIT licensed OP Stack.  While it has received initial review from core contributors, it is still undergoing testing, and may have bugs or other issues.")
 	}
 
-	if cfg.DataAvailabilityType == flags.AutoType {
+	bs.DAChannelConfigs = map[flags.DataAvailabilityType]ChannelConfig{}
+	if cc.UseBlobs {
 		// copy blobs config and use hardcoded calldata fallback config for now
 		calldataCC := cc
 		calldataCC.TargetNumFrames = 1
@@ -308,7 +358,25 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 		calldataCC.UseBlobs = false
 		calldataCC.ReinitCompressorConfig()
 
-		bs.ChannelConfig = NewDynamicEthChannelConfig(bs.Log, 10*time.Second, bs.TxManager, cc, calldataCC)
+		bs.DAChannelConfigs[flags.BlobsType] = cc
+		bs.DAChannelConfigs[flags.CalldataType] = calldataCC
+	} else {
+		bs.DAChannelConfigs[flags.CalldataType] = cc
+		// Alt-DA commitments are posted in calldata, so there is no blob config to switch to
+		if !bs.UseAltDA && bs.RollupConfig.IsEcotone(uint64(time.Now().Unix())) {
+			blobCC := cc
+			blobCC.MaxFrameSize = eth.MaxBlobDataSize - 1
+			blobCC.UseBlobs = true
+			blobCC.ReinitCompressorConfig()
+			if blobCC.TargetNumFrames <= maxBlobsPerBlock && blobCC.Check() == nil {
+				bs.DAChannelConfigs[flags.BlobsType] = blobCC
+			}
+		}
+	}
+
+	if cfg.DataAvailabilityType == flags.AutoType {
+		bs.ChannelConfig = NewDynamicEthChannelConfig(bs.Log, 10*time.Second, bs.TxManager,
+			bs.DAChannelConfigs[flags.BlobsType], bs.DAChannelConfigs[flags.CalldataType])
 	} else {
 		bs.ChannelConfig = cc
 	}
@@ -371,6 +439,7 @@ func (bs *BatcherService) initDriver(opts ...DriverSetupOption) {
 		L1Client:         bs.L1Client,
 		EndpointProvider: bs.EndpointProvider,
 		ChannelConfig:    bs.ChannelConfig,
+		DAChannelConfigs: bs.DAChannelConfigs,
 		AltDA:            bs.AltDA,
 	}
 	for _, opt := range opts {
@@ -482,6 +551,9 @@ func (bs *BatcherService) Stop(ctx context.Context) error {
 	if bs.L1Client != nil {
 		bs.L1Client.Close()
 	}
+	if bs.l1Multi != nil {
+		bs.l1Multi.Close()
+	}
 	if bs.EndpointProvider != nil {
 		bs.EndpointProvider.Close()
 	}
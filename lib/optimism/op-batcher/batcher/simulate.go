@@ -0,0 +1,188 @@
+package batcher
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/params"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SimulationConfig configures an offline batch submission simulation, see Simulate.
+type SimulationConfig struct {
+	// MaxL1TxSize is the maximum size of a calldata batcher tx.
+	MaxL1TxSize uint64
+	// TargetNumFrames is the number of blobs per blob tx. Calldata txs always hold a single frame.
+	TargetNumFrames int
+	// ApproxComprRatio to assume for the ratio compressor.
+	ApproxComprRatio      float64
+	BatchType             uint
+	MaxBlocksPerSpanBatch int
+
+	// L1 fee inputs used to estimate the cost of each batcher tx.
+	BaseFee, TipCap, BlobBaseFee *big.Int
+	// IsPectra enables the EIP-7623 calldata floor cost.
+	IsPectra bool
+}
+
+// SimulationResult is the outcome of submitting a range of L2 blocks with a single channel configuration.
+type SimulationResult struct {
+	UseBlobs        bool                   `json:"useBlobs"`
+	Compressor      string                 `json:"compressor"`
+	CompressionAlgo derive.CompressionAlgo `json:"compressionAlgo"`
+
+	Channels int `json:"channels"`
+	Txs      int `json:"txs"`
+	Frames   int `json:"frames"`
+	Blobs    int `json:"blobs"`
+
+	InputBytes  int     `json:"inputBytes"`
+	OutputBytes int     `json:"outputBytes"`
+	ComprRatio  float64 `json:"comprRatio"`
+	// L1Cost is the estimated L1 cost of all batcher txs in wei.
+	L1Cost *big.Int `json:"l1Cost"`
+
+	// Err is set if the configuration couldn't be simulated, only the configuration fields are then set.
+	Err string `json:"error,omitempty"`
+}
+
+// Simulate feeds the given contiguous L2 blocks through a channel manager for every
+// combination of compressor kind, compression algorithm and blobs vs. calldata,
+// and returns the resulting number of channels, txs, frames and bytes together
+// with the estimated L1 cost of each combination.
+//
+// Combinations that are invalid for the given blocks, like brotli compression
+// before Fjord, are reported with their error set.
+func Simulate(lgr log.Logger, rollupCfg *rollup.Config, blocks []*types.Block, cfg SimulationConfig) ([]SimulationResult, error) {
+	if len(blocks) == 0 {
+		return nil, errors.New("no blocks to simulate")
+	}
+	kinds := slices.Clone(compressor.KindKeys)
+	slices.Sort(kinds)
+	var results []SimulationResult
+	for _, useBlobs := range []bool{true, false} {
+		for _, kind := range kinds {
+			for _, algo := range derive.CompressionAlgos {
+				cc := simulationChannelConfig(rollupCfg, cfg, useBlobs)
+				cc.InitCompressorConfig(cfg.ApproxComprRatio, kind, algo)
+				res, err := simulateChannelConfig(lgr, rollupCfg, blocks, cc, cfg)
+				if err != nil {
+					res = SimulationResult{UseBlobs: useBlobs, Compressor: kind, CompressionAlgo: algo, Err: err.Error()}
+				}
+				results = append(results, res)
+			}
+		}
+	}
+	return results, nil
+}
+
+func simulationChannelConfig(rollupCfg *rollup.Config, cfg SimulationConfig, useBlobs bool) ChannelConfig {
+	channelTimeout := rollupCfg.ChannelTimeoutBedrock
+	if rollupCfg.GraniteTime != nil {
+		channelTimeout = params.ChannelTimeoutGranite
+	}
+	cc := ChannelConfig{
+		SeqWindowSize:         rollupCfg.SeqWindowSize,
+		ChannelTimeout:        channelTimeout,
+		MaxFrameSize:          cfg.MaxL1TxSize - 1, // account for version byte prefix
+		MaxBlocksPerSpanBatch: cfg.MaxBlocksPerSpanBatch,
+		TargetNumFrames:       1,
+		BatchType:             cfg.BatchType,
+	}
+	if useBlobs {
+		cc.MaxFrameSize = eth.MaxBlobDataSize - 1
+		cc.TargetNumFrames = cfg.TargetNumFrames
+		cc.UseBlobs = true
+	}
+	return cc
+}
+
+// simulateChannelConfig submits all blocks with the given channel config and accounts all resulting txs.
+// Channels are only closed when they are full, except for the last channel, which is closed once all
+// blocks got added.
+func simulateChannelConfig(lgr log.Logger, rollupCfg *rollup.Config, blocks []*types.Block, cc ChannelConfig, cfg SimulationConfig) (SimulationResult, error) {
+	res := SimulationResult{
+		UseBlobs:        cc.UseBlobs,
+		Compressor:      cc.CompressorConfig.Kind,
+		CompressionAlgo: cc.CompressorConfig.CompressionAlgo,
+		L1Cost:          new(big.Int),
+	}
+	if err := cc.Check(); err != nil {
+		return res, fmt.Errorf("invalid channel config: %w", err)
+	}
+	if cc.CompressorConfig.CompressionAlgo.IsBrotli() && !rollupCfg.IsFjord(blocks[0].Time()) {
+		return res, errors.New("cannot use brotli compression before Fjord")
+	}
+	if cc.CompressorConfig.CompressionAlgo == derive.Zstd && !rollupCfg.IsZstdChannel(blocks[0].Time()) {
+		return res, errors.New("cannot use zstd compression before zstd channel activation")
+	}
+
+	m := NewChannelManager(lgr, metrics.NoopMetrics, cc, rollupCfg)
+	for _, block := range blocks {
+		if err := m.AddL2Block(block); err != nil {
+			return res, fmt.Errorf("adding L2 block %s: %w", eth.ToBlockID(block), err)
+		}
+	}
+
+	for {
+		// The zero L1 head never triggers channel timeouts, so channels only close when full.
+		txd, err := m.TxData(eth.BlockID{}, cfg.IsPectra)
+		if errors.Is(err, io.EOF) {
+			if m.pendingBlocks() > 0 || m.currentChannel == nil || m.currentChannel.IsFull() {
+				break
+			}
+			m.currentChannel.Close()
+			if err := m.outputFrames(); err != nil {
+				return res, err
+			}
+			continue
+		} else if err != nil {
+			return res, fmt.Errorf("getting tx data: %w", err)
+		}
+
+		res.Txs++
+		res.Frames += len(txd.Frames())
+		res.OutputBytes += txd.Len()
+		if txd.asBlob {
+			res.Blobs += len(txd.Frames())
+			res.L1Cost.Add(res.L1Cost, computeSingleBlobTxCost(len(txd.Frames()), cfg.BaseFee, cfg.TipCap, cfg.BlobBaseFee))
+		} else {
+			res.L1Cost.Add(res.L1Cost, computeSingleCalldataTxCost(calldataTokens(txd.CallData()), cfg.BaseFee, cfg.TipCap, cfg.IsPectra))
+		}
+	}
+	if m.pendingBlocks() > 0 {
+		return res, fmt.Errorf("%d blocks left unsubmitted", m.pendingBlocks())
+	}
+
+	res.Channels = len(m.channelQueue)
+	for _, c := range m.channelQueue {
+		res.InputBytes += c.InputBytes()
+	}
+	if res.InputBytes > 0 {
+		res.ComprRatio = float64(res.OutputBytes) / float64(res.InputBytes)
+	}
+	return res, nil
+}
+
+// calldataTokens returns the number of EIP-7623 tokens of the given calldata.
+func calldataTokens(data []byte) uint64 {
+	var tokens uint64
+	for _, b := range data {
+		if b == 0 {
+			tokens++
+		} else {
+			tokens += 4
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,86 @@
+package batcher
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	l := testlog.Logger(t, log.LevelCrit)
+	rng := rand.New(rand.NewSource(1234))
+
+	// build a chain of random L2 blocks
+	var blocks []*types.Block
+	parent := common.Hash{}
+	for i := 0; i < 8; i++ {
+		b := derivetest.RandomL2BlockWithChainId(rng, 20, defaultTestRollupConfig.L2ChainID)
+		h := b.Header()
+		h.ParentHash = parent
+		h.Number = big.NewInt(int64(i + 1))
+		b = types.NewBlockWithHeader(h).WithBody(types.Body{Transactions: b.Transactions()})
+		blocks = append(blocks, b)
+		parent = b.Hash()
+	}
+
+	results, err := Simulate(l, defaultTestRollupConfig, blocks, SimulationConfig{
+		MaxL1TxSize:      1_000,
+		TargetNumFrames:  2,
+		ApproxComprRatio: 0.6,
+		BaseFee:          big.NewInt(10e9),
+		TipCap:           big.NewInt(1e9),
+		BlobBaseFee:      big.NewInt(1),
+		IsPectra:         true,
+	})
+	require.NoError(t, err)
+
+	var blobCost, calldataCost *big.Int
+	for _, r := range results {
+		if r.CompressionAlgo.IsBrotli() {
+			require.Contains(t, r.Err, "Fjord", "brotli must be rejected before Fjord")
+			continue
+		}
+		if r.CompressionAlgo == derive.Zstd {
+			require.Contains(t, r.Err, "zstd", "zstd must be rejected before activation")
+			continue
+		}
+		require.Empty(t, r.Err)
+		require.Positive(t, r.Channels)
+		require.Positive(t, r.Txs)
+		require.Positive(t, r.InputBytes)
+		require.Positive(t, r.OutputBytes)
+		require.Positive(t, r.L1Cost.Sign())
+		if r.UseBlobs {
+			require.Equal(t, r.Frames, r.Blobs)
+			require.LessOrEqual(t, r.Frames, r.Txs*2)
+		} else {
+			require.Zero(t, r.Blobs)
+			require.Equal(t, r.Txs, r.Frames)
+			require.Greater(t, r.Txs, 1, "calldata channels must be split up into multiple txs")
+		}
+		if r.Compressor == "ratio" {
+			if r.UseBlobs {
+				blobCost = r.L1Cost
+			} else {
+				calldataCost = r.L1Cost
+			}
+		}
+	}
+	// with a blob base fee of 1 wei, blobs must be cheaper
+	require.NotNil(t, blobCost)
+	require.NotNil(t, calldataCost)
+	require.Equal(t, -1, blobCost.Cmp(calldataCost))
+}
+
+func TestCalldataTokens(t *testing.T) {
+	require.Equal(t, uint64(0), calldataTokens(nil))
+	require.Equal(t, uint64(1+4+4+1), calldataTokens([]byte{0, 1, 0xff, 0}))
+}
@@ -0,0 +1,131 @@
+package batcher
+
+import (
+	"math"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+)
+
+// ThrottleParams are the DA limits to impose on block building via miner_setMaxDASize.
+// A zero limit means no limit.
+type ThrottleParams struct {
+	MaxTxSize    uint64
+	MaxBlockSize uint64
+	// Intensity is the throttling intensity, between 0 (not throttling) and 1 (fully throttling).
+	Intensity float64
+}
+
+// ThrottleController computes the DA limits to impose on block building from the
+// current backlog of pending DA bytes.
+type ThrottleController interface {
+	// Update returns the throttle params for the pending DA bytes observed at time now.
+	Update(now time.Time, pendingBytes int64) ThrottleParams
+}
+
+// NewThrottleController returns the throttle controller selected by the batcher config.
+// The step controller is used if no controller type is set.
+func NewThrottleController(cfg BatcherConfig) ThrottleController {
+	switch cfg.ThrottleController {
+	case flags.PIDControllerType:
+		return &PIDThrottleController{
+			Threshold:       cfg.ThrottleThreshold,
+			TxSize:          cfg.ThrottleTxSize,
+			BlockSize:       cfg.ThrottleBlockSize,
+			AlwaysBlockSize: cfg.ThrottleAlwaysBlockSize,
+			Kp:              cfg.ThrottlePIDKp,
+			Ki:              cfg.ThrottlePIDKi,
+			Kd:              cfg.ThrottlePIDKd,
+		}
+	default:
+		return &StepThrottleController{
+			Threshold:       cfg.ThrottleThreshold,
+			TxSize:          cfg.ThrottleTxSize,
+			BlockSize:       cfg.ThrottleBlockSize,
+			AlwaysBlockSize: cfg.ThrottleAlwaysBlockSize,
+		}
+	}
+}
+
+// StepThrottleController imposes the AlwaysBlockSize limit while the pending bytes are
+// at most Threshold, and the TxSize and BlockSize limits once they are over it.
+type StepThrottleController struct {
+	Threshold, TxSize, BlockSize, AlwaysBlockSize uint64
+}
+
+var _ ThrottleController = (*StepThrottleController)(nil)
+
+func (c *StepThrottleController) Update(_ time.Time, pendingBytes int64) ThrottleParams {
+	p := ThrottleParams{MaxBlockSize: c.AlwaysBlockSize}
+	if pendingBytes > int64(c.Threshold) {
+		p.Intensity = 1
+		p.MaxTxSize = c.TxSize
+		if p.MaxBlockSize == 0 || (c.BlockSize != 0 && c.BlockSize < p.MaxBlockSize) {
+			p.MaxBlockSize = c.BlockSize
+		}
+	}
+	return p
+}
+
+// PIDThrottleController scales the DA limits between no throttling and full throttling,
+// instead of switching between them at the threshold, to avoid oscillating block fullness.
+//
+// The controlled error is the amount of pending bytes over the threshold, relative to the
+// threshold, so that with a Kp of 1 and no other gains, throttling starts at the threshold
+// and reaches full intensity at twice the threshold. The intensity is the clamped PID output.
+// The block limit is then interpolated between AlwaysBlockSize and BlockSize, and the tx
+// limit between AlwaysBlockSize and TxSize.
+type PIDThrottleController struct {
+	Threshold, TxSize, BlockSize, AlwaysBlockSize uint64
+
+	// Kp, Ki and Kd are the proportional, integral (per second) and derivative (in seconds) gains.
+	Kp, Ki, Kd float64
+
+	integral float64
+	lastErr  float64
+	lastTime time.Time
+}
+
+var _ ThrottleController = (*PIDThrottleController)(nil)
+
+func (c *PIDThrottleController) Update(now time.Time, pendingBytes int64) ThrottleParams {
+	threshold := float64(max(c.Threshold, 1))
+	e := (float64(pendingBytes) - threshold) / threshold
+
+	var deriv float64
+	if !c.lastTime.IsZero() {
+		if dt := now.Sub(c.lastTime).Seconds(); dt > 0 {
+			c.integral += e * dt
+			deriv = (e - c.lastErr) / dt
+		}
+	}
+	c.lastErr, c.lastTime = e, now
+	// Prevent integral windup by bounding the integral term's contribution to [0, 1].
+	if c.Ki > 0 {
+		c.integral = clamp(c.integral, 0, 1/c.Ki)
+	} else {
+		c.integral = 0
+	}
+
+	u := c.Kp*e + c.Ki*c.integral + c.Kd*deriv
+	if math.IsNaN(u) {
+		u = 1
+	}
+	u = clamp(u, 0, 1)
+
+	p := ThrottleParams{MaxBlockSize: c.AlwaysBlockSize, Intensity: u}
+	if u > 0 {
+		p.MaxTxSize = interpolate(c.AlwaysBlockSize, c.TxSize, u)
+		p.MaxBlockSize = interpolate(c.AlwaysBlockSize, c.BlockSize, u)
+	}
+	return p
+}
+
+func clamp(x, lo, hi float64) float64 {
+	return math.Min(math.Max(x, lo), hi)
+}
+
+// interpolate linearly interpolates from a to b by t in [0, 1].
+func interpolate(a, b uint64, t float64) uint64 {
+	return uint64(math.Round(float64(a) + (float64(b)-float64(a))*t))
+}
@@ -0,0 +1,75 @@
+package batcher
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewThrottleController(t *testing.T) {
+	require.IsType(t, &StepThrottleController{}, NewThrottleController(BatcherConfig{}))
+	require.IsType(t, &StepThrottleController{}, NewThrottleController(BatcherConfig{ThrottleController: flags.StepControllerType}))
+	require.IsType(t, &PIDThrottleController{}, NewThrottleController(BatcherConfig{ThrottleController: flags.PIDControllerType}))
+}
+
+func TestStepThrottleController(t *testing.T) {
+	c := &StepThrottleController{Threshold: 1000, TxSize: 50, BlockSize: 200, AlwaysBlockSize: 500}
+	now := time.Now()
+	require.Equal(t, ThrottleParams{MaxBlockSize: 500}, c.Update(now, 0))
+	require.Equal(t, ThrottleParams{MaxBlockSize: 500}, c.Update(now, 1000))
+	require.Equal(t, ThrottleParams{MaxTxSize: 50, MaxBlockSize: 200, Intensity: 1}, c.Update(now, 1001))
+
+	// no always-limit
+	c.AlwaysBlockSize = 0
+	require.Equal(t, ThrottleParams{}, c.Update(now, 0))
+	require.Equal(t, ThrottleParams{MaxTxSize: 50, MaxBlockSize: 200, Intensity: 1}, c.Update(now, 2000))
+
+	// block throttle limit larger than always-limit
+	c.AlwaysBlockSize, c.BlockSize = 100, 200
+	require.Equal(t, ThrottleParams{MaxTxSize: 50, MaxBlockSize: 100, Intensity: 1}, c.Update(now, 2000))
+}
+
+func TestPIDThrottleController_Proportional(t *testing.T) {
+	c := &PIDThrottleController{Threshold: 1000, TxSize: 100, BlockSize: 200, AlwaysBlockSize: 1200, Kp: 1}
+	now := time.Now()
+	require.Equal(t, ThrottleParams{MaxBlockSize: 1200}, c.Update(now, 500))
+	require.Equal(t, ThrottleParams{MaxBlockSize: 1200}, c.Update(now, 1000))
+	require.Equal(t, ThrottleParams{MaxTxSize: 1090, MaxBlockSize: 1100, Intensity: 0.1}, c.Update(now, 1100))
+	require.Equal(t, ThrottleParams{MaxTxSize: 650, MaxBlockSize: 700, Intensity: 0.5}, c.Update(now, 1500))
+	require.Equal(t, ThrottleParams{MaxTxSize: 100, MaxBlockSize: 200, Intensity: 1}, c.Update(now, 2000))
+	require.Equal(t, ThrottleParams{MaxTxSize: 100, MaxBlockSize: 200, Intensity: 1}, c.Update(now, math.MaxInt64))
+	require.Equal(t, ThrottleParams{MaxBlockSize: 1200}, c.Update(now, 0))
+}
+
+func TestPIDThrottleController_Integral(t *testing.T) {
+	c := &PIDThrottleController{Threshold: 1000, TxSize: 100, BlockSize: 200, AlwaysBlockSize: 1200, Ki: 0.1}
+	now := time.Now()
+	require.Zero(t, c.Update(now, 1500).Intensity)
+
+	// a constant backlog over the threshold increases the intensity over time
+	now = now.Add(2 * time.Second)
+	require.InDelta(t, 0.1, c.Update(now, 1500).Intensity, 1e-9)
+	now = now.Add(2 * time.Second)
+	require.InDelta(t, 0.2, c.Update(now, 1500).Intensity, 1e-9)
+
+	// the integral is bounded so that it unwinds quickly
+	now = now.Add(time.Hour)
+	require.Equal(t, 1.0, c.Update(now, 1500).Intensity)
+	now = now.Add(10 * time.Second)
+	require.Zero(t, c.Update(now, 0).Intensity)
+}
+
+func TestPIDThrottleController_Derivative(t *testing.T) {
+	c := &PIDThrottleController{Threshold: 1000, TxSize: 100, BlockSize: 200, AlwaysBlockSize: 1200, Kd: 1}
+	now := time.Now()
+	require.Zero(t, c.Update(now, 1000).Intensity)
+
+	// a growing backlog is throttled even below the threshold
+	now = now.Add(time.Second)
+	require.InDelta(t, 0.3, c.Update(now, 1300).Intensity, 1e-9)
+	now = now.Add(time.Second)
+	require.Zero(t, c.Update(now, 1300).Intensity)
+}
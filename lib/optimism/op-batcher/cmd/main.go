@@ -7,6 +7,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/batcher"
+	"github.com/ethereum-optimism/optimism/op-batcher/cmd/simulate"
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
@@ -38,6 +39,7 @@ func main() {
 			Name:        "doc",
 			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
 		},
+		simulate.Command,
 	}
 
 	ctx := ctxinterrupt.WithSignalWaiterMain(context.Background())
@@ -0,0 +1,296 @@
+package simulate
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/batcher"
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var (
+	l2EthRpcFlag = &cli.StringFlag{
+		Name:  "l2-eth-rpc",
+		Usage: "HTTP provider URL for L2 execution engine to read the blocks from. Mutually exclusive with --blocks-file.",
+	}
+	blocksFileFlag = &cli.PathFlag{
+		Name:  "blocks-file",
+		Usage: "Path to an RLP encoded block file, as written by 'geth export', to read the blocks from. Mutually exclusive with --l2-eth-rpc.",
+	}
+	startFlag = &cli.Uint64Flag{
+		Name:     "start",
+		Usage:    "First L2 block number of the range to simulate",
+		Required: true,
+	}
+	endFlag = &cli.Uint64Flag{
+		Name:     "end",
+		Usage:    "Last L2 block number (inclusive) of the range to simulate",
+		Required: true,
+	}
+	rollupConfigFlag = &cli.PathFlag{
+		Name:  "rollup-config",
+		Usage: "Path to the rollup config of the chain. Mutually exclusive with --network.",
+	}
+	networkFlag = &cli.StringFlag{
+		Name:  "network",
+		Usage: fmt.Sprintf("Predefined network to take the rollup config from. Mutually exclusive with --rollup-config. Available networks: %s", strings.Join(chaincfg.AvailableNetworks(), ", ")),
+	}
+	maxL1TxSizeFlag = &cli.Uint64Flag{
+		Name:  flags.MaxL1TxSizeBytesFlag.Name,
+		Usage: "The maximum size of a calldata batch tx",
+		Value: flags.MaxL1TxSizeBytesFlag.Value,
+	}
+	targetNumFramesFlag = &cli.IntFlag{
+		Name:  flags.TargetNumFramesFlag.Name,
+		Usage: "The target number of frames per channel, i.e. the number of blobs per blob tx. Calldata txs always hold a single frame.",
+		Value: flags.TargetNumFramesFlag.Value,
+	}
+	approxComprRatioFlag = &cli.Float64Flag{
+		Name:  flags.ApproxComprRatioFlag.Name,
+		Usage: flags.ApproxComprRatioFlag.Usage,
+		Value: flags.ApproxComprRatioFlag.Value,
+	}
+	batchTypeFlag = &cli.UintFlag{
+		Name:  flags.BatchTypeFlag.Name,
+		Usage: flags.BatchTypeFlag.Usage,
+		Value: flags.BatchTypeFlag.Value,
+	}
+	maxBlocksPerSpanBatchFlag = &cli.IntFlag{
+		Name:  flags.MaxBlocksPerSpanBatch.Name,
+		Usage: flags.MaxBlocksPerSpanBatch.Usage,
+		Value: flags.MaxBlocksPerSpanBatch.Value,
+	}
+	baseFeeFlag = &cli.Float64Flag{
+		Name:     "l1-base-fee",
+		Usage:    "L1 base fee in gwei to estimate costs with",
+		Required: true,
+	}
+	tipCapFlag = &cli.Float64Flag{
+		Name:  "l1-tip-cap",
+		Usage: "L1 priority fee in gwei to estimate costs with",
+		Value: 1,
+	}
+	blobBaseFeeFlag = &cli.Float64Flag{
+		Name:     "l1-blob-base-fee",
+		Usage:    "L1 blob base fee in gwei to estimate costs with",
+		Required: true,
+	}
+	pectraFlag = &cli.BoolFlag{
+		Name:  "pectra",
+		Usage: "Estimate calldata costs with the Pectra (EIP-7623) calldata floor",
+	}
+	jsonFlag = &cli.BoolFlag{
+		Name:  "json",
+		Usage: "Print the report as JSON instead of a table",
+	}
+)
+
+var Command = &cli.Command{
+	Name:  "batch-simulate",
+	Usage: "Simulates batch submission of an L2 block range with all channel configurations and reports the estimated L1 costs",
+	Description: "Feeds a range of L2 blocks through the batcher's channel manager for every combination of compressor kind, " +
+		"compression algorithm and blobs vs. calldata, and reports the resulting frames, blobs, bytes, compression ratio " +
+		"and estimated L1 cost for the given L1 fees. All channels are assumed to only be closed when full.",
+	Flags: append([]cli.Flag{
+		l2EthRpcFlag,
+		blocksFileFlag,
+		startFlag,
+		endFlag,
+		rollupConfigFlag,
+		networkFlag,
+		maxL1TxSizeFlag,
+		targetNumFramesFlag,
+		approxComprRatioFlag,
+		batchTypeFlag,
+		maxBlocksPerSpanBatchFlag,
+		baseFeeFlag,
+		tipCapFlag,
+		blobBaseFeeFlag,
+		pectraFlag,
+		jsonFlag,
+	}, oplog.CLIFlags("OP_BATCHER")...),
+	Action: func(cliCtx *cli.Context) error {
+		// Log to stderr to keep the report on stdout parseable.
+		logger := oplog.NewLogger(os.Stderr, oplog.ReadCLIConfig(cliCtx))
+
+		rollupCfg, err := loadRollupConfig(cliCtx)
+		if err != nil {
+			return err
+		}
+		start, end := cliCtx.Uint64(startFlag.Name), cliCtx.Uint64(endFlag.Name)
+		if end < start {
+			return fmt.Errorf("end block %d is before start block %d", end, start)
+		}
+		var blocks []*types.Block
+		switch rpcURL, file := cliCtx.String(l2EthRpcFlag.Name), cliCtx.Path(blocksFileFlag.Name); {
+		case rpcURL != "" && file != "":
+			return errors.New("only one of --l2-eth-rpc and --blocks-file may be set")
+		case rpcURL != "":
+			blocks, err = fetchBlocks(cliCtx.Context, rpcURL, start, end)
+		case file != "":
+			blocks, err = readBlocksFile(file, start, end)
+		default:
+			return errors.New("one of --l2-eth-rpc or --blocks-file must be set")
+		}
+		if err != nil {
+			return err
+		}
+		logger.Info("Loaded L2 blocks", "start", start, "end", end, "count", len(blocks))
+
+		results, err := batcher.Simulate(logger, rollupCfg, blocks, batcher.SimulationConfig{
+			MaxL1TxSize:           cliCtx.Uint64(maxL1TxSizeFlag.Name),
+			TargetNumFrames:       cliCtx.Int(targetNumFramesFlag.Name),
+			ApproxComprRatio:      cliCtx.Float64(approxComprRatioFlag.Name),
+			BatchType:             cliCtx.Uint(batchTypeFlag.Name),
+			MaxBlocksPerSpanBatch: cliCtx.Int(maxBlocksPerSpanBatchFlag.Name),
+			BaseFee:               gweiToWei(cliCtx.Float64(baseFeeFlag.Name)),
+			TipCap:                gweiToWei(cliCtx.Float64(tipCapFlag.Name)),
+			BlobBaseFee:           gweiToWei(cliCtx.Float64(blobBaseFeeFlag.Name)),
+			IsPectra:              cliCtx.Bool(pectraFlag.Name),
+		})
+		if err != nil {
+			return err
+		}
+		sortResults(results)
+
+		if cliCtx.Bool(jsonFlag.Name) {
+			enc := json.NewEncoder(cliCtx.App.Writer)
+			enc.SetIndent("", "  ")
+			return enc.Encode(results)
+		}
+		return writeTable(cliCtx.App.Writer, results)
+	},
+}
+
+func loadRollupConfig(cliCtx *cli.Context) (*rollup.Config, error) {
+	path, network := cliCtx.Path(rollupConfigFlag.Name), cliCtx.String(networkFlag.Name)
+	switch {
+	case path != "" && network != "":
+		return nil, errors.New("only one of --rollup-config and --network may be set")
+	case path != "":
+		cfg, err := jsonutil.LoadJSON[rollup.Config](path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load rollup config: %w", err)
+		}
+		return cfg, nil
+	case network != "":
+		return chaincfg.GetRollupConfig(network)
+	default:
+		return nil, errors.New("one of --rollup-config or --network must be set")
+	}
+}
+
+func fetchBlocks(ctx context.Context, rpcURL string, start, end uint64) ([]*types.Block, error) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L2 RPC: %w", err)
+	}
+	defer client.Close()
+	blocks := make([]*types.Block, 0, end-start+1)
+	for n := start; n <= end; n++ {
+		block, err := client.BlockByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch L2 block %d: %w", n, err)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// readBlocksFile reads the blocks in the given range from an RLP block stream,
+// as written by 'geth export'. Files ending in .gz are decompressed.
+func readBlocksFile(path string, start, end uint64) ([]*types.Block, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blocks file: %w", err)
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzipped blocks file: %w", err)
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	stream := rlp.NewStream(r, 0)
+	var blocks []*types.Block
+	for {
+		var block types.Block
+		if err := stream.Decode(&block); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode block: %w", err)
+		}
+		if n := block.NumberU64(); n < start || n > end {
+			continue
+		}
+		blocks = append(blocks, &block)
+	}
+	if uint64(len(blocks)) != end-start+1 {
+		return nil, fmt.Errorf("blocks file holds %d of the %d blocks in range", len(blocks), end-start+1)
+	}
+	slices.SortFunc(blocks, func(a, b *types.Block) int { return a.Number().Cmp(b.Number()) })
+	return blocks, nil
+}
+
+func gweiToWei(gwei float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(params.GWei)).Int(nil)
+	return wei
+}
+
+// sortResults sorts the results by ascending L1 cost. Failed simulations go last.
+func sortResults(results []batcher.SimulationResult) {
+	slices.SortStableFunc(results, func(a, b batcher.SimulationResult) int {
+		switch {
+		case a.Err != "" && b.Err != "":
+			return 0
+		case a.Err != "":
+			return 1
+		case b.Err != "":
+			return -1
+		}
+		return a.L1Cost.Cmp(b.L1Cost)
+	})
+}
+
+func writeTable(w io.Writer, results []batcher.SimulationResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DA\tCOMPRESSOR\tALGO\tCHANNELS\tTXS\tFRAMES\tBLOBS\tINPUT_BYTES\tOUTPUT_BYTES\tRATIO\tL1_COST_ETH\t")
+	for _, r := range results {
+		da := "calldata"
+		if r.UseBlobs {
+			da = "blobs"
+		}
+		if r.Err != "" {
+			fmt.Fprintf(tw, "%s\t%s\t%s\terror: %s\t\n", da, r.Compressor, r.CompressionAlgo, r.Err)
+			continue
+		}
+		cost := new(big.Float).Quo(new(big.Float).SetInt(r.L1Cost), big.NewFloat(params.Ether))
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%.4f\t%s\t\n",
+			da, r.Compressor, r.CompressionAlgo, r.Channels, r.Txs, r.Frames, r.Blobs,
+			r.InputBytes, r.OutputBytes, r.ComprRatio, cost.Text('f', 9))
+	}
+	return tw.Flush()
+}
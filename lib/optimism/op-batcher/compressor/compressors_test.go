@@ -7,7 +7,10 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 )
 
 func TestCloseOverheadZlib(t *testing.T) {
@@ -23,3 +26,33 @@ func TestCloseOverheadZlib(t *testing.T) {
 	csize := buf.Len()
 	require.Equal(t, CloseOverheadZlib, csize-fsize)
 }
+
+func TestCompressorsZstd(t *testing.T) {
+	data := bytes.Repeat(randomBytes(512), 8)
+	for _, kind := range []string{RatioKind, ShadowKind} {
+		t.Run(kind, func(t *testing.T) {
+			c, err := Kinds[kind](Config{
+				TargetOutputSize: 1 << 16,
+				ApproxComprRatio: 0.4,
+				CompressionAlgo:  derive.Zstd,
+			})
+			require.NoError(t, err)
+			_, err = c.Write(data)
+			require.NoError(t, err)
+			require.NoError(t, c.Close())
+			require.NoError(t, c.FullErr())
+
+			buf, err := io.ReadAll(c)
+			require.NoError(t, err)
+			require.Equal(t, derive.ChannelVersionZstd, buf[0])
+			require.Less(t, len(buf), len(data))
+
+			zr, err := zstd.NewReader(bytes.NewReader(buf[1:]))
+			require.NoError(t, err)
+			defer zr.Close()
+			uncompressed, err := io.ReadAll(zr)
+			require.NoError(t, err)
+			require.Equal(t, data, uncompressed)
+		})
+	}
+}
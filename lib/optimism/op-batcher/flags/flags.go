@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -129,7 +130,7 @@ var (
 	DataAvailabilityTypeFlag = &cli.GenericFlag{
 		Name: "data-availability-type",
 		Usage: "The data availability type to use for submitting batches to the L1. Valid options: " +
-			openum.EnumString(DataAvailabilityTypes),
+			openum.EnumString(DataAvailabilityTypes) + ". Only with auto, the type can be pinned at runtime with the admin_pinDAType RPC",
 		Value: func() *DataAvailabilityType {
 			out := CalldataType
 			return &out
@@ -180,12 +181,100 @@ var (
 		Value:   130_000, // should be larger than the builder's max-l2-tx-size to prevent endlessly throttling some txs
 		EnvVars: prefixEnvVars("THROTTLE_ALWAYS_BLOCK_SIZE"),
 	}
+	ThrottleControllerFlag = &cli.GenericFlag{
+		Name: "throttle-controller",
+		Usage: "The controller computing the DA limits to impose on block building from the pending bytes. " +
+			"'step' applies the throttle limits once over the threshold, 'pid' scales the block limit down from the " +
+			"always-limit towards the throttle limit with how far the pending bytes are over the threshold. Valid options: " +
+			openum.EnumString(ThrottleControllerTypes),
+		Value: func() *ThrottleControllerType {
+			out := StepControllerType
+			return &out
+		}(),
+		EnvVars: prefixEnvVars("THROTTLE_CONTROLLER"),
+	}
+	ThrottlePIDKpFlag = &cli.Float64Flag{
+		Name:    "throttle-pid-kp",
+		Usage:   "Proportional gain of the pid throttle controller, applied to the pending bytes over the threshold relative to the threshold",
+		Value:   1,
+		EnvVars: prefixEnvVars("THROTTLE_PID_KP"),
+	}
+	ThrottlePIDKiFlag = &cli.Float64Flag{
+		Name:    "throttle-pid-ki",
+		Usage:   "Integral gain (per second) of the pid throttle controller",
+		Value:   0,
+		EnvVars: prefixEnvVars("THROTTLE_PID_KI"),
+	}
+	ThrottlePIDKdFlag = &cli.Float64Flag{
+		Name:    "throttle-pid-kd",
+		Usage:   "Derivative gain (in seconds) of the pid throttle controller",
+		Value:   0,
+		EnvVars: prefixEnvVars("THROTTLE_PID_KD"),
+	}
 	PreferLocalSafeL2Flag = &cli.BoolFlag{
 		Name:    "prefer-local-safe-l2",
 		Usage:   "Load unsafe blocks higher than the sequencer's LocalSafeL2 instead of SafeL2",
 		Value:   false,
 		EnvVars: prefixEnvVars("PREFER_LOCAL_SAFE_L2"),
 	}
+	DataDirFlag = &cli.StringFlag{
+		Name: "data-dir",
+		Usage: "Directory to journal full channels to, so they can be resumed after a restart instead of being rebuilt. " +
+			"Journaling is disabled if empty.",
+		EnvVars: prefixEnvVars("DATA_DIR"),
+	}
+	AltDAFailoverThresholdFlag = &cli.Uint64Flag{
+		Name: "altda-failover-threshold",
+		Usage: "Number of consecutive failed requests to the Alt-DA server after which the batcher fails over to Ethereum DA. " +
+			"0 disables the threshold.",
+		Value:   0,
+		EnvVars: prefixEnvVars("ALTDA_FAILOVER_THRESHOLD"),
+	}
+	AltDAFailoverTimeoutFlag = &cli.DurationFlag{
+		Name: "altda-failover-timeout",
+		Usage: "Duration of consecutively failing requests to the Alt-DA server after which the batcher fails over to Ethereum DA. " +
+			"0 disables the timeout.",
+		Value:   0,
+		EnvVars: prefixEnvVars("ALTDA_FAILOVER_TIMEOUT"),
+	}
+	AltDAFailoverDATypeFlag = &cli.GenericFlag{
+		Name:  "altda-failover-da-type",
+		Usage: "The data availability type to fail over to when the Alt-DA server is unavailable. Valid options: blobs, calldata",
+		Value: func() *DataAvailabilityType {
+			out := CalldataType
+			return &out
+		}(),
+		EnvVars: prefixEnvVars("ALTDA_FAILOVER_DA_TYPE"),
+	}
+	AltDAFailoverProbeIntervalFlag = &cli.DurationFlag{
+		Name:    "altda-failover-probe-interval",
+		Usage:   "Interval at which the Alt-DA server is probed with a single request while failed over to Ethereum DA.",
+		Value:   time.Minute,
+		EnvVars: prefixEnvVars("ALTDA_FAILOVER_PROBE_INTERVAL"),
+	}
+	L1ExtraEthRpcsFlag = &cli.StringSliceFlag{
+		Name:    txmgr.L1ExtraRPCsFlagName,
+		Usage:   "HTTP provider URLs of additional L1 endpoints of the same chain, used for failover or quorum with l1-eth-rpc, also by the tx manager.",
+		EnvVars: prefixEnvVars("L1_ETH_EXTRA_RPCS"),
+	}
+	L1MultiModeFlag = &cli.GenericFlag{
+		Name: txmgr.L1MultiModeFlagName,
+		Usage: "How to use multiple L1 endpoints, if l1-eth-extra-rpcs is set. 'failover' rotates away from failing or lagging endpoints, " +
+			"'quorum' additionally requires L1 block headers to match across l1-quorum endpoints. Receipts and transactions are not checked against the headers. Valid options: " +
+			openum.EnumString(client.MultiModes),
+		EnvVars: prefixEnvVars("L1_MULTI_MODE"),
+		Value: func() *client.MultiMode {
+			out := client.MultiFailover
+			return &out
+		}(),
+	}
+	L1QuorumFlag = &cli.IntFlag{
+		Name: txmgr.L1QuorumFlagName,
+		Usage: "Number of L1 endpoints that must agree on block headers in quorum mode. Defaults to a majority of the endpoints if 0. " +
+			"A higher quorum needs more colluding endpoints to accept a wrong header, but fewer faulty endpoints to stall. " +
+			"Must be a majority and at most all but one of the endpoints, so quorum mode requires at least 3 endpoints.",
+		EnvVars: prefixEnvVars("L1_QUORUM"),
+	}
 	// Legacy Flags
 	SequencerHDPathFlag = txmgr.SequencerHDPathFlag
 )
@@ -197,6 +286,9 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	L1ExtraEthRpcsFlag,
+	L1MultiModeFlag,
+	L1QuorumFlag,
 	WaitNodeSyncFlag,
 	CheckRecentTxsDepthFlag,
 	SubSafetyMarginFlag,
@@ -218,7 +310,16 @@ var optionalFlags = []cli.Flag{
 	ThrottleTxSizeFlag,
 	ThrottleBlockSizeFlag,
 	ThrottleAlwaysBlockSizeFlag,
+	ThrottleControllerFlag,
+	ThrottlePIDKpFlag,
+	ThrottlePIDKiFlag,
+	ThrottlePIDKdFlag,
 	PreferLocalSafeL2Flag,
+	DataDirFlag,
+	AltDAFailoverThresholdFlag,
+	AltDAFailoverTimeoutFlag,
+	AltDAFailoverDATypeFlag,
+	AltDAFailoverProbeIntervalFlag,
 }
 
 func init() {
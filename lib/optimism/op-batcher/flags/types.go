@@ -40,3 +40,37 @@ func (kind *DataAvailabilityType) Clone() any {
 func ValidDataAvailabilityType(value DataAvailabilityType) bool {
 	return slices.Contains(DataAvailabilityTypes, value)
 }
+
+type ThrottleControllerType string
+
+const (
+	// throttle controller types
+	StepControllerType ThrottleControllerType = "step"
+	PIDControllerType  ThrottleControllerType = "pid"
+)
+
+var ThrottleControllerTypes = []ThrottleControllerType{
+	StepControllerType,
+	PIDControllerType,
+}
+
+func (kind ThrottleControllerType) String() string {
+	return string(kind)
+}
+
+func (kind *ThrottleControllerType) Set(value string) error {
+	if !ValidThrottleControllerType(ThrottleControllerType(value)) {
+		return fmt.Errorf("unknown throttle controller type: %q", value)
+	}
+	*kind = ThrottleControllerType(value)
+	return nil
+}
+
+func (kind *ThrottleControllerType) Clone() any {
+	cpy := *kind
+	return &cpy
+}
+
+func ValidThrottleControllerType(value ThrottleControllerType) bool {
+	return slices.Contains(ThrottleControllerTypes, value)
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 	"sync/atomic"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -54,6 +55,16 @@ type Metricer interface {
 
 	RecordBlobUsedBytes(num int)
 
+	// RecordThrottleParams records the output of the DA throttle controller. The intensity
+	// ranges from 0 (not throttling) to 1 (fully throttling).
+	RecordThrottleParams(intensity float64, maxTxSize, maxBlockSize uint64)
+
+	// RecordChannelCost records the L1 cost, in wei, of a fully submitted or timed out channel.
+	RecordChannelCost(executionCost, blobCost *big.Int, costPerL2Byte float64)
+
+	// RecordAltDAFailover records whether the batcher is failed over from Alt-DA to Ethereum DA.
+	RecordAltDAFailover(active bool)
+
 	Document() []opmetrics.DocumentedMetric
 
 	PendingDABytes() float64
@@ -96,6 +107,15 @@ type Metrics struct {
 	batcherTxEvs opmetrics.EventVec
 
 	blobUsedBytes prometheus.Histogram
+
+	throttleIntensity    prometheus.Gauge
+	throttleMaxTxSize    prometheus.Gauge
+	throttleMaxBlockSize prometheus.Gauge
+
+	channelL1Cost          *prometheus.CounterVec
+	channelL1CostPerL2Byte prometheus.Gauge
+
+	altDAFailover prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -208,6 +228,36 @@ func NewMetrics(procName string) *Metrics {
 			Help:      "Blob size in bytes (of last blob only for multi-blob txs).",
 			Buckets:   prometheus.LinearBuckets(0.0, eth.MaxBlobDataSize/13, 14),
 		}),
+		throttleIntensity: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "throttle_intensity",
+			Help:      "DA throttling intensity between 0 (not throttling) and 1 (fully throttling).",
+		}),
+		throttleMaxTxSize: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "throttle_max_tx_size",
+			Help:      "Max DA size per tx imposed on block building. Zero means no limit.",
+		}),
+		throttleMaxBlockSize: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "throttle_max_block_size",
+			Help:      "Max DA size per block imposed on block building. Zero means no limit.",
+		}),
+		channelL1Cost: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "channel_l1_cost_wei_total",
+			Help:      "Total L1 cost in wei of fully submitted or timed out channels, by execution or blob gas.",
+		}, []string{"kind"}),
+		channelL1CostPerL2Byte: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "channel_l1_cost_per_l2_byte_wei",
+			Help:      "L1 cost in wei per uncompressed L2 batch byte of the last fully submitted or timed out channel.",
+		}),
+		altDAFailover: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "altda_failover",
+			Help:      "1 if the batcher is failed over from Alt-DA to Ethereum DA, 0 otherwise.",
+		}),
 
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
 	}
@@ -349,6 +399,28 @@ func (m *Metrics) RecordBlobUsedBytes(num int) {
 	m.blobUsedBytes.Observe(float64(num))
 }
 
+func (m *Metrics) RecordThrottleParams(intensity float64, maxTxSize, maxBlockSize uint64) {
+	m.throttleIntensity.Set(intensity)
+	m.throttleMaxTxSize.Set(float64(maxTxSize))
+	m.throttleMaxBlockSize.Set(float64(maxBlockSize))
+}
+
+func (m *Metrics) RecordChannelCost(executionCost, blobCost *big.Int, costPerL2Byte float64) {
+	execF, _ := new(big.Float).SetInt(executionCost).Float64()
+	blobF, _ := new(big.Float).SetInt(blobCost).Float64()
+	m.channelL1Cost.WithLabelValues("execution").Add(execF)
+	m.channelL1Cost.WithLabelValues("blob").Add(blobF)
+	m.channelL1CostPerL2Byte.Set(costPerL2Byte)
+}
+
+func (m *Metrics) RecordAltDAFailover(active bool) {
+	if active {
+		m.altDAFailover.Set(1)
+	} else {
+		m.altDAFailover.Set(0)
+	}
+}
+
 func (m *Metrics) RecordChannelQueueLength(len int) {
 	m.channelQueueLength.Set(float64(len))
 }
@@ -3,6 +3,7 @@ package metrics
 import (
 	"io"
 	"math"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -45,6 +46,13 @@ func (*noopMetrics) RecordBatchTxSubmitted() {}
 func (*noopMetrics) RecordBatchTxSuccess()   {}
 func (*noopMetrics) RecordBatchTxFailed()    {}
 func (*noopMetrics) RecordBlobUsedBytes(int) {}
+
+func (*noopMetrics) RecordThrottleParams(float64, uint64, uint64) {}
+
+func (*noopMetrics) RecordChannelCost(*big.Int, *big.Int, float64) {}
+
+func (*noopMetrics) RecordAltDAFailover(bool) {}
+
 func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
 	return nil
 }
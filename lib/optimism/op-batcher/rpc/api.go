@@ -2,10 +2,12 @@ package rpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-service/apis"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
@@ -13,6 +15,12 @@ import (
 type BatcherDriver interface {
 	StartBatchSubmitting() error
 	StopBatchSubmitting(ctx context.Context) error
+	ChannelManagerState() ChannelManagerState
+	UpdateChannelConfig(update ChannelConfigUpdate) error
+	ChannelConfigOverrides() ChannelConfigUpdate
+	PinDAType(daType flags.DataAvailabilityType, duration time.Duration) error
+	ChannelCosts() []ChannelCost
+	AltDAState() (AltDAState, error)
 }
 
 type adminAPI struct {
@@ -43,3 +51,40 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.StopBatchSubmitting(ctx)
 }
+
+// ChannelManagerState returns a snapshot of the pending L2 blocks and channels
+// held by the batcher's channel manager.
+func (a *adminAPI) ChannelManagerState(_ context.Context) (ChannelManagerState, error) {
+	return a.b.ChannelManagerState(), nil
+}
+
+// UpdateChannelConfig validates and applies the given channel config update.
+// The update is applied on top of previous updates and takes effect from the next channel onward.
+func (a *adminAPI) UpdateChannelConfig(_ context.Context, update ChannelConfigUpdate) error {
+	return a.b.UpdateChannelConfig(update)
+}
+
+// ChannelConfigOverrides returns the channel config values currently overridden at runtime.
+func (a *adminAPI) ChannelConfigOverrides(_ context.Context) (ChannelConfigUpdate, error) {
+	return a.b.ChannelConfigOverrides(), nil
+}
+
+// PinDAType pins the data availability type chosen by the batcher in auto mode to blobs or calldata.
+// The pin expires after durationSecs seconds, or never if durationSecs is 0.
+// Pinning to auto removes any existing pin.
+// It returns an error if the batcher doesn't run with data availability type auto.
+// To switch the data availability type of a static batcher, use UpdateChannelConfig with a daType.
+func (a *adminAPI) PinDAType(_ context.Context, daType string, durationSecs uint64) error {
+	return a.b.PinDAType(flags.DataAvailabilityType(daType), time.Duration(durationSecs)*time.Second)
+}
+
+// ChannelCosts returns the L1 cost of the most recently confirmed or timed out channels, oldest first.
+func (a *adminAPI) ChannelCosts(_ context.Context) ([]ChannelCost, error) {
+	return a.b.ChannelCosts(), nil
+}
+
+// AltDAState returns whether the batcher posts to the Alt-DA server or is failed over to Ethereum DA.
+// It returns an error if Alt-DA is not enabled.
+func (a *adminAPI) AltDAState(_ context.Context) (AltDAState, error) {
+	return a.b.AltDAState()
+}
@@ -0,0 +1,16 @@
+package rpc
+
+import "github.com/ethereum-optimism/optimism/op-service/apis"
+
+// The admin API types are defined in op-service/apis, to share them with the batcher admin client.
+type (
+	ChannelManagerState = apis.ChannelManagerState
+	BlockQueueState     = apis.BlockQueueState
+	ChannelState        = apis.ChannelState
+	ConfirmedTx         = apis.ConfirmedTx
+	ChannelCost         = apis.ChannelCost
+	AltDAState          = apis.AltDAState
+	ChannelConfigUpdate = apis.ChannelConfigUpdate
+)
+
+const AltDAModeAltDA = apis.AltDAModeAltDA
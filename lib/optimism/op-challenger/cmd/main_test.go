@@ -394,6 +394,21 @@ func TestAsteriscOpProgramRequiredArgs(t *testing.T) {
 			require.Equal(t, "http://localhost/bar", cfg.AsteriscAbsolutePreStateBaseURL.String())
 		})
 	})
+
+	t.Run(fmt.Sprintf("TestSetAsteriscL2Custom-%v", traceType), func(t *testing.T) {
+		t.Run("Valid", func(t *testing.T) {
+			cfg := configForArgs(t, addRequiredArgsExcept(traceType, "--network",
+				"--asterisc-rollup-config=rollup.json",
+				"--asterisc-l2-genesis=genesis.json",
+				"--asterisc-l2-custom"))
+			require.True(t, cfg.Asterisc.L2Custom)
+		})
+
+		t.Run("RequiresRollupConfigAndGenesis", func(t *testing.T) {
+			verifyArgsInvalid(t, "flag rollup-config/asterisc-rollup-config and l2-genesis/asterisc-l2-genesis must be set when asterisc-l2-custom is true",
+				addRequiredArgs(traceType, "--asterisc-l2-custom"))
+		})
+	})
 }
 
 func TestAsteriscKonaRequiredArgs(t *testing.T) {
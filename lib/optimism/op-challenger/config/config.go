@@ -80,6 +80,8 @@ type Config struct {
 	SupervisorRPC string   // L2 supervisor RPC URL
 	L2Rpcs        []string // L2 RPC Url
 
+	NetworksDir string // Directory of custom networks to watch for changes
+
 	// Specific to the cannon trace provider
 	Cannon                        vm.Config
 	CannonAbsolutePreState        string   // File to load the absolute pre-state for Cannon traces from
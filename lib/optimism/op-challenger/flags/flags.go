@@ -59,6 +59,12 @@ var (
 		Usage:   fmt.Sprintf("Predefined network selection. Available networks: %s", strings.Join(chaincfg.AvailableNetworks(), ", ")),
 		EnvVars: prefixEnvVars("NETWORK"),
 	}
+	NetworksDirFlag = &cli.StringFlag{
+		Name: flags.NetworksDirFlagName,
+		Usage: "Directory of custom networks, with a subdirectory per network, named after the network or its chain ID. " +
+			"Each network directory has a rollup.json, and a genesis.json or chain-config.json. The networks are reloaded on change.",
+		EnvVars: prefixEnvVars("NETWORKS_DIR"),
+	}
 	FactoryAddressFlag = &cli.StringFlag{
 		Name:    "game-factory-address",
 		Usage:   "Address of the fault game factory contract.",
@@ -180,6 +186,14 @@ var (
 		EnvVars: prefixEnvVars("CANNON_INFO_FREQ"),
 		Value:   config.DefaultCannonInfoFreq,
 	}
+	AsteriscL2CustomFlag = &cli.BoolFlag{
+		Name: "asterisc-l2-custom",
+		Usage: "Notify the op-program host that the L2 chain uses custom config to be loaded via the preimage oracle. " +
+			"WARNING: This is incompatible with on-chain testing and must only be used for testing purposes.",
+		EnvVars: prefixEnvVars("ASTERISC_L2_CUSTOM"),
+		Value:   false,
+		Hidden:  true,
+	}
 	AsteriscBinFlag = &cli.StringFlag{
 		Name:    "asterisc-bin",
 		Usage:   "Path to asterisc executable to use when generating trace data (asterisc trace type only)",
@@ -248,6 +262,7 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	RollupRpcFlag,
 	NetworkFlag,
+	NetworksDirFlag,
 	FactoryAddressFlag,
 	TraceTypeFlag,
 	MaxConcurrencyFlag,
@@ -259,6 +274,7 @@ var optionalFlags = []cli.Flag{
 	AdditionalBondClaimants,
 	GameAllowlistFlag,
 	CannonL2CustomFlag,
+	AsteriscL2CustomFlag,
 	CannonBinFlag,
 	CannonServerFlag,
 	CannonPreStateFlag,
@@ -381,6 +397,10 @@ func CheckAsteriscFlags(ctx *cli.Context) error {
 	if !ctx.IsSet(AsteriscServerFlag.Name) {
 		return fmt.Errorf("flag %s is required", AsteriscServerFlag.Name)
 	}
+	if ctx.Bool(AsteriscL2CustomFlag.Name) && !(RollupConfigFlag.IsSet(ctx, types.TraceTypeAsterisc) && L2GenesisFlag.IsSet(ctx, types.TraceTypeAsterisc)) {
+		return fmt.Errorf("flag %v and %v must be set when %v is true",
+			RollupConfigFlag.EitherFlagName(types.TraceTypeAsterisc), L2GenesisFlag.EitherFlagName(types.TraceTypeAsterisc), AsteriscL2CustomFlag.Name)
+	}
 	if !PreStatesURLFlag.IsSet(ctx, types.TraceTypeAsterisc) && !ctx.IsSet(AsteriscPreStateFlag.Name) {
 		return fmt.Errorf("flag %s or %s is required", PreStatesURLFlag.EitherFlagName(types.TraceTypeAsterisc), AsteriscPreStateFlag.Name)
 	}
@@ -470,6 +490,9 @@ func FactoryAddress(ctx *cli.Context) (common.Address, error) {
 	}
 
 	network := networks[0]
+	if chaincfg.CustomChainByName(network) != nil {
+		return common.Address{}, fmt.Errorf("flag %v required for custom network %v", FactoryAddressFlag.Name, network)
+	}
 	chainCfg := chaincfg.ChainByName(network)
 	if chainCfg == nil {
 		var opts []string
@@ -494,6 +517,14 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 	if err := CheckRequired(ctx, traceTypes); err != nil {
 		return nil, err
 	}
+	networksDir := ctx.String(NetworksDirFlag.Name)
+	if networksDir != "" {
+		chains, err := chaincfg.LoadCustomChains(networksDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load custom networks: %w", err)
+		}
+		chaincfg.SetCustomChains(chains)
+	}
 	gameFactoryAddress, err := FactoryAddress(ctx)
 	if err != nil {
 		return nil, err
@@ -570,6 +601,7 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		PollInterval:            ctx.Duration(HTTPPollInterval.Name),
 		AdditionalBondClaimants: claimants,
 		RollupRpc:               ctx.String(RollupRpcFlag.Name),
+		NetworksDir:             networksDir,
 		SupervisorRPC:           ctx.String(SupervisorRpcFlag.Name),
 		Cannon: vm.Config{
 			VmType:            types.TraceTypeCannon,
@@ -580,6 +612,7 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			VmBin:             ctx.String(CannonBinFlag.Name),
 			Server:            ctx.String(CannonServerFlag.Name),
 			Networks:          networks,
+			NetworksDir:       networksDir,
 			L2Custom:          ctx.Bool(CannonL2CustomFlag.Name),
 			RollupConfigPaths: RollupConfigFlag.StringSlice(ctx, types.TraceTypeCannon),
 			L2GenesisPaths:    L2GenesisFlag.StringSlice(ctx, types.TraceTypeCannon),
@@ -601,6 +634,8 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			VmBin:             ctx.String(AsteriscBinFlag.Name),
 			Server:            ctx.String(AsteriscServerFlag.Name),
 			Networks:          networks,
+			NetworksDir:       networksDir,
+			L2Custom:          ctx.Bool(AsteriscL2CustomFlag.Name),
 			RollupConfigPaths: RollupConfigFlag.StringSlice(ctx, types.TraceTypeAsterisc),
 			L2GenesisPaths:    L2GenesisFlag.StringSlice(ctx, types.TraceTypeAsterisc),
 			DepsetConfigPath:  DepsetConfigFlag.String(ctx, types.TraceTypeAsterisc),
@@ -619,6 +654,7 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			VmBin:             ctx.String(AsteriscBinFlag.Name),
 			Server:            ctx.String(AsteriscKonaServerFlag.Name),
 			Networks:          networks,
+			NetworksDir:       networksDir,
 			RollupConfigPaths: RollupConfigFlag.StringSlice(ctx, types.TraceTypeAsteriscKona),
 			L2GenesisPaths:    L2GenesisFlag.StringSlice(ctx, types.TraceTypeAsteriscKona),
 			DepsetConfigPath:  DepsetConfigFlag.String(ctx, types.TraceTypeAsteriscKona),
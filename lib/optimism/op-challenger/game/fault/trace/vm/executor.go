@@ -55,7 +55,8 @@ type Config struct {
 	L2Experimental    string
 	Server            string // Path to the executable that provides the pre-image oracle server
 	Networks          []string
-	L2Custom          bool
+	NetworksDir       string // Directory of custom networks, to resolve the networks from
+	L2Custom          bool   // Whether the L2 chain config is loaded via the preimage oracle. Implied by custom networks.
 	RollupConfigPaths []string
 	L2GenesisPaths    []string
 	DepsetConfigPath  string
@@ -85,7 +86,7 @@ func (c *Config) Check() error {
 		}
 	} else {
 		for _, network := range c.Networks {
-			if ch := chaincfg.ChainByName(network); ch == nil {
+			if ch := chaincfg.ChainByName(network); ch == nil && chaincfg.CustomChainByName(network) == nil {
 				// Check if this looks like a chain ID that could be a custom chain configuration.
 				if _, err := strconv.ParseUint(network, 10, 32); err != nil {
 					return fmt.Errorf("%w: %v", ErrNetworkUnknown, network)
@@ -96,6 +97,17 @@ func (c *Config) Check() error {
 	return nil
 }
 
+// CustomNetwork returns whether any of the networks is a custom network, of which the
+// chain config is not in the superchain registry that op-program is built with.
+func (c *Config) CustomNetwork() bool {
+	for _, network := range c.Networks {
+		if chaincfg.CustomChainByName(network) != nil {
+			return true
+		}
+	}
+	return false
+}
+
 type OracleServerExecutor interface {
 	OracleCommand(cfg Config, dataDir string, inputs utils.LocalGameInputs) ([]string, error)
 }
@@ -2,6 +2,7 @@ package vm
 
 import (
 	"errors"
+	"path/filepath"
 	"strconv"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
@@ -53,8 +54,13 @@ func (s *KonaExecutor) OracleCommand(cfg Config, dataDir string, inputs utils.Lo
 			return nil, errors.New("network is not defined")
 		}
 
-		chainCfg := chaincfg.ChainByName(cfg.Networks[0])
-		args = append(args, "--l2-chain-id", strconv.FormatUint(chainCfg.ChainID, 10))
+		if custom := chaincfg.CustomChainByName(cfg.Networks[0]); custom != nil {
+			// kona does not know about custom networks, so pass the rollup config of the network instead
+			args = append(args, "--rollup-config-path", filepath.Join(cfg.NetworksDir, custom.Name, chaincfg.CustomRollupFileName))
+		} else {
+			chainCfg := chaincfg.ChainByName(cfg.Networks[0])
+			args = append(args, "--l2-chain-id", strconv.FormatUint(chainCfg.ChainID, 10))
+		}
 	}
 
 	return args, nil
@@ -45,6 +45,9 @@ func (s *OpProgramServerExecutor) OracleCommand(cfg Config, dataDir string, inpu
 	if len(cfg.Networks) != 0 {
 		args = append(args, "--network", strings.Join(cfg.Networks, ","))
 	}
+	if cfg.NetworksDir != "" {
+		args = append(args, "--networks.dir", cfg.NetworksDir)
+	}
 	if len(cfg.RollupConfigPaths) != 0 {
 		args = append(args, "--rollup.config", strings.Join(cfg.RollupConfigPaths, ","))
 	}
@@ -69,7 +72,8 @@ func (s *OpProgramServerExecutor) OracleCommand(cfg Config, dataDir string, inpu
 		logLevel = "CRIT"
 	}
 	args = append(args, "--log.level", logLevel)
-	if cfg.L2Custom {
+	// op-program can only load the config of custom networks via the preimage oracle
+	if cfg.L2Custom || cfg.CustomNetwork() {
 		args = append(args, "--l2.custom")
 	}
 	return args, nil
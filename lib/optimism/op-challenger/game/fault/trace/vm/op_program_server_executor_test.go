@@ -1,13 +1,17 @@
 package vm
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"math/big"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -66,6 +70,7 @@ func TestOpProgramFillHostCommand(t *testing.T) {
 	t.Run("NoExtras", func(t *testing.T) {
 		pairs := oracleCommand(t, log.LvlInfo, func(c *Config, _ *utils.LocalGameInputs) {})
 		require.NotContains(t, pairs, "--network")
+		require.NotContains(t, pairs, "--networks.dir")
 		require.NotContains(t, pairs, "--rollup.config")
 		require.NotContains(t, pairs, "--l2.genesis")
 	})
@@ -77,6 +82,15 @@ func TestOpProgramFillHostCommand(t *testing.T) {
 		require.Equal(t, "op-test", pairs["--network"])
 	})
 
+	t.Run("WithNetworksDir", func(t *testing.T) {
+		pairs := oracleCommand(t, log.LvlInfo, func(c *Config, _ *utils.LocalGameInputs) {
+			c.Networks = []string{"op-test"}
+			c.NetworksDir = "/networks"
+		})
+		require.Equal(t, "op-test", pairs["--network"])
+		require.Equal(t, "/networks", pairs["--networks.dir"])
+	})
+
 	t.Run("WithMultipleNetworks", func(t *testing.T) {
 		pairs := oracleCommand(t, log.LvlInfo, func(c *Config, _ *utils.LocalGameInputs) {
 			c.Networks = []string{"op-test", "op-other"}
@@ -91,6 +105,32 @@ func TestOpProgramFillHostCommand(t *testing.T) {
 		require.Equal(t, "true", pairs["--l2.custom"])
 	})
 
+	t.Run("WithCustomNetwork", func(t *testing.T) {
+		networksDir := t.TempDir()
+		rollupCfg := *chaincfg.OPSepolia()
+		rollupCfg.L2ChainID = big.NewInt(424242)
+		data, err := json.Marshal(&rollupCfg)
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(networksDir, "op-custom"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(networksDir, "op-custom", chaincfg.CustomRollupFileName), data, 0o644))
+		customChains, err := chaincfg.LoadCustomChains(networksDir)
+		require.NoError(t, err)
+		chaincfg.SetCustomChains(customChains)
+		t.Cleanup(func() { chaincfg.SetCustomChains(nil) })
+
+		pairs := oracleCommand(t, log.LvlInfo, func(c *Config, _ *utils.LocalGameInputs) {
+			c.Networks = []string{"op-custom"}
+			c.NetworksDir = networksDir
+		})
+		require.Equal(t, "true", pairs["--l2.custom"], "custom networks are loaded via the preimage oracle")
+
+		pairs = oracleCommand(t, log.LvlInfo, func(c *Config, _ *utils.LocalGameInputs) {
+			c.Networks = []string{"op-sepolia"}
+			c.NetworksDir = networksDir
+		})
+		require.NotContains(t, pairs, "--l2.custom")
+	})
+
 	t.Run("WithRollupConfigPath", func(t *testing.T) {
 		pairs := oracleCommand(t, log.LvlInfo, func(c *Config, _ *utils.LocalGameInputs) {
 			c.RollupConfigPaths = []string{"rollup.config.json"}
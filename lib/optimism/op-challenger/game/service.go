@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/fetcher"
 	"github.com/ethereum-optimism/optimism/op-challenger/sender"
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
@@ -63,6 +64,8 @@ type Service struct {
 
 	balanceMetricer io.Closer
 
+	customChains *chaincfg.CustomChainsWatcher
+
 	stopped atomic.Bool
 }
 
@@ -88,6 +91,9 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 		return fmt.Errorf("failed to init tx manager: %w", err)
 	}
 	s.initClaimants(cfg)
+	if err := s.initCustomChains(cfg); err != nil {
+		return fmt.Errorf("failed to init custom networks: %w", err)
+	}
 	if err := s.initL1Client(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init l1 client: %w", err)
 	}
@@ -123,6 +129,18 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	return nil
 }
 
+func (s *Service) initCustomChains(cfg *config.Config) error {
+	if cfg.NetworksDir == "" {
+		return nil
+	}
+	watcher, err := chaincfg.WatchCustomChains(s.logger, cfg.NetworksDir, nil)
+	if err != nil {
+		return err
+	}
+	s.customChains = watcher
+	return nil
+}
+
 func (s *Service) initClaimants(cfg *config.Config) {
 	claimants := []common.Address{s.txSender.From()}
 	s.claimants = append(claimants, cfg.AdditionalBondClaimants...)
@@ -282,6 +300,11 @@ func (s *Service) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to close balance metricer: %w", err))
 		}
 	}
+	if s.customChains != nil {
+		if err := s.customChains.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to stop watching custom networks: %w", err))
+		}
+	}
 
 	if s.txMgr != nil {
 		s.txMgr.Close()
@@ -221,7 +221,7 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher,
 		{
 			Namespace:     "admin",
 			Version:       "",
-			Service:       node.NewAdminAPI(backend, log),
+			Service:       node.NewAdminAPI(backend, nil, log),
 			Public:        true, // TODO: this field is deprecated. Do we even need this anymore?
 			Authenticated: false,
 		},
@@ -267,6 +267,18 @@ func (s *l2VerifierBackend) StopSequencer(ctx context.Context) (common.Hash, err
 	return common.Hash{}, errors.New("stopping the L2Verifier sequencer is not supported")
 }
 
+func (s *l2VerifierBackend) StopSequencerAt(ctx context.Context, target eth.SequencerStopTarget) (common.Hash, error) {
+	return common.Hash{}, errors.New("stopping the L2Verifier sequencer is not supported")
+}
+
+func (s *l2VerifierBackend) StartSequencerAt(ctx context.Context, blockHash common.Hash) error {
+	return nil
+}
+
+func (s *l2VerifierBackend) CancelSequencerHandoff(ctx context.Context) error {
+	return nil
+}
+
 func (s *l2VerifierBackend) SequencerActive(ctx context.Context) (bool, error) {
 	return false, nil
 }
@@ -287,6 +299,10 @@ func (s *l2VerifierBackend) SetRecoverMode(ctx context.Context, mode bool) error
 	return errors.New("recover mode unsupported")
 }
 
+func (s *l2VerifierBackend) SubscribeHeads(topic status.HeadTopic) (*status.HeadSubscription, error) {
+	return s.verifier.syncStatus.SubscribeHeads(topic)
+}
+
 func (s *L2Verifier) DerivationMetricsTracer() *testutils.TestDerivationMetrics {
 	return s.derivationMetrics
 }
@@ -0,0 +1,54 @@
+package verifier
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	op_e2e "github.com/ethereum-optimism/optimism/op-e2e"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-e2e/system/e2esys"
+	"github.com/ethereum-optimism/optimism/op-node/node"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplayVerifierRecording records the events and external calls of a verifier that derives
+// the safe chain, and replays the recording with the op-node derivers.
+func TestReplayVerifierRecording(t *testing.T) {
+	op_e2e.InitParallel(t)
+
+	cfg := e2esys.DefaultSystemConfig(t)
+	recordPath := filepath.Join(t.TempDir(), "verifier-events.jsonl")
+	cfg.Nodes["verifier"].EventRecordPath = recordPath
+
+	sys, err := cfg.Start(t)
+	require.NoError(t, err, "Error starting up system")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	require.NoError(t, wait.ForSafeBlock(ctx, sys.RollupClient("verifier"), 5))
+	// stopping the verifier completes the recording
+	require.NoError(t, sys.RollupNodes["verifier"].Stop(ctx))
+
+	f, err := os.Open(recordPath)
+	require.NoError(t, err)
+	defer f.Close()
+	rec, err := event.ReadRecording(f)
+	require.NoError(t, err)
+	require.NotEmpty(t, rec.Emits())
+	require.NotEmpty(t, rec.Calls(node.RecordSourceL1))
+	require.NotEmpty(t, rec.Calls(node.RecordSourceEngine))
+
+	verifierCfg := *cfg.Nodes["verifier"]
+	verifierCfg.Rollup = *sys.RollupConfig
+	replayCfg, err := node.NewReplayConfig(&verifierCfg)
+	require.NoError(t, err)
+	logger := testlog.Logger(t, log.LevelInfo).New("role", "replay")
+	require.NoError(t, node.Replay(ctx, logger, replayCfg, rec))
+}
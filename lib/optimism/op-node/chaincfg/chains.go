@@ -2,6 +2,7 @@ package chaincfg
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -35,13 +36,21 @@ var L2ChainIDToNetworkDisplayName = func() map[string]string {
 	return out
 }()
 
-// AvailableNetworks returns the selection of network configurations that is available by default.
+// AvailableNetworks returns the selection of network configurations that is available by default,
+// followed by the custom chains, if any are set.
 func AvailableNetworks() []string {
 	var networks []string
 	for _, cfg := range superchain.Chains {
 		networks = append(networks, cfg.Name+"-"+cfg.Network)
 	}
 	sort.Strings(networks)
+	if chains := customChains.Load(); chains != nil {
+		for _, name := range chains.Names() {
+			if !slices.Contains(networks, name) {
+				networks = append(networks, name)
+			}
+		}
+	}
 	return networks
 }
 
@@ -75,7 +84,12 @@ func ChainByName(name string) *superchain.ChainConfig {
 	return nil
 }
 
+// GetRollupConfig returns the rollup config of a custom chain or of a superchain-registry chain, by name.
 func GetRollupConfig(name string) (*rollup.Config, error) {
+	if custom := CustomChainByName(name); custom != nil {
+		rollupCfg := *custom.Rollup
+		return &rollupCfg, nil
+	}
 	chainCfg := ChainByName(name)
 	if chainCfg == nil {
 		return nil, fmt.Errorf("invalid network: %q", name)
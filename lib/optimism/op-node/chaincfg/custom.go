@@ -0,0 +1,192 @@
+package chaincfg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	CustomRollupFileName      = "rollup.json"
+	CustomGenesisFileName     = "genesis.json"
+	CustomChainConfigFileName = "chain-config.json"
+)
+
+// CustomChain is a chain that is not in the superchain registry, loaded from a directory of custom chains.
+type CustomChain struct {
+	// Name is the name of the chain directory.
+	Name   string
+	Rollup *rollup.Config
+	// ChainConfig is the L2 chain config, or nil if the chain directory has neither a genesis nor a chain config.
+	ChainConfig *params.ChainConfig
+}
+
+func (c *CustomChain) ChainID() eth.ChainID {
+	return eth.ChainIDFromBig(c.Rollup.L2ChainID)
+}
+
+// CustomChains is a set of custom chains, keyed by name and by chain ID.
+type CustomChains struct {
+	dir     string
+	byName  map[string]*CustomChain
+	byChain map[eth.ChainID]*CustomChain
+}
+
+// LoadCustomChains loads the custom chains of a directory, with a subdirectory per chain.
+// Each chain directory has a rollup.json, and optionally a genesis.json or a chain-config.json with the L2 chain config.
+// The chain can be selected by the name of its directory, or by its L2 chain ID.
+// Directories without a rollup.json are ignored. All rollup configs must be valid, and all chain IDs unique.
+func LoadCustomChains(dir string) (*CustomChains, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom chains directory: %w", err)
+	}
+	chains := &CustomChains{
+		dir:     dir,
+		byName:  make(map[string]*CustomChain),
+		byChain: make(map[eth.ChainID]*CustomChain),
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		chain, err := loadCustomChain(filepath.Join(dir, entry.Name()), entry.Name())
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid custom chain %q: %w", entry.Name(), err)
+		}
+		name := strings.ToLower(chain.Name)
+		if _, ok := chains.byName[name]; ok {
+			return nil, fmt.Errorf("duplicate custom chain name %q", chain.Name)
+		}
+		if other, ok := chains.byChain[chain.ChainID()]; ok {
+			return nil, fmt.Errorf("custom chains %q and %q have the same chain ID %v", other.Name, chain.Name, chain.ChainID())
+		}
+		chains.byName[name] = chain
+		chains.byChain[chain.ChainID()] = chain
+	}
+	return chains, nil
+}
+
+func loadCustomChain(dir string, name string) (*CustomChain, error) {
+	f, err := os.Open(filepath.Join(dir, CustomRollupFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var rollupCfg rollup.Config
+	if err := rollupCfg.ParseRollupConfig(f); err != nil {
+		return nil, err
+	}
+	if err := rollupCfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid rollup config: %w", err)
+	}
+	chain := &CustomChain{Name: name, Rollup: &rollupCfg}
+	if id, err := eth.ParseDecimalChainID(name); err == nil && id != chain.ChainID() {
+		return nil, fmt.Errorf("chain directory is named after chain ID %v, but rollup config has chain ID %v", id, chain.ChainID())
+	}
+	chain.ChainConfig, err = loadCustomChainConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	if chain.ChainConfig != nil && eth.ChainIDFromBig(chain.ChainConfig.ChainID) != chain.ChainID() {
+		return nil, fmt.Errorf("chain config has chain ID %v, but rollup config has chain ID %v", chain.ChainConfig.ChainID, chain.ChainID())
+	}
+	return chain, nil
+}
+
+func loadCustomChainConfig(dir string) (*params.ChainConfig, error) {
+	data, err := os.ReadFile(filepath.Join(dir, CustomGenesisFileName))
+	if err == nil {
+		var genesis core.Genesis
+		if err := json.Unmarshal(data, &genesis); err != nil {
+			return nil, fmt.Errorf("failed to decode genesis: %w", err)
+		}
+		if genesis.Config == nil {
+			return nil, errors.New("genesis has no chain config")
+		}
+		return genesis.Config, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read genesis: %w", err)
+	}
+	data, err = os.ReadFile(filepath.Join(dir, CustomChainConfigFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read chain config: %w", err)
+	}
+	var chainCfg params.ChainConfig
+	if err := json.Unmarshal(data, &chainCfg); err != nil {
+		return nil, fmt.Errorf("failed to decode chain config: %w", err)
+	}
+	return &chainCfg, nil
+}
+
+// Dir returns the directory that the custom chains were loaded from.
+func (c *CustomChains) Dir() string {
+	return c.dir
+}
+
+// ByName returns the custom chain with the given name, or with the given chain ID in decimal, or nil if unknown.
+func (c *CustomChains) ByName(name string) *CustomChain {
+	if chain, ok := c.byName[strings.ToLower(name)]; ok {
+		return chain
+	}
+	if id, err := eth.ParseDecimalChainID(name); err == nil {
+		return c.byChain[id]
+	}
+	return nil
+}
+
+// ByChainID returns the custom chain with the given chain ID, or nil if unknown.
+func (c *CustomChains) ByChainID(id eth.ChainID) *CustomChain {
+	return c.byChain[id]
+}
+
+// Names returns the sorted names of the custom chains.
+func (c *CustomChains) Names() []string {
+	out := make([]string, 0, len(c.byName))
+	for _, chain := range c.byName {
+		out = append(out, chain.Name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+var customChains atomic.Pointer[CustomChains]
+
+// SetCustomChains sets the custom chains, to select networks from in addition to the superchain registry.
+// Custom chains take precedence over superchain-registry chains of the same name.
+func SetCustomChains(chains *CustomChains) {
+	customChains.Store(chains)
+}
+
+// CustomChainByName returns the custom chain with the given name or chain ID, or nil if unknown or if no custom chains are set.
+func CustomChainByName(name string) *CustomChain {
+	chains := customChains.Load()
+	if chains == nil {
+		return nil
+	}
+	return chains.ByName(name)
+}
+
+// CustomChainByID returns the custom chain with the given chain ID, or nil if unknown or if no custom chains are set.
+func CustomChainByID(id eth.ChainID) *CustomChain {
+	chains := customChains.Load()
+	if chains == nil {
+		return nil
+	}
+	return chains.ByChainID(id)
+}
@@ -0,0 +1,194 @@
+package chaincfg
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func writeJSON(t *testing.T, path string, v any) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+}
+
+func customRollupConfig(chainID uint64) *rollup.Config {
+	cfg := *OPSepolia()
+	cfg.L2ChainID = new(big.Int).SetUint64(chainID)
+	return &cfg
+}
+
+func writeCustomChain(t *testing.T, dir string, name string, chainID uint64) {
+	writeJSON(t, filepath.Join(dir, name, CustomRollupFileName), customRollupConfig(chainID))
+}
+
+func TestLoadCustomChains(t *testing.T) {
+	t.Run("ByNameAndChainID", func(t *testing.T) {
+		dir := t.TempDir()
+		writeCustomChain(t, dir, "devnet-a", 900)
+		writeCustomChain(t, dir, "901", 901)
+		writeJSON(t, filepath.Join(dir, "devnet-a", CustomGenesisFileName), &core.Genesis{
+			Config:     &params.ChainConfig{ChainID: big.NewInt(900)},
+			Difficulty: big.NewInt(0),
+			Alloc:      types.GenesisAlloc{},
+		})
+		writeJSON(t, filepath.Join(dir, "901", CustomChainConfigFileName), &params.ChainConfig{ChainID: big.NewInt(901)})
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "not-a-chain"), 0o755))
+
+		chains, err := LoadCustomChains(dir)
+		require.NoError(t, err)
+		require.Equal(t, []string{"901", "devnet-a"}, chains.Names())
+
+		a := chains.ByName("Devnet-A")
+		require.NotNil(t, a)
+		require.Equal(t, eth.ChainIDFromUInt64(900), a.ChainID())
+		require.Equal(t, big.NewInt(900), a.ChainConfig.ChainID)
+		require.Same(t, a, chains.ByName("900"))
+		require.Same(t, a, chains.ByChainID(eth.ChainIDFromUInt64(900)))
+
+		b := chains.ByName("901")
+		require.NotNil(t, b)
+		require.Equal(t, big.NewInt(901), b.ChainConfig.ChainID)
+
+		require.Nil(t, chains.ByName("not-a-chain"))
+		require.Nil(t, chains.ByName("902"))
+	})
+
+	t.Run("NoChainConfig", func(t *testing.T) {
+		dir := t.TempDir()
+		writeCustomChain(t, dir, "devnet", 900)
+		chains, err := LoadCustomChains(dir)
+		require.NoError(t, err)
+		require.Nil(t, chains.ByName("devnet").ChainConfig)
+	})
+
+	t.Run("DuplicateChainID", func(t *testing.T) {
+		dir := t.TempDir()
+		writeCustomChain(t, dir, "devnet-a", 900)
+		writeCustomChain(t, dir, "devnet-b", 900)
+		_, err := LoadCustomChains(dir)
+		require.ErrorContains(t, err, "same chain ID")
+	})
+
+	t.Run("InvalidRollupConfig", func(t *testing.T) {
+		dir := t.TempDir()
+		cfg := customRollupConfig(900)
+		cfg.BlockTime = 0
+		writeJSON(t, filepath.Join(dir, "devnet", CustomRollupFileName), cfg)
+		_, err := LoadCustomChains(dir)
+		require.ErrorIs(t, err, rollup.ErrBlockTimeZero)
+	})
+
+	t.Run("DirectoryChainIDMismatch", func(t *testing.T) {
+		dir := t.TempDir()
+		writeCustomChain(t, dir, "901", 900)
+		_, err := LoadCustomChains(dir)
+		require.ErrorContains(t, err, "named after chain ID")
+	})
+
+	t.Run("ChainConfigChainIDMismatch", func(t *testing.T) {
+		dir := t.TempDir()
+		writeCustomChain(t, dir, "devnet", 900)
+		writeJSON(t, filepath.Join(dir, "devnet", CustomChainConfigFileName), &params.ChainConfig{ChainID: big.NewInt(901)})
+		_, err := LoadCustomChains(dir)
+		require.ErrorContains(t, err, "chain config has chain ID")
+	})
+
+	t.Run("MissingDirectory", func(t *testing.T) {
+		_, err := LoadCustomChains(filepath.Join(t.TempDir(), "missing"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestGetRollupConfigCustom(t *testing.T) {
+	dir := t.TempDir()
+	writeCustomChain(t, dir, "op-sepolia", 900)
+	writeCustomChain(t, dir, "devnet", 901)
+	chains, err := LoadCustomChains(dir)
+	require.NoError(t, err)
+	SetCustomChains(chains)
+	t.Cleanup(func() { SetCustomChains(nil) })
+
+	cfg, err := GetRollupConfig("op-sepolia")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(900), cfg.L2ChainID, "custom chains take precedence")
+	cfg.BlockTime = 100
+	require.Equal(t, uint64(2), CustomChainByName("op-sepolia").Rollup.BlockTime, "must return a copy")
+
+	cfg, err = GetRollupConfig("op-mainnet")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(10), cfg.L2ChainID)
+
+	networks := AvailableNetworks()
+	require.Contains(t, networks, "op-mainnet")
+	require.Contains(t, networks, "devnet")
+	require.Equal(t, 1, countOf(networks, "op-sepolia"))
+}
+
+func TestWatchCustomChains(t *testing.T) {
+	dir := t.TempDir()
+	writeCustomChain(t, dir, "devnet-a", 900)
+	t.Cleanup(func() { SetCustomChains(nil) })
+
+	reloaded := make(chan *CustomChains, 10)
+	watcher, err := WatchCustomChains(testlog.Logger(t, log.LevelDebug), dir, func(chains *CustomChains) {
+		reloaded <- chains
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, watcher.Close()) })
+	require.NotNil(t, CustomChainByName("devnet-a"))
+
+	awaitReload := func() *CustomChains {
+		select {
+		case chains := <-reloaded:
+			return chains
+		case <-time.After(10 * time.Second):
+			t.Fatal("custom chains were not reloaded")
+			return nil
+		}
+	}
+
+	// A new chain directory is picked up
+	writeCustomChain(t, dir, "devnet-b", 901)
+	require.NotNil(t, awaitReload().ByName("devnet-b"))
+	require.NotNil(t, CustomChainByName("devnet-b"))
+
+	// A change of a rollup config in a chain directory is picked up
+	cfg := customRollupConfig(901)
+	cfg.BlockTime = 1
+	writeJSON(t, filepath.Join(dir, "devnet-b", CustomRollupFileName), cfg)
+	require.Equal(t, uint64(1), awaitReload().ByName("devnet-b").Rollup.BlockTime)
+
+	// An invalid change is ignored, the previous chains are kept
+	cfg.BlockTime = 0
+	writeJSON(t, filepath.Join(dir, "devnet-b", CustomRollupFileName), cfg)
+	select {
+	case <-reloaded:
+		t.Fatal("invalid custom chains must not be reloaded")
+	case <-time.After(2*reloadDelay + time.Second):
+	}
+	require.Equal(t, uint64(1), CustomChainByName("devnet-b").Rollup.BlockTime)
+}
+
+func countOf(items []string, item string) (n int) {
+	for _, v := range items {
+		if v == item {
+			n++
+		}
+	}
+	return n
+}
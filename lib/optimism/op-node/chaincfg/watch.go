@@ -0,0 +1,138 @@
+package chaincfg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDelay is the time to wait after a file change before reloading,
+// so a chain directory that is written file by file is reloaded once.
+const reloadDelay = 2 * time.Second
+
+// CustomChainsWatcher keeps the custom chains up to date with a custom chains directory.
+type CustomChainsWatcher struct {
+	log      log.Logger
+	dir      string
+	onReload func(chains *CustomChains)
+
+	watcher *fsnotify.Watcher
+	// watched are the directories that are watched, the custom chains directory and the chain directories
+	watched map[string]struct{}
+
+	closing chan struct{}
+	wg      sync.WaitGroup
+}
+
+// WatchCustomChains loads and sets the custom chains of a directory, see LoadCustomChains,
+// and reloads them on any change of the directory. If a reload fails, the previous custom chains are kept.
+// The optional onReload function is called after every successful reload.
+func WatchCustomChains(logger log.Logger, dir string, onReload func(chains *CustomChains)) (*CustomChainsWatcher, error) {
+	chains, err := LoadCustomChains(dir)
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create custom chains watcher: %w", err)
+	}
+	w := &CustomChainsWatcher{
+		log:      logger,
+		dir:      dir,
+		onReload: onReload,
+		watcher:  watcher,
+		watched:  make(map[string]struct{}),
+		closing:  make(chan struct{}),
+	}
+	if err := w.watchDirs(); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+	SetCustomChains(chains)
+	logger.Info("Loaded custom chains", "dir", dir, "chains", chains.Names())
+	w.wg.Add(1)
+	go w.run()
+	return w, nil
+}
+
+// watchDirs watches the custom chains directory and all of its chain directories, including new ones.
+func (w *CustomChainsWatcher) watchDirs() error {
+	dirs := []string{w.dir}
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read custom chains directory: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, filepath.Join(w.dir, entry.Name()))
+		}
+	}
+	for _, dir := range dirs {
+		if _, ok := w.watched[dir]; ok {
+			continue
+		}
+		if err := w.watcher.Add(dir); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+		w.watched[dir] = struct{}{}
+	}
+	return nil
+}
+
+func (w *CustomChainsWatcher) run() {
+	defer w.wg.Done()
+	reload := time.NewTimer(reloadDelay)
+	reload.Stop()
+	defer reload.Stop()
+	for {
+		select {
+		case <-w.closing:
+			return
+		case ev, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if ev.Has(fsnotify.Remove) || ev.Has(fsnotify.Rename) {
+				// fsnotify stops watching removed directories by itself
+				delete(w.watched, ev.Name)
+			}
+			w.log.Debug("Custom chains changed, queued reload", "file", ev.Name, "op", ev.Op)
+			reload.Reset(reloadDelay)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.log.Error("Error watching custom chains", "err", err)
+		case <-reload.C:
+			w.reload()
+		}
+	}
+}
+
+func (w *CustomChainsWatcher) reload() {
+	if err := w.watchDirs(); err != nil {
+		w.log.Error("Failed to watch custom chains", "err", err)
+	}
+	chains, err := LoadCustomChains(w.dir)
+	if err != nil {
+		w.log.Error("Failed to reload custom chains, keeping the previous custom chains", "dir", w.dir, "err", err)
+		return
+	}
+	SetCustomChains(chains)
+	w.log.Info("Reloaded custom chains", "dir", w.dir, "chains", chains.Names())
+	if w.onReload != nil {
+		w.onReload(chains)
+	}
+}
+
+// Close stops watching the custom chains directory. The custom chains that were loaded last remain set.
+func (w *CustomChainsWatcher) Close() error {
+	close(w.closing)
+	w.wg.Wait()
+	return w.watcher.Close()
+}
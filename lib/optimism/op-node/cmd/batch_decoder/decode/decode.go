@@ -0,0 +1,292 @@
+package decode
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/reassemble"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+const (
+	FormatJSONLines = "jsonl"
+	FormatCSV       = "csv"
+)
+
+type Config struct {
+	BatchInbox  common.Address
+	InDirectory string
+	// BlobsDirectory optionally contains blob sidecars of blob transactions that were fetched
+	// without an L1 Beacon endpoint, as returned by the beacon API's blob_sidecars endpoint.
+	BlobsDirectory string
+	OutFile        string
+	Format         string
+}
+
+// Block is a single L2 block as decoded from a singular batch or expanded from a span batch.
+type Block struct {
+	ChannelID      derive.ChannelID `json:"channel_id"`
+	InclusionBlock uint64           `json:"inclusion_block"`
+	BatchType      int              `json:"batch_type"`
+	Number         uint64           `json:"number"`
+	Timestamp      uint64           `json:"timestamp"`
+	EpochNum       uint64           `json:"epoch_num"`
+	// EpochHash is only known for singular batches. Span batches only commit to
+	// a prefix of the last block's epoch hash.
+	EpochHash    *common.Hash `json:"epoch_hash,omitempty"`
+	Transactions []Tx         `json:"transactions"`
+}
+
+// Tx is a decoded L2 transaction of a batch.
+type Tx struct {
+	Hash common.Hash     `json:"hash"`
+	Type uint8           `json:"type"`
+	From common.Address  `json:"from"`
+	To   *common.Address `json:"to"`
+	Size int             `json:"size"`
+}
+
+// Batches loads all transactions from the given input directory that are submitted to the
+// specified batch inbox, re-assembles all channels, and writes all L2 blocks of the channels'
+// batches to the out file, one JSON object per line or one CSV row per transaction.
+func Batches(config Config, rollupCfg *rollup.Config) {
+	var blobs map[common.Hash]*eth.Blob
+	if config.BlobsDirectory != "" {
+		var err error
+		if blobs, err = LoadBlobSidecars(config.BlobsDirectory); err != nil {
+			log.Fatal(err)
+		}
+	}
+	frames := reassemble.LoadFramesWithBlobs(config.InDirectory, config.BatchInbox, blobs)
+	// keep the channels in the order their first frame was included on L1
+	var ids []derive.ChannelID
+	framesByChannel := make(map[derive.ChannelID][]reassemble.FrameWithMetadata)
+	for _, frame := range frames {
+		if _, ok := framesByChannel[frame.Frame.ID]; !ok {
+			ids = append(ids, frame.Frame.ID)
+		}
+		framesByChannel[frame.Frame.ID] = append(framesByChannel[frame.Frame.ID], frame)
+	}
+	rcfg := reassemble.Config{
+		BatchInbox:    config.BatchInbox,
+		L2ChainID:     rollupCfg.L2ChainID,
+		L2GenesisTime: rollupCfg.Genesis.L2Time,
+		L2BlockTime:   rollupCfg.BlockTime,
+	}
+	var blocks []Block
+	for _, id := range ids {
+		ch := reassemble.ProcessFrames(rcfg, rollupCfg, id, framesByChannel[id])
+		chBlocks, err := ChannelBlocks(rollupCfg, ch)
+		if err != nil {
+			fmt.Printf("Error decoding channel %v. Err: %v\n", id, err)
+		}
+		blocks = append(blocks, chBlocks...)
+	}
+
+	if err := os.MkdirAll(path.Dir(config.OutFile), 0750); err != nil {
+		log.Fatal(err)
+	}
+	file, err := os.Create(config.OutFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	switch config.Format {
+	case FormatJSONLines:
+		err = WriteJSONLines(file, blocks)
+	case FormatCSV:
+		err = WriteCSV(file, blocks)
+	default:
+		err = fmt.Errorf("unknown format: %q", config.Format)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Wrote %d L2 blocks to %v\n", len(blocks), config.OutFile)
+}
+
+// ChannelBlocks returns the L2 blocks of all batches of the given channel, expanding span batches
+// into their individual blocks. Batches that failed to decode are skipped. If a transaction fails
+// to decode, the blocks decoded so far are returned together with the error.
+func ChannelBlocks(rollupCfg *rollup.Config, ch reassemble.ChannelWithMetadata) ([]Block, error) {
+	var inclusionBlock uint64
+	if len(ch.Frames) > 0 {
+		inclusionBlock = ch.Frames[len(ch.Frames)-1].InclusionBlock
+	}
+	signer := types.LatestSignerForChainID(rollupCfg.L2ChainID)
+	var blocks []Block
+	addBlock := func(batchType int, timestamp uint64, epochNum uint64, epochHash *common.Hash, txs []hexutil.Bytes) error {
+		num, err := rollupCfg.TargetBlockNumber(timestamp)
+		if err != nil {
+			return fmt.Errorf("block number of timestamp %d: %w", timestamp, err)
+		}
+		decoded, err := decodeTxs(signer, txs)
+		if err != nil {
+			return fmt.Errorf("block %d: %w", num, err)
+		}
+		blocks = append(blocks, Block{
+			ChannelID:      ch.ID,
+			InclusionBlock: inclusionBlock,
+			BatchType:      batchType,
+			Number:         num,
+			Timestamp:      timestamp,
+			EpochNum:       epochNum,
+			EpochHash:      epochHash,
+			Transactions:   decoded,
+		})
+		return nil
+	}
+	for _, batch := range ch.Batches {
+		switch b := batch.(type) {
+		case *derive.SingularBatch:
+			if b == nil {
+				continue
+			}
+			epochHash := b.EpochHash
+			if err := addBlock(derive.SingularBatchType, b.Timestamp, uint64(b.EpochNum), &epochHash, b.Transactions); err != nil {
+				return blocks, err
+			}
+		case *derive.SpanBatch:
+			if b == nil {
+				continue
+			}
+			for _, el := range b.Batches {
+				if err := addBlock(derive.SpanBatchType, el.Timestamp, uint64(el.EpochNum), nil, el.Transactions); err != nil {
+					return blocks, err
+				}
+			}
+		}
+	}
+	return blocks, nil
+}
+
+func decodeTxs(signer types.Signer, txs []hexutil.Bytes) ([]Tx, error) {
+	out := make([]Tx, 0, len(txs))
+	for i, data := range txs {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("decoding tx %d: %w", i, err)
+		}
+		from, err := types.Sender(signer, &tx)
+		if err != nil {
+			return nil, fmt.Errorf("recovering sender of tx %d: %w", i, err)
+		}
+		out = append(out, Tx{
+			Hash: tx.Hash(),
+			Type: tx.Type(),
+			From: from,
+			To:   tx.To(),
+			Size: len(data),
+		})
+	}
+	return out, nil
+}
+
+// WriteJSONLines writes one JSON object per block.
+func WriteJSONLines(w io.Writer, blocks []Block) error {
+	enc := json.NewEncoder(w)
+	for _, b := range blocks {
+		if err := enc.Encode(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var csvHeader = []string{
+	"channel_id", "inclusion_block", "batch_type", "number", "timestamp", "epoch_num", "epoch_hash",
+	"tx_index", "tx_hash", "tx_type", "tx_from", "tx_to", "tx_size",
+}
+
+// WriteCSV writes one row per transaction. Blocks without transactions are written as a single
+// row with empty transaction columns.
+func WriteCSV(w io.Writer, blocks []Block) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, b := range blocks {
+		epochHash := ""
+		if b.EpochHash != nil {
+			epochHash = b.EpochHash.String()
+		}
+		row := []string{
+			b.ChannelID.String(),
+			strconv.FormatUint(b.InclusionBlock, 10),
+			strconv.Itoa(b.BatchType),
+			strconv.FormatUint(b.Number, 10),
+			strconv.FormatUint(b.Timestamp, 10),
+			strconv.FormatUint(b.EpochNum, 10),
+			epochHash,
+		}
+		if len(b.Transactions) == 0 {
+			if err := cw.Write(append(row, "", "", "", "", "", "")); err != nil {
+				return err
+			}
+		}
+		for i, tx := range b.Transactions {
+			to := ""
+			if tx.To != nil {
+				to = tx.To.String()
+			}
+			txRow := append(append([]string{}, row...),
+				strconv.Itoa(i),
+				tx.Hash.String(),
+				strconv.Itoa(int(tx.Type)),
+				tx.From.String(),
+				to,
+				strconv.Itoa(tx.Size),
+			)
+			if err := cw.Write(txRow); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// LoadBlobSidecars loads all blob sidecars from the JSON files in the given directory, keyed by
+// versioned hash. Every file must contain a response of the beacon API's blob_sidecars endpoint.
+// The KZG proofs of the blobs are verified against their commitments.
+func LoadBlobSidecars(dir string) (map[common.Hash]*eth.Blob, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	blobs := make(map[common.Hash]*eth.Blob)
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		f := path.Join(dir, file.Name())
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var resp eth.APIGetBlobSidecarsResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("decoding blob sidecars file %v: %w", f, err)
+		}
+		for _, sc := range resp.Data {
+			commitment := kzg4844.Commitment(sc.KZGCommitment)
+			if err := eth.VerifyBlobProof(&sc.Blob, commitment, kzg4844.Proof(sc.KZGProof)); err != nil {
+				return nil, fmt.Errorf("invalid blob sidecar %d in file %v: %w", sc.Index, f, err)
+			}
+			blobs[eth.KZGToVersionedHash(commitment)] = &sc.Blob
+		}
+	}
+	return blobs, nil
+}
@@ -0,0 +1,80 @@
+package decode
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/reassemble"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelBlocks(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chainID := big.NewInt(901)
+	rollupCfg := &rollup.Config{
+		Genesis:   rollup.Genesis{L2Time: 1000},
+		BlockTime: 2,
+		L2ChainID: chainID,
+	}
+
+	singular := derive.RandomSingularBatch(rng, 2, chainID)
+	singular.Timestamp = 1010
+	span := derive.NewSpanBatch(rollupCfg.Genesis.L2Time, chainID)
+	var spanBatches []*derive.SingularBatch
+	for i := 0; i < 2; i++ {
+		b := derive.RandomSingularBatch(rng, i, chainID)
+		b.Timestamp = 1012 + uint64(i)*2
+		b.EpochNum = singular.EpochNum
+		require.NoError(t, span.AppendSingularBatch(b, uint64(i)))
+		spanBatches = append(spanBatches, b)
+	}
+
+	ch := reassemble.ChannelWithMetadata{
+		ID:      derive.ChannelID{0x01},
+		Frames:  []reassemble.FrameWithMetadata{{InclusionBlock: 7}, {InclusionBlock: 8}},
+		Batches: []derive.Batch{singular, (*derive.SingularBatch)(nil), span},
+	}
+	blocks, err := ChannelBlocks(rollupCfg, ch)
+	require.NoError(t, err)
+	require.Len(t, blocks, 3)
+
+	require.Equal(t, derive.SingularBatchType, blocks[0].BatchType)
+	require.Equal(t, uint64(5), blocks[0].Number)
+	require.Equal(t, uint64(8), blocks[0].InclusionBlock)
+	require.Equal(t, singular.EpochHash, *blocks[0].EpochHash)
+	require.Len(t, blocks[0].Transactions, 2)
+	signer := types.LatestSignerForChainID(chainID)
+	for i, data := range singular.Transactions {
+		var tx types.Transaction
+		require.NoError(t, tx.UnmarshalBinary(data))
+		from, err := types.Sender(signer, &tx)
+		require.NoError(t, err)
+		require.Equal(t, Tx{Hash: tx.Hash(), Type: tx.Type(), From: from, To: tx.To(), Size: len(data)}, blocks[0].Transactions[i])
+	}
+
+	for i, b := range spanBatches {
+		block := blocks[1+i]
+		require.Equal(t, derive.SpanBatchType, block.BatchType)
+		require.Equal(t, uint64(6+i), block.Number)
+		require.Equal(t, b.Timestamp, block.Timestamp)
+		require.Equal(t, uint64(b.EpochNum), block.EpochNum)
+		require.Nil(t, block.EpochHash)
+		require.Len(t, block.Transactions, i)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, blocks))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	// header, two txs of the singular batch, one empty and one single-tx span batch block
+	require.Len(t, records, 1+2+1+1)
+	require.Equal(t, csvHeader, records[0])
+	require.Equal(t, "", records[3][len(csvHeader)-1])
+	require.Equal(t, "0", records[4][7])
+}
@@ -34,6 +34,10 @@ type TransactionWithMetadata struct {
 	FrameErrs   []string           `json:"frame_parse_error"`
 	ValidFrames []bool             `json:"valid_data"`
 	Tx          *types.Transaction `json:"tx"`
+
+	// MissingBlobs is set for blob transactions fetched without an L1 Beacon endpoint.
+	// Their frames can be parsed later from blob sidecars saved on disk.
+	MissingBlobs bool `json:"missing_blobs,omitempty"`
 }
 
 type Config struct {
@@ -104,15 +108,15 @@ func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, beacon
 				validSender = false
 			}
 			var datas []hexutil.Bytes
+			missingBlobs := false
 			if tx.Type() != types.BlobTxType {
 				datas = append(datas, tx.Data())
 				// no need to increment blobIndex because no blobs
+			} else if beacon == nil {
+				fmt.Printf("Unable to fetch blobs of transaction (%s) because L1 Beacon API not provided\n", tx.Hash().String())
+				blobIndex += len(tx.BlobHashes())
+				missingBlobs = true
 			} else {
-				if beacon == nil {
-					fmt.Printf("Unable to handle blob transaction (%s) because L1 Beacon API not provided\n", tx.Hash().String())
-					blobIndex += len(tx.BlobHashes())
-					continue
-				}
 				var hashes []eth.IndexedBlobHash
 				for _, h := range tx.BlobHashes() {
 					idh := eth.IndexedBlobHash{
@@ -158,10 +162,10 @@ func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, beacon
 				frameErrors = append(frameErrors, frameError)
 				validFrames = append(validFrames, validFrame)
 			}
-			if validSender && validBatch {
-				validBatchCount += 1
-			} else {
+			if !validSender || !validBatch {
 				invalidBatchCount += 1
+			} else if !missingBlobs {
+				validBatchCount += 1
 			}
 			txm := &TransactionWithMetadata{
 				Tx:          tx,
@@ -176,6 +180,8 @@ func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, beacon
 				Frames:      frames,
 				FrameErrs:   frameErrors,
 				ValidFrames: validFrames,
+
+				MissingBlobs: missingBlobs,
 			}
 			filename := path.Join(config.OutDirectory, fmt.Sprintf("%s.json", tx.Hash().String()))
 			file, err := os.Create(filename)
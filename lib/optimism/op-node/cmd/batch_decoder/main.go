@@ -8,6 +8,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/decode"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/fetch"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/reassemble"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -186,6 +187,82 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:  "decode",
+			Usage: "Decodes the L2 blocks & transactions of all batches, expanding span batches",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "in",
+					Value: "/tmp/batch_decoder/transactions_cache",
+					Usage: "Cache directory for the found transactions",
+				},
+				&cli.StringFlag{
+					Name:  "blobs",
+					Usage: "(Optional) Directory of blob sidecars, as returned by the L1 Beacon API, of blob transactions fetched without an L1 Beacon endpoint",
+				},
+				&cli.StringFlag{
+					Name:  "format",
+					Value: decode.FormatJSONLines,
+					Usage: fmt.Sprintf("Output format, one of %q or %q", decode.FormatJSONLines, decode.FormatCSV),
+				},
+				&cli.StringFlag{
+					Name:  "out",
+					Usage: "Output file. Defaults to /tmp/batch_decoder/blocks.<format>",
+				},
+				&cli.Uint64Flag{
+					Name:  "l2-chain-id",
+					Value: 10,
+					Usage: "L2 chain id to load the rollup config of from the superchain-registry. Default value from op-mainnet.",
+				},
+				&cli.StringFlag{
+					Name:  "rollup-config",
+					Usage: "(Optional) Rollup config file to use instead of the superchain-registry",
+				},
+				&cli.StringFlag{
+					Name:  "inbox",
+					Usage: "(Optional) Batch Inbox Address. Defaults to the rollup config's batch inbox address",
+				},
+			},
+			Action: func(cliCtx *cli.Context) error {
+				var rollupCfg *rollup.Config
+				if file := cliCtx.String("rollup-config"); file != "" {
+					f, err := os.Open(file)
+					if err != nil {
+						log.Fatal(err)
+					}
+					defer f.Close()
+					rollupCfg = new(rollup.Config)
+					if err := rollupCfg.ParseRollupConfig(f); err != nil {
+						log.Fatal(err)
+					}
+				} else {
+					var err error
+					rollupCfg, err = rollup.LoadOPStackRollupConfig(cliCtx.Uint64("l2-chain-id"))
+					if err != nil {
+						log.Fatal(err)
+					}
+				}
+				format := cliCtx.String("format")
+				if format != decode.FormatJSONLines && format != decode.FormatCSV {
+					log.Fatalf("unknown format: %q", format)
+				}
+				config := decode.Config{
+					BatchInbox:     rollupCfg.BatchInboxAddress,
+					InDirectory:    cliCtx.String("in"),
+					BlobsDirectory: cliCtx.String("blobs"),
+					OutFile:        cliCtx.String("out"),
+					Format:         format,
+				}
+				if inbox := cliCtx.String("inbox"); inbox != "" {
+					config.BatchInbox = common.HexToAddress(inbox)
+				}
+				if config.OutFile == "" {
+					config.OutFile = fmt.Sprintf("/tmp/batch_decoder/blocks.%s", format)
+				}
+				decode.Batches(config, rollupCfg)
+				return nil
+			},
+		},
 		{
 			Name:  "force-close",
 			Usage: "Create the tx data which will force close a channel",
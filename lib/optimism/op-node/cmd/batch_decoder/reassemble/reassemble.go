@@ -46,7 +46,18 @@ type Config struct {
 }
 
 func LoadFrames(directory string, inbox common.Address) []FrameWithMetadata {
+	return LoadFramesWithBlobs(directory, inbox, nil)
+}
+
+// LoadFramesWithBlobs is like LoadFrames, but also parses the frames of blob transactions that were
+// fetched without an L1 Beacon endpoint from the given blobs, which are keyed by versioned hash.
+func LoadFramesWithBlobs(directory string, inbox common.Address, blobs map[common.Hash]*eth.Blob) []FrameWithMetadata {
 	txns := loadTransactions(directory, inbox)
+	for i := range txns {
+		if txns[i].MissingBlobs {
+			parseBlobFrames(&txns[i], blobs)
+		}
+	}
 	// Sort first by block number then by transaction index inside the block number range.
 	// This is to match the order they are processed in derivation.
 	sort.Slice(txns, func(i, j int) bool {
@@ -117,7 +128,7 @@ func ProcessFrames(cfg Config, rollupCfg *rollup.Config, id derive.ChannelID, fr
 
 	invalidBatches := false
 	if ch.IsReady() {
-		br, err := derive.BatchReader(ch.Reader(), spec.MaxRLPBytesPerChannel(ch.HighestBlock().Time), rollupCfg.IsFjord(ch.HighestBlock().Time))
+		br, err := derive.BatchReader(ch.Reader(), spec.MaxRLPBytesPerChannel(ch.HighestBlock().Time), rollupCfg.IsFjord(ch.HighestBlock().Time), rollupCfg.IsZstdChannel(ch.HighestBlock().Time))
 		if err == nil {
 			for batchData, err := br(); err != io.EOF; batchData, err = br() {
 				if err != nil {
@@ -168,6 +179,28 @@ func ProcessFrames(cfg Config, rollupCfg *rollup.Config, id derive.ChannelID, fr
 	}
 }
 
+// parseBlobFrames parses the frames of the transaction's blobs, skipping unavailable or invalid blobs.
+func parseBlobFrames(txm *fetch.TransactionWithMetadata, blobs map[common.Hash]*eth.Blob) {
+	for _, h := range txm.Tx.BlobHashes() {
+		blob, ok := blobs[h]
+		if !ok {
+			fmt.Printf("Blob %v of transaction %v not found\n", h, txm.Tx.Hash())
+			continue
+		}
+		data, err := blob.ToData()
+		if err != nil {
+			fmt.Printf("Failed to parse blob %v of transaction %v. Err: %v\n", h, txm.Tx.Hash(), err)
+			continue
+		}
+		frames, err := derive.ParseFrames(data)
+		if err != nil {
+			fmt.Printf("Found a transaction (%v) with invalid data: %v\n", txm.Tx.Hash(), err)
+			continue
+		}
+		txm.Frames = append(txm.Frames, frames...)
+	}
+}
+
 func transactionsToFrames(txns []fetch.TransactionWithMetadata) []FrameWithMetadata {
 	var out []FrameWithMetadata
 	for _, tx := range txns {
@@ -15,6 +15,8 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/cmd/interop"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/networks"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/safedb"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/snapshot"
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/node"
@@ -63,7 +65,12 @@ func main() {
 			Name:        "networks",
 			Subcommands: networks.Subcommands,
 		},
+		{
+			Name:        "safedb",
+			Subcommands: safedb.Subcommands,
+		},
 		interop.InteropCmd,
+		snapshot.AnalyzeCmd,
 	}
 
 	ctx := ctxinterrupt.WithSignalWaiterMain(context.Background())
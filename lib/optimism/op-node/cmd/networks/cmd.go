@@ -19,6 +19,7 @@ var Subcommands = []*cli.Command{
 		Usage: "Dumps network configs",
 		Flags: []cli.Flag{
 			opflags.CLINetworkFlag(flags.EnvVarPrefix, ""),
+			opflags.CLINetworksDirFlag(flags.EnvVarPrefix, ""),
 		},
 		Action: func(ctx *cli.Context) error {
 			logCfg := oplog.ReadCLIConfig(ctx)
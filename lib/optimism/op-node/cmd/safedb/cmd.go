@@ -0,0 +1,112 @@
+package safedb
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var (
+	dbPathFlag = &cli.PathFlag{
+		Name:     "safedb.path",
+		Usage:    "Path of the safe head database. The op-node using it must be stopped.",
+		Required: true,
+	}
+	fileFlag = &cli.PathFlag{
+		Name:     "file",
+		Usage:    "Path of the export file",
+		Required: true,
+	}
+	l2RPCFlag = &cli.StringFlag{
+		Name:  "l2-rpc",
+		Usage: "(Optional) RPC URL of the L2 execution engine to spot-check imported safe heads against",
+	}
+	spotChecksFlag = &cli.IntFlag{
+		Name:  "spot-checks",
+		Usage: "Number of randomly sampled imported safe heads to check against the L2 execution engine",
+		Value: 100,
+	}
+)
+
+var Subcommands = cli.Commands{
+	{
+		Name:  "export",
+		Usage: "Exports the safe head database to a portable, checksummed file",
+		Flags: []cli.Flag{dbPathFlag, fileFlag},
+		Action: func(ctx *cli.Context) error {
+			logger := oplog.NewLogger(ctx.App.Writer, oplog.DefaultCLIConfig())
+			db, err := safedb.NewSafeDB(logger, ctx.Path(dbPathFlag.Name))
+			if err != nil {
+				return fmt.Errorf("failed to open safe head database: %w", err)
+			}
+			defer db.Close()
+
+			f, err := os.Create(ctx.Path(fileFlag.Name))
+			if err != nil {
+				return fmt.Errorf("failed to create export file: %w", err)
+			}
+			count, err := db.Export(ctx.Context, f)
+			if err != nil {
+				_ = f.Close()
+				return fmt.Errorf("failed to export safe head database: %w", err)
+			}
+			if err := f.Close(); err != nil {
+				return fmt.Errorf("failed to close export file: %w", err)
+			}
+			logger.Info("Exported safe head database", "entries", count, "file", ctx.Path(fileFlag.Name))
+			return nil
+		},
+	},
+	{
+		Name:  "import",
+		Usage: "Imports an exported safe head database into an empty safe head database, verifying its checksum and continuity",
+		Flags: []cli.Flag{dbPathFlag, fileFlag, l2RPCFlag, spotChecksFlag},
+		Action: func(ctx *cli.Context) error {
+			logger := oplog.NewLogger(ctx.App.Writer, oplog.DefaultCLIConfig())
+			var check safedb.SpotCheckFn
+			if l2RPC := ctx.String(l2RPCFlag.Name); l2RPC != "" {
+				client, err := ethclient.DialContext(ctx.Context, l2RPC)
+				if err != nil {
+					return fmt.Errorf("cannot dial %s: %w", l2RPC, err)
+				}
+				defer client.Close()
+				check = func(ctx context.Context, l1 eth.BlockID, safeHead eth.BlockID) error {
+					header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(safeHead.Number))
+					if err != nil {
+						return fmt.Errorf("failed to fetch L2 block %d: %w", safeHead.Number, err)
+					}
+					if header.Hash() != safeHead.Hash {
+						return fmt.Errorf("L2 block %d has hash %s", safeHead.Number, header.Hash())
+					}
+					return nil
+				}
+			}
+
+			f, err := os.Open(ctx.Path(fileFlag.Name))
+			if err != nil {
+				return fmt.Errorf("failed to open export file: %w", err)
+			}
+			defer f.Close()
+			db, err := safedb.NewSafeDB(logger, ctx.Path(dbPathFlag.Name))
+			if err != nil {
+				return fmt.Errorf("failed to open safe head database: %w", err)
+			}
+			defer db.Close()
+
+			count, err := db.Import(ctx.Context, f, ctx.Int(spotChecksFlag.Name), check)
+			if err != nil {
+				return fmt.Errorf("failed to import safe head database: %w", err)
+			}
+			logger.Info("Imported safe head database", "entries", count, "spot_checked", check != nil)
+			return nil
+		},
+	},
+}
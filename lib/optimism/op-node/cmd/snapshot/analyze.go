@@ -0,0 +1,354 @@
+package snapshot
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	FormatJSONLines = "jsonl"
+	FormatCSV       = "csv"
+)
+
+const (
+	HeadL1        = "l1"
+	HeadUnsafe    = "unsafe"
+	HeadSafe      = "safe"
+	HeadFinalized = "finalized"
+)
+
+// Config configures the thresholds of the anomalies that are detected. A zero threshold disables the detection.
+type Config struct {
+	// UnsafeStall is the duration after which an unsafe head that does not change is reported as stalled.
+	UnsafeStall time.Duration
+	// SafeStall is the duration after which a safe head that does not change is reported as stalled.
+	SafeStall time.Duration
+	// FinalizedStall is the duration after which a finalized head that does not change is reported as stalled.
+	FinalizedStall time.Duration
+	// SafeLag is the number of blocks that the safe head may be behind the unsafe head,
+	// before it is reported as a safe lag spike.
+	SafeLag uint64
+}
+
+// Reorg is a change of a head to a block that does not build on the previous head.
+type Reorg struct {
+	Time time.Time
+	Head string
+	From eth.BlockID
+	To   eth.BlockID
+	// Rewind is the number of blocks the head moved back by, 0 if it was replaced by a block of the same or next height.
+	Rewind uint64
+}
+
+// Stall is a period in which a head did not change for longer than the configured threshold.
+type Stall struct {
+	Head  string
+	Start time.Time
+	End   time.Time
+	// Block is the head during the stall.
+	Block eth.BlockID
+	// Ongoing is true if the head had not changed yet at the end of the snapshot log.
+	Ongoing bool
+}
+
+func (s *Stall) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// LagSpike is a period in which the safe head lagged behind the unsafe head by more than the configured threshold.
+type LagSpike struct {
+	Start  time.Time
+	End    time.Time
+	MaxLag uint64
+	// Ongoing is true if the lag had not recovered yet at the end of the snapshot log.
+	Ongoing bool
+}
+
+// Report summarizes the snapshot log and the anomalies found in it.
+type Report struct {
+	Snapshots int
+	Start     time.Time
+	End       time.Time
+
+	FirstUnsafe, LastUnsafe       eth.L2BlockRef
+	FirstSafe, LastSafe           eth.L2BlockRef
+	FirstFinalized, LastFinalized eth.L2BlockRef
+	MaxSafeLag                    uint64
+
+	Reorgs    []Reorg
+	Stalls    []Stall
+	LagSpikes []LagSpike
+}
+
+// TimelineEntry is a single snapshot of the timeline, annotated with the anomalies that were detected at it.
+type TimelineEntry struct {
+	Time              time.Time   `json:"t"`
+	Event             string      `json:"event"`
+	L1Head            uint64      `json:"l1Head"`
+	L1Current         uint64      `json:"l1Current"`
+	Unsafe            uint64      `json:"unsafe"`
+	UnsafeHash        common.Hash `json:"unsafeHash"`
+	UnsafeL1Origin    uint64      `json:"unsafeL1Origin"`
+	Safe              uint64      `json:"safe"`
+	SafeHash          common.Hash `json:"safeHash"`
+	SafeL1Origin      uint64      `json:"safeL1Origin"`
+	Finalized         uint64      `json:"finalized"`
+	FinalizedHash     common.Hash `json:"finalizedHash"`
+	FinalizedL1Origin uint64      `json:"finalizedL1Origin"`
+	SafeLag           uint64      `json:"safeLag"`
+	Anomalies         []string    `json:"anomalies,omitempty"`
+}
+
+// headState tracks a single head for stall detection.
+type headState struct {
+	name      string
+	threshold time.Duration
+	get       func(s *status.Snapshot) eth.L2BlockRef
+
+	ref   eth.BlockID
+	since time.Time
+}
+
+// Analyze reconstructs the timeline of the heads of the given snapshots, ordered by time,
+// and detects reorgs, stalls and safe lag spikes in it.
+func Analyze(cfg Config, snapshots []status.Snapshot) (*Report, []TimelineEntry) {
+	report := &Report{Snapshots: len(snapshots)}
+	timeline := make([]TimelineEntry, 0, len(snapshots))
+	if len(snapshots) == 0 {
+		return report, timeline
+	}
+	report.Start = snapshots[0].Time
+	report.End = snapshots[len(snapshots)-1].Time
+
+	heads := []*headState{
+		{name: HeadUnsafe, threshold: cfg.UnsafeStall, get: func(s *status.Snapshot) eth.L2BlockRef { return s.L2Unsafe }},
+		{name: HeadSafe, threshold: cfg.SafeStall, get: func(s *status.Snapshot) eth.L2BlockRef { return s.L2Safe }},
+		{name: HeadFinalized, threshold: cfg.FinalizedStall, get: func(s *status.Snapshot) eth.L2BlockRef { return s.L2Finalized }},
+	}
+	var spike *LagSpike
+	var prev *status.Snapshot
+	for i := range snapshots {
+		snap := &snapshots[i]
+		entry := TimelineEntry{
+			Time:              snap.Time,
+			Event:             snap.Event,
+			L1Head:            snap.L1Head.Number,
+			L1Current:         snap.L1Current.Number,
+			Unsafe:            snap.L2Unsafe.Number,
+			UnsafeHash:        snap.L2Unsafe.Hash,
+			UnsafeL1Origin:    snap.L2Unsafe.L1Origin.Number,
+			Safe:              snap.L2Safe.Number,
+			SafeHash:          snap.L2Safe.Hash,
+			SafeL1Origin:      snap.L2Safe.L1Origin.Number,
+			Finalized:         snap.L2Finalized.Number,
+			FinalizedHash:     snap.L2Finalized.Hash,
+			FinalizedL1Origin: snap.L2Finalized.L1Origin.Number,
+		}
+		updateRange(&report.FirstUnsafe, &report.LastUnsafe, snap.L2Unsafe)
+		updateRange(&report.FirstSafe, &report.LastSafe, snap.L2Safe)
+		updateRange(&report.FirstFinalized, &report.LastFinalized, snap.L2Finalized)
+
+		if prev != nil {
+			for _, c := range []struct {
+				head       string
+				prev, next blockRef
+			}{
+				{HeadL1, l1Ref(prev.L1Head), l1Ref(snap.L1Head)},
+				{HeadUnsafe, l2Ref(prev.L2Unsafe), l2Ref(snap.L2Unsafe)},
+				{HeadSafe, l2Ref(prev.L2Safe), l2Ref(snap.L2Safe)},
+				{HeadFinalized, l2Ref(prev.L2Finalized), l2Ref(snap.L2Finalized)},
+			} {
+				if r, ok := detectReorg(c.prev, c.next); ok {
+					r.Time = snap.Time
+					r.Head = c.head
+					report.Reorgs = append(report.Reorgs, r)
+					entry.Anomalies = append(entry.Anomalies, "reorg:"+c.head)
+				}
+			}
+		}
+
+		for _, h := range heads {
+			ref := h.get(snap).ID()
+			if ref == h.ref {
+				continue
+			}
+			if h.threshold > 0 && h.ref != (eth.BlockID{}) && snap.Time.Sub(h.since) > h.threshold {
+				report.Stalls = append(report.Stalls, Stall{Head: h.name, Start: h.since, End: snap.Time, Block: h.ref})
+				entry.Anomalies = append(entry.Anomalies, "stall-end:"+h.name)
+			}
+			h.ref = ref
+			h.since = snap.Time
+		}
+
+		if snap.L2Unsafe != (eth.L2BlockRef{}) && snap.L2Safe != (eth.L2BlockRef{}) && snap.L2Unsafe.Number > snap.L2Safe.Number {
+			entry.SafeLag = snap.L2Unsafe.Number - snap.L2Safe.Number
+		}
+		report.MaxSafeLag = max(report.MaxSafeLag, entry.SafeLag)
+		if cfg.SafeLag > 0 {
+			if entry.SafeLag > cfg.SafeLag {
+				if spike == nil {
+					spike = &LagSpike{Start: snap.Time}
+					entry.Anomalies = append(entry.Anomalies, "safe-lag-start")
+				}
+				spike.MaxLag = max(spike.MaxLag, entry.SafeLag)
+			} else if spike != nil {
+				spike.End = snap.Time
+				report.LagSpikes = append(report.LagSpikes, *spike)
+				spike = nil
+				entry.Anomalies = append(entry.Anomalies, "safe-lag-end")
+			}
+		}
+
+		timeline = append(timeline, entry)
+		prev = snap
+	}
+
+	for _, h := range heads {
+		if h.threshold > 0 && h.ref != (eth.BlockID{}) && report.End.Sub(h.since) > h.threshold {
+			report.Stalls = append(report.Stalls, Stall{Head: h.name, Start: h.since, End: report.End, Block: h.ref, Ongoing: true})
+		}
+	}
+	if spike != nil {
+		spike.End = report.End
+		spike.Ongoing = true
+		report.LagSpikes = append(report.LagSpikes, *spike)
+	}
+	return report, timeline
+}
+
+func updateRange(first, last *eth.L2BlockRef, ref eth.L2BlockRef) {
+	if ref == (eth.L2BlockRef{}) {
+		return
+	}
+	if *first == (eth.L2BlockRef{}) {
+		*first = ref
+	}
+	*last = ref
+}
+
+// blockRef is the common part of L1 and L2 block references that reorg detection needs.
+type blockRef struct {
+	id     eth.BlockID
+	parent common.Hash
+}
+
+func l1Ref(ref eth.L1BlockRef) blockRef {
+	return blockRef{id: ref.ID(), parent: ref.ParentHash}
+}
+
+func l2Ref(ref eth.L2BlockRef) blockRef {
+	return blockRef{id: ref.ID(), parent: ref.ParentHash}
+}
+
+// detectReorg returns a reorg if the next head does not build on the previous head.
+// Heads that are unset, e.g. during a pipeline reset, and heads that skip blocks, are not reported.
+func detectReorg(prev, next blockRef) (Reorg, bool) {
+	if prev.id == (eth.BlockID{}) || next.id == (eth.BlockID{}) || prev.id == next.id {
+		return Reorg{}, false
+	}
+	switch {
+	case next.id.Number <= prev.id.Number:
+		return Reorg{From: prev.id, To: next.id, Rewind: prev.id.Number - next.id.Number}, true
+	case next.id.Number == prev.id.Number+1 && next.parent != prev.id.Hash:
+		return Reorg{From: prev.id, To: next.id}, true
+	default:
+		return Reorg{}, false
+	}
+}
+
+// WriteReport writes a human-readable summary of the report.
+func WriteReport(w io.Writer, r *Report) error {
+	if r.Snapshots == 0 {
+		_, err := io.WriteString(w, "No snapshots found\n")
+		return err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Snapshots:       %d, from %s to %s (%s)\n", r.Snapshots,
+		r.Start.Format(time.RFC3339), r.End.Format(time.RFC3339), r.End.Sub(r.Start).Round(time.Second))
+	fmt.Fprintf(&b, "Unsafe head:     %s -> %s\n", r.FirstUnsafe.TerminalString(), r.LastUnsafe.TerminalString())
+	fmt.Fprintf(&b, "Safe head:       %s -> %s\n", r.FirstSafe.TerminalString(), r.LastSafe.TerminalString())
+	fmt.Fprintf(&b, "Finalized head:  %s -> %s\n", r.FirstFinalized.TerminalString(), r.LastFinalized.TerminalString())
+	fmt.Fprintf(&b, "Last L1 origin:  unsafe %s, safe %s\n", r.LastUnsafe.L1Origin.TerminalString(), r.LastSafe.L1Origin.TerminalString())
+	fmt.Fprintf(&b, "Max safe lag:    %d blocks\n", r.MaxSafeLag)
+
+	fmt.Fprintf(&b, "\nReorgs: %d\n", len(r.Reorgs))
+	for _, x := range r.Reorgs {
+		fmt.Fprintf(&b, "  %s  %-9s %s -> %s, rewind %d\n",
+			x.Time.Format(time.RFC3339), x.Head, x.From.TerminalString(), x.To.TerminalString(), x.Rewind)
+	}
+	fmt.Fprintf(&b, "\nStalls: %d\n", len(r.Stalls))
+	for _, x := range r.Stalls {
+		ongoing := ""
+		if x.Ongoing {
+			ongoing = " (ongoing)"
+		}
+		fmt.Fprintf(&b, "  %s  %-9s stalled at %s for %s%s\n",
+			x.Start.Format(time.RFC3339), x.Head, x.Block.TerminalString(), x.Duration().Round(time.Second), ongoing)
+	}
+	fmt.Fprintf(&b, "\nSafe lag spikes: %d\n", len(r.LagSpikes))
+	for _, x := range r.LagSpikes {
+		ongoing := ""
+		if x.Ongoing {
+			ongoing = " (ongoing)"
+		}
+		fmt.Fprintf(&b, "  %s  max lag %d blocks for %s%s\n",
+			x.Start.Format(time.RFC3339), x.MaxLag, x.End.Sub(x.Start).Round(time.Second), ongoing)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteTimeline writes the timeline in the given format, one JSON object per line or one CSV row per snapshot.
+func WriteTimeline(w io.Writer, format string, timeline []TimelineEntry) error {
+	switch format {
+	case FormatJSONLines:
+		return writeTimelineJSONLines(w, timeline)
+	case FormatCSV:
+		return writeTimelineCSV(w, timeline)
+	default:
+		return fmt.Errorf("unknown format: %q", format)
+	}
+}
+
+func writeTimelineJSONLines(w io.Writer, timeline []TimelineEntry) error {
+	enc := json.NewEncoder(w)
+	for i := range timeline {
+		if err := enc.Encode(&timeline[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeTimelineCSV(w io.Writer, timeline []TimelineEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"time", "event", "l1_head", "l1_current",
+		"unsafe", "unsafe_hash", "unsafe_l1_origin", "safe", "safe_hash", "safe_l1_origin",
+		"finalized", "finalized_hash", "finalized_l1_origin", "safe_lag", "anomalies"}); err != nil {
+		return err
+	}
+	u := func(v uint64) string { return strconv.FormatUint(v, 10) }
+	for _, e := range timeline {
+		if err := cw.Write([]string{
+			e.Time.Format(time.RFC3339Nano), e.Event, u(e.L1Head), u(e.L1Current),
+			u(e.Unsafe), e.UnsafeHash.String(), u(e.UnsafeL1Origin),
+			u(e.Safe), e.SafeHash.String(), u(e.SafeL1Origin),
+			u(e.Finalized), e.FinalizedHash.String(), u(e.FinalizedL1Origin),
+			u(e.SafeLag), strings.Join(e.Anomalies, ";"),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,121 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ref creates a L2 block reference, where blocks of a different fork get a different hash.
+func ref(number uint64, fork byte) eth.L2BlockRef {
+	return eth.L2BlockRef{
+		Hash:       common.Hash{fork, byte(number >> 8), byte(number)},
+		Number:     number,
+		ParentHash: common.Hash{fork, byte((number - 1) >> 8), byte(number - 1)},
+		L1Origin:   eth.BlockID{Number: number / 6},
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0).UTC()
+	var snapshots []status.Snapshot
+	add := func(at time.Duration, unsafe, safe, finalized eth.L2BlockRef) {
+		snapshots = append(snapshots, status.Snapshot{
+			Time:        start.Add(at),
+			Event:       "forkchoice-update",
+			L2Unsafe:    unsafe,
+			L2Safe:      safe,
+			L2Finalized: finalized,
+		})
+	}
+	fin := ref(1, 0)
+	add(0, ref(10, 0), ref(5, 0), fin)
+	add(2*time.Second, ref(11, 0), ref(5, 0), fin)
+	add(4*time.Second, ref(12, 0), ref(5, 0), fin)
+	// unsafe block 12 is replaced by another fork
+	add(6*time.Second, ref(12, 1), ref(5, 0), fin)
+	// unsafe head stalls for 2 minutes, and the safe head lags
+	add(3*time.Minute, ref(13, 1), ref(5, 0), fin)
+	// safe head catches up, after which the unsafe head is reset back to it
+	add(3*time.Minute+2*time.Second, ref(13, 1), ref(11, 0), fin)
+	add(3*time.Minute+4*time.Second, ref(11, 0), ref(11, 0), fin)
+
+	report, timeline := Analyze(Config{
+		UnsafeStall:    time.Minute,
+		SafeStall:      10 * time.Minute,
+		FinalizedStall: 2 * time.Minute,
+		SafeLag:        7,
+	}, snapshots)
+	require.Len(t, timeline, len(snapshots))
+	require.Equal(t, len(snapshots), report.Snapshots)
+	require.Equal(t, ref(10, 0), report.FirstUnsafe)
+	require.Equal(t, ref(11, 0), report.LastUnsafe)
+	require.Equal(t, uint64(8), report.MaxSafeLag)
+
+	require.Equal(t, []Reorg{
+		{Time: start.Add(6 * time.Second), Head: HeadUnsafe, From: ref(12, 0).ID(), To: ref(12, 1).ID(), Rewind: 0},
+		{Time: start.Add(3*time.Minute + 4*time.Second), Head: HeadUnsafe, From: ref(13, 1).ID(), To: ref(11, 0).ID(), Rewind: 2},
+	}, report.Reorgs)
+
+	require.Equal(t, []Stall{
+		{Head: HeadUnsafe, Start: start.Add(6 * time.Second), End: start.Add(3 * time.Minute), Block: ref(12, 1).ID()},
+		{Head: HeadFinalized, Start: start, End: start.Add(3*time.Minute + 4*time.Second), Block: fin.ID(), Ongoing: true},
+	}, report.Stalls)
+
+	require.Equal(t, []LagSpike{
+		{Start: start.Add(3 * time.Minute), End: start.Add(3*time.Minute + 2*time.Second), MaxLag: 8},
+	}, report.LagSpikes)
+	require.Equal(t, []string{"reorg:unsafe"}, timeline[3].Anomalies)
+	require.Equal(t, []string{"stall-end:unsafe", "safe-lag-start"}, timeline[4].Anomalies)
+	require.Equal(t, uint64(8), timeline[4].SafeLag)
+	require.Equal(t, uint64(2), timeline[4].UnsafeL1Origin)
+
+	t.Run("report", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteReport(&buf, report))
+		out := buf.String()
+		require.Contains(t, out, "Reorgs: 2")
+		require.Contains(t, out, "Stalls: 2")
+		require.Contains(t, out, "(ongoing)")
+		require.Contains(t, out, "Safe lag spikes: 1")
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteTimeline(&buf, FormatCSV, timeline))
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, len(timeline)+1)
+		require.Equal(t, "time", records[0][0])
+		require.Equal(t, "13", records[5][4])
+		require.Equal(t, "stall-end:unsafe;safe-lag-start", records[5][14])
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteTimeline(&buf, FormatJSONLines, timeline))
+		dec := json.NewDecoder(&buf)
+		for _, expected := range timeline {
+			var entry TimelineEntry
+			require.NoError(t, dec.Decode(&entry))
+			require.Equal(t, expected, entry)
+		}
+		require.False(t, dec.More())
+	})
+}
+
+func TestAnalyzeEmpty(t *testing.T) {
+	report, timeline := Analyze(Config{UnsafeStall: time.Minute}, nil)
+	require.Empty(t, timeline)
+	var buf bytes.Buffer
+	require.NoError(t, WriteReport(&buf, report))
+	require.Equal(t, "No snapshots found\n", buf.String())
+}
@@ -0,0 +1,92 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
+)
+
+var (
+	fileFlag = &cli.PathFlag{
+		Name:     "file",
+		Usage:    "Path of the snapshot log, as written by the op-node with --debug.snapshot-log-path",
+		Required: true,
+	}
+	timelineFlag = &cli.PathFlag{
+		Name:  "timeline",
+		Usage: "(Optional) Path to write the timeline of the heads to, annotated with the detected anomalies",
+	}
+	timelineFormatFlag = &cli.StringFlag{
+		Name:  "timeline-format",
+		Usage: "Format of the timeline: '" + FormatJSONLines + "' (one JSON object per snapshot) or '" + FormatCSV + "'",
+		Value: FormatCSV,
+	}
+	unsafeStallFlag = &cli.DurationFlag{
+		Name:  "unsafe-stall",
+		Usage: "Report the unsafe head as stalled if it does not change for longer than this. Disabled if 0",
+		Value: time.Minute,
+	}
+	safeStallFlag = &cli.DurationFlag{
+		Name:  "safe-stall",
+		Usage: "Report the safe head as stalled if it does not change for longer than this. Disabled if 0",
+		Value: 30 * time.Minute,
+	}
+	finalizedStallFlag = &cli.DurationFlag{
+		Name:  "finalized-stall",
+		Usage: "Report the finalized head as stalled if it does not change for longer than this. Disabled if 0",
+		Value: 30 * time.Minute,
+	}
+	safeLagFlag = &cli.Uint64Flag{
+		Name:  "safe-lag",
+		Usage: "Report a safe lag spike if the safe head is more than this number of blocks behind the unsafe head. Disabled if 0",
+		Value: 1800,
+	}
+)
+
+var AnalyzeCmd = &cli.Command{
+	Name:  "snapshot-analyze",
+	Usage: "Reconstructs the timeline of the L1 and L2 heads from a snapshot log, and reports reorgs, stalls and safe lag spikes",
+	Flags: []cli.Flag{fileFlag, timelineFlag, timelineFormatFlag, unsafeStallFlag, safeStallFlag, finalizedStallFlag, safeLagFlag},
+	Action: func(ctx *cli.Context) error {
+		format := ctx.String(timelineFormatFlag.Name)
+		if format != FormatJSONLines && format != FormatCSV {
+			return fmt.Errorf("unknown timeline format: %q", format)
+		}
+		f, err := os.Open(ctx.Path(fileFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to open snapshot log: %w", err)
+		}
+		snapshots, err := status.ReadSnapshots(f)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read snapshot log: %w", err)
+		}
+		report, timeline := Analyze(Config{
+			UnsafeStall:    ctx.Duration(unsafeStallFlag.Name),
+			SafeStall:      ctx.Duration(safeStallFlag.Name),
+			FinalizedStall: ctx.Duration(finalizedStallFlag.Name),
+			SafeLag:        ctx.Uint64(safeLagFlag.Name),
+		}, snapshots)
+		if err := WriteReport(ctx.App.Writer, report); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		if path := ctx.Path(timelineFlag.Name); path != "" {
+			out, err := os.Create(path)
+			if err != nil {
+				return fmt.Errorf("failed to create timeline file: %w", err)
+			}
+			if err := WriteTimeline(out, format, timeline); err != nil {
+				_ = out.Close()
+				return fmt.Errorf("failed to write timeline: %w", err)
+			}
+			if err := out.Close(); err != nil {
+				return fmt.Errorf("failed to close timeline file: %w", err)
+			}
+		}
+		return nil
+	},
+}
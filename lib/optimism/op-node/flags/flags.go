@@ -8,7 +8,9 @@ import (
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -158,6 +160,33 @@ var (
 		}(),
 		Category: L1RPCCategory,
 	}
+	L1ExtraAddrs = &cli.StringSliceFlag{
+		Name:     "l1.extra-rpcs",
+		Usage:    "Addresses of additional L1 User JSON-RPC endpoints of the same chain, used for failover or quorum with the l1 endpoint.",
+		EnvVars:  prefixEnvVars("L1_EXTRA_RPCS"),
+		Category: L1RPCCategory,
+	}
+	L1MultiMode = &cli.GenericFlag{
+		Name: "l1.multi-mode",
+		Usage: "How to use multiple L1 RPC endpoints, if l1.extra-rpcs is set. 'failover' rotates away from failing or lagging endpoints, " +
+			"'quorum' additionally requires block headers to match across l1.quorum endpoints. Valid options: " +
+			openum.EnumString(client.MultiModes),
+		EnvVars: prefixEnvVars("L1_MULTI_MODE"),
+		Value: func() *client.MultiMode {
+			out := client.MultiFailover
+			return &out
+		}(),
+		Category: L1RPCCategory,
+	}
+	L1Quorum = &cli.IntFlag{
+		Name: "l1.quorum",
+		Usage: "Number of L1 RPC endpoints that must agree on block headers in quorum mode. Defaults to a majority of the endpoints if 0. " +
+			"A higher quorum needs more colluding endpoints to accept a wrong header, but fewer faulty endpoints to stall the node. " +
+			"Must be a majority and at most all but one of the endpoints, so quorum mode requires at least 3 endpoints.",
+		EnvVars:  prefixEnvVars("L1_QUORUM"),
+		Value:    0,
+		Category: L1RPCCategory,
+	}
 	L1RPCMaxConcurrency = &cli.IntFlag{
 		Name:     "l1.max-concurrency",
 		Usage:    "Maximum number of concurrent RPC requests to make to the L1 RPC provider.",
@@ -249,6 +278,33 @@ var (
 		Value:    4,
 		Category: SequencerCategory,
 	}
+	SequencerOriginPolicy = &cli.GenericFlag{
+		Name: "sequencer.origin-policy",
+		Usage: "Policy for adopting the next L1 origin as a sequencer. 'eager' adopts it as soon as possible, " +
+			"'adaptive' requires extra L1 confirmations after L1 reorgs, " +
+			"'drift-margin' is adaptive but adopts the next origin when the sequencer drift margin becomes small. Valid options: " +
+			openum.EnumString(sequencing.OriginPolicyKinds),
+		EnvVars: prefixEnvVars("SEQUENCER_ORIGIN_POLICY"),
+		Value: func() *sequencing.OriginPolicyKind {
+			out := sequencing.OriginPolicyEager
+			return &out
+		}(),
+		Category: SequencerCategory,
+	}
+	SequencerOriginMaxExtraConfs = &cli.Uint64Flag{
+		Name:     "sequencer.origin-max-extra-confs",
+		Usage:    "Maximum number of L1 confirmations, on top of sequencer.l1-confs, that the adaptive origin policies require after L1 reorgs.",
+		EnvVars:  prefixEnvVars("SEQUENCER_ORIGIN_MAX_EXTRA_CONFS"),
+		Value:    8,
+		Category: SequencerCategory,
+	}
+	SequencerOriginMinDriftMargin = &cli.Uint64Flag{
+		Name:     "sequencer.origin-min-drift-margin",
+		Usage:    "Seconds of sequencer drift margin, below which the drift-margin origin policy adopts the next L1 origin regardless of L1 reorgs.",
+		EnvVars:  prefixEnvVars("SEQUENCER_ORIGIN_MIN_DRIFT_MARGIN"),
+		Value:    300,
+		Category: SequencerCategory,
+	}
 	SequencerRecoverMode = &cli.BoolFlag{
 		Name:     "sequencer.recover",
 		Usage:    "Forces the sequencer to strictly prepare the next L1 origin and create empty L2 blocks",
@@ -336,6 +392,88 @@ var (
 		EnvVars:  prefixEnvVars("SAFEDB_PATH"),
 		Category: OperationsCategory,
 	}
+	SafeDBRetention = &cli.Uint64Flag{
+		Name:     "safedb.retention",
+		Usage:    "Number of L1 blocks to keep safe head update data for. Older data is pruned. All data is kept if 0.",
+		EnvVars:  prefixEnvVars("SAFEDB_RETENTION"),
+		Value:    0,
+		Category: OperationsCategory,
+	}
+	EventTraceDir = &cli.StringFlag{
+		Name:     "debug.event-trace-dir",
+		Usage:    "Directory to write event traces started with admin_startEventTrace to. Event tracing is disabled if not set.",
+		EnvVars:  prefixEnvVars("DEBUG_EVENT_TRACE_DIR"),
+		Category: OperationsCategory,
+	}
+	EventRecordPath = &cli.StringFlag{
+		Name: "debug.event-record-path",
+		Usage: "File path to record all events and L1, engine and L1 Beacon API responses to, to replay them offline. " +
+			"The file grows without bound, only enable this to debug. Disabled if not set.",
+		EnvVars:  prefixEnvVars("DEBUG_EVENT_RECORD_PATH"),
+		Category: OperationsCategory,
+	}
+	SnapshotLogPath = &cli.StringFlag{
+		Name: "debug.snapshot-log-path",
+		Usage: "File path to append a snapshot of the L1 and L2 heads to, as JSON line, every time any of the heads changes. " +
+			"Analyze the snapshots with the snapshot-analyze command. The file grows without bound, only enable this to debug. Disabled if not set.",
+		EnvVars:  prefixEnvVars("DEBUG_SNAPSHOT_LOG_PATH"),
+		Category: OperationsCategory,
+	}
+	UnsafePayloadsPath = &cli.StringFlag{
+		Name: "unsafe-payloads.path",
+		Usage: "Directory used to persist signed unsafe payloads, to recover the queue of unsafe payloads after a restart. " +
+			"Persisted payloads are verified against the unsafe block signer and the finalized head when loaded. Disabled if not set.",
+		EnvVars:  prefixEnvVars("UNSAFE_PAYLOADS_PATH"),
+		Category: OperationsCategory,
+	}
+	UnsafePayloadsMaxSize = &cli.Uint64Flag{
+		Name:     "unsafe-payloads.max-size",
+		Usage:    "Maximum total size in bytes of the persisted unsafe payloads. Payloads beyond the maximum are not persisted.",
+		EnvVars:  prefixEnvVars("UNSAFE_PAYLOADS_MAX_SIZE"),
+		Value:    500 * 1024 * 1024,
+		Category: OperationsCategory,
+	}
+	P2PSignersFile = &cli.StringFlag{
+		Name: "p2p.signers-file",
+		Usage: "JSON file with a list of additional unsafe block signers, each with an optional activationTime and expiryTime L2 timestamp, " +
+			"to accept blocks from both the old and new signer during a key rotation. The file is reloaded together with the runtime config. Disabled if not set.",
+		EnvVars:  prefixEnvVars("P2P_SIGNERS_FILE"),
+		Category: P2PCategory,
+	}
+	OutputCheckDisputeGameFactory = &cli.StringFlag{
+		Name: "output-check.dgf-address",
+		Usage: "Address of the DisputeGameFactory on L1, to check the root claims of new dispute games against the output roots of the local safe chain. " +
+			"Divergences are logged, counted in metrics and served by the optimism_outputDivergences RPC. Disabled if not set.",
+		EnvVars:  prefixEnvVars("OUTPUT_CHECK_DGF_ADDRESS"),
+		Category: OperationsCategory,
+	}
+	OutputCheckL2OutputOracle = &cli.StringFlag{
+		Name:     "output-check.l2oo-address",
+		Usage:    "Address of the legacy L2OutputOracle on L1, to check its output proposals against the output roots of the local safe chain. Disabled if not set.",
+		EnvVars:  prefixEnvVars("OUTPUT_CHECK_L2OO_ADDRESS"),
+		Category: OperationsCategory,
+	}
+	OutputCheckGameTypes = &cli.UintSliceFlag{
+		Name:     "output-check.game-types",
+		Usage:    "Types of dispute games to check the root claims of. Only game types that claim an output root can be checked.",
+		EnvVars:  prefixEnvVars("OUTPUT_CHECK_GAME_TYPES"),
+		Value:    cli.NewUintSlice(0, 1),
+		Category: OperationsCategory,
+	}
+	OutputCheckPollInterval = &cli.DurationFlag{
+		Name:     "output-check.poll-interval",
+		Usage:    "Interval to poll L1 for new finalized output proposals at.",
+		EnvVars:  prefixEnvVars("OUTPUT_CHECK_POLL_INTERVAL"),
+		Value:    time.Minute,
+		Category: OperationsCategory,
+	}
+	OutputCheckBackfill = &cli.Uint64Flag{
+		Name:     "output-check.backfill",
+		Usage:    "Number of most recent output proposals of each contract to check at startup.",
+		EnvVars:  prefixEnvVars("OUTPUT_CHECK_BACKFILL"),
+		Value:    10,
+		Category: OperationsCategory,
+	}
 	/* Deprecated Flags */
 	L2EngineSyncEnabled = &cli.BoolFlag{
 		Name:    "l2.engine-sync",
@@ -458,6 +596,9 @@ var optionalFlags = []cli.Flag{
 	L1RPCRateLimit,
 	L1RPCMaxBatchSize,
 	L1RPCMaxConcurrency,
+	L1ExtraAddrs,
+	L1MultiMode,
+	L1Quorum,
 	L1HTTPPollInterval,
 	L1CacheSize,
 	VerifierL1Confs,
@@ -465,6 +606,9 @@ var optionalFlags = []cli.Flag{
 	SequencerStoppedFlag,
 	SequencerMaxSafeLagFlag,
 	SequencerL1Confs,
+	SequencerOriginPolicy,
+	SequencerOriginMaxExtraConfs,
+	SequencerOriginMinDriftMargin,
 	SequencerRecoverMode,
 	L1EpochPollIntervalFlag,
 	RuntimeConfigReloadIntervalFlag,
@@ -483,6 +627,18 @@ var optionalFlags = []cli.Flag{
 	ConductorRpcFlag,
 	ConductorRpcTimeoutFlag,
 	SafeDBPath,
+	SafeDBRetention,
+	EventRecordPath,
+	SnapshotLogPath,
+	EventTraceDir,
+	UnsafePayloadsPath,
+	UnsafePayloadsMaxSize,
+	P2PSignersFile,
+	OutputCheckDisputeGameFactory,
+	OutputCheckL2OutputOracle,
+	OutputCheckGameTypes,
+	OutputCheckPollInterval,
+	OutputCheckBackfill,
 	L2EngineKind,
 	L2EngineRpcTimeout,
 	InteropSupervisor,
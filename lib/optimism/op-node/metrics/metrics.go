@@ -49,6 +49,9 @@ type Metricer interface {
 	RecordL1ReorgDepth(d uint64)
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
+	SetSequencerHandoffScheduled(kind string, scheduled bool)
+	RecordSequencerHandoff(kind string)
+	RecordOutputCheck(source string, result string)
 	RecordGossipEvent(evType int32)
 	IncPeerCount()
 	DecPeerCount()
@@ -66,6 +69,8 @@ type Metricer interface {
 	SetPeerScores(allScores []store.PeerScores)
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ClientPayloadsByRangeEvent(start uint64, received uint64, resultCode byte, duration time.Duration)
+	ServerPayloadsByRangeEvent(start uint64, served uint64, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
 	RecordPeerUnban()
 	RecordIPUnban()
@@ -100,12 +105,16 @@ type Metrics struct {
 	P2PReqDurationSeconds *prometheus.HistogramVec
 	P2PReqTotal           *prometheus.CounterVec
 	P2PPayloadByNumber    *prometheus.GaugeVec
+	P2PPayloadsByRange    *prometheus.CounterVec
 
 	PayloadsQuarantineTotal prometheus.Gauge
 
 	SequencerInconsistentL1Origin *metrics.Event
 	SequencerResets               *metrics.Event
 
+	SequencerHandoffScheduled *prometheus.GaugeVec
+	SequencerHandoffs         metrics.EventVec
+
 	L1RequestDurationSeconds *prometheus.HistogramVec
 
 	SequencerBuildingDiffDurationSeconds prometheus.Histogram
@@ -143,6 +152,8 @@ type Metrics struct {
 
 	ChannelInputBytes prometheus.Counter
 
+	OutputChecks *prometheus.CounterVec
+
 	// Protocol version reporting
 	// Delta = params.ProtocolVersionComparison
 	ProtocolVersionDelta *prometheus.GaugeVec
@@ -209,6 +220,13 @@ func NewMetrics(procName string) *Metrics {
 		SequencerInconsistentL1Origin: metrics.NewEvent(factory, ns, "", "sequencer_inconsistent_l1_origin", "events when the sequencer selects an inconsistent L1 origin"),
 		SequencerResets:               metrics.NewEvent(factory, ns, "", "sequencer_resets", "sequencer resets"),
 
+		SequencerHandoffScheduled: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sequencer_handoff_scheduled",
+			Help:      "1 if a sequencer stop or start is scheduled, 0 otherwise",
+		}, []string{"kind"}),
+		SequencerHandoffs: metrics.NewEventVec(factory, ns, "", "sequencer_handoffs", "scheduled sequencer stops and starts that happened", []string{"kind"}),
+
 		UnsafePayloadsBufferLen: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "unsafe_payloads_buffer_len",
@@ -303,6 +321,12 @@ func NewMetrics(procName string) *Metrics {
 			Help:      "Number of compressed bytes added to the channel",
 		}),
 
+		OutputChecks: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_checks_total",
+			Help:      "Count of L1 output proposals checked against the local safe chain, by proposal source and result",
+		}, []string{"source", "result"}),
+
 		P2PReqDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -334,6 +358,14 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"p2p_role", // "client" or "server"
 		}),
+		P2PPayloadsByRange: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "payloads_by_range_total",
+			Help:      "Number of payloads transferred with payloads by range requests",
+		}, []string{
+			"p2p_role", // "client" or "server"
+		}),
 		PayloadsQuarantineTotal: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -494,6 +526,22 @@ func (m *Metrics) RecordSequencerReset() {
 	m.SequencerResets.Record()
 }
 
+func (m *Metrics) SetSequencerHandoffScheduled(kind string, scheduled bool) {
+	var val float64
+	if scheduled {
+		val = 1
+	}
+	m.SequencerHandoffScheduled.WithLabelValues(kind).Set(val)
+}
+
+func (m *Metrics) RecordSequencerHandoff(kind string) {
+	m.SequencerHandoffs.Record(kind)
+}
+
+func (m *Metrics) RecordOutputCheck(source string, result string) {
+	m.OutputChecks.WithLabelValues(source, result).Inc()
+}
+
 func (m *Metrics) RecordGossipEvent(evType int32) {
 	m.GossipEventsTotal.WithLabelValues(pb.TraceEvent_Type_name[evType]).Inc()
 }
@@ -580,6 +628,23 @@ func (m *Metrics) ServerPayloadByNumberEvent(num uint64, resultCode byte, durati
 	m.P2PPayloadByNumber.WithLabelValues("server").Set(float64(num))
 }
 
+func (m *Metrics) ClientPayloadsByRangeEvent(start uint64, received uint64, resultCode byte, duration time.Duration) {
+	if resultCode > 4 { // summarize all high codes to reduce metrics overhead
+		resultCode = 5
+	}
+	code := strconv.FormatUint(uint64(resultCode), 10)
+	m.P2PReqTotal.WithLabelValues("client", "payloads_by_range", code).Inc()
+	m.P2PReqDurationSeconds.WithLabelValues("client", "payloads_by_range", code).Observe(float64(duration) / float64(time.Second))
+	m.P2PPayloadsByRange.WithLabelValues("client").Add(float64(received))
+}
+
+func (m *Metrics) ServerPayloadsByRangeEvent(start uint64, served uint64, resultCode byte, duration time.Duration) {
+	code := strconv.FormatUint(uint64(resultCode), 10)
+	m.P2PReqTotal.WithLabelValues("server", "payloads_by_range", code).Inc()
+	m.P2PReqDurationSeconds.WithLabelValues("server", "payloads_by_range", code).Observe(float64(duration) / float64(time.Second))
+	m.P2PPayloadsByRange.WithLabelValues("server").Add(float64(served))
+}
+
 func (m *Metrics) PayloadsQuarantineSize(n int) {
 	m.PayloadsQuarantineTotal.Set(float64(n))
 }
@@ -692,6 +757,15 @@ func (n *noopMetricer) RecordSequencerInconsistentL1Origin(from eth.BlockID, to
 func (n *noopMetricer) RecordSequencerReset() {
 }
 
+func (n *noopMetricer) SetSequencerHandoffScheduled(kind string, scheduled bool) {
+}
+
+func (n *noopMetricer) RecordSequencerHandoff(kind string) {
+}
+
+func (n *noopMetricer) RecordOutputCheck(source string, result string) {
+}
+
 func (n *noopMetricer) RecordGossipEvent(evType int32) {
 }
 
@@ -729,6 +803,12 @@ func (n *noopMetricer) ClientPayloadByNumberEvent(num uint64, resultCode byte, d
 func (n *noopMetricer) ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration) {
 }
 
+func (n *noopMetricer) ClientPayloadsByRangeEvent(start uint64, received uint64, resultCode byte, duration time.Duration) {
+}
+
+func (n *noopMetricer) ServerPayloadsByRangeEvent(start uint64, served uint64, resultCode byte, duration time.Duration) {
+}
+
 func (n *noopMetricer) PayloadsQuarantineSize(int) {
 }
 
@@ -8,9 +8,11 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/apis"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -32,27 +34,42 @@ type driverClient interface {
 	StartSequencer(ctx context.Context, blockHash common.Hash) error
 	StopSequencer(context.Context) (common.Hash, error)
 	SequencerActive(context.Context) (bool, error)
+	StopSequencerAt(ctx context.Context, target eth.SequencerStopTarget) (common.Hash, error)
+	StartSequencerAt(ctx context.Context, blockHash common.Hash) error
+	CancelSequencerHandoff(ctx context.Context) error
 	OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 	OverrideLeader(ctx context.Context) error
 	ConductorEnabled(ctx context.Context) (bool, error)
 	SetRecoverMode(ctx context.Context, mode bool) error
+	SubscribeHeads(topic status.HeadTopic) (*status.HeadSubscription, error)
+}
+
+type eventTracing interface {
+	Start(name string, maxEvents uint64) error
+	Stop() (*eth.EventTraceResult, error)
 }
 
 type SafeDBReader interface {
 	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1 eth.BlockID, l2 eth.BlockID, err error)
+	SafeHeadsInRange(ctx context.Context, start uint64, end uint64) ([]eth.SafeHeadResponse, error)
+	L1BlockAtSafeHead(ctx context.Context, l2BlockNum uint64) (l1 eth.BlockID, l2 eth.BlockID, err error)
+	DerivationInfoAtBlock(ctx context.Context, l2BlockNum uint64) (eth.DerivationInfo, error)
 }
 
 type adminAPI struct {
 	*rpc.CommonAdminAPI
-	dr driverClient
+	dr      driverClient
+	tracing eventTracing
 }
 
 var _ apis.OpnodeAdminServer = (*adminAPI)(nil)
 
-func NewAdminAPI(dr driverClient, log log.Logger) *adminAPI {
+// NewAdminAPI creates the admin API. The tracing may be nil, if event tracing is not supported.
+func NewAdminAPI(dr driverClient, tracing eventTracing, log log.Logger) *adminAPI {
 	return &adminAPI{
 		CommonAdminAPI: rpc.NewCommonAdminAPI(log),
 		dr:             dr,
+		tracing:        tracing,
 	}
 }
 
@@ -72,6 +89,23 @@ func (n *adminAPI) SequencerActive(ctx context.Context) (bool, error) {
 	return n.dr.SequencerActive(ctx)
 }
 
+// StopSequencerAt schedules the sequencer to stop right after sealing the target block,
+// and waits for it to stop. It returns the hash of the last sealed block, to start the next sequencer at.
+// If the call times out, the stop remains scheduled, and the call can be repeated to get the hash.
+func (n *adminAPI) StopSequencerAt(ctx context.Context, target eth.SequencerStopTarget) (common.Hash, error) {
+	return n.dr.StopSequencerAt(ctx, target)
+}
+
+// StartSequencerAt schedules the sequencer to start once the unsafe head is the given block.
+func (n *adminAPI) StartSequencerAt(ctx context.Context, blockHash common.Hash) error {
+	return n.dr.StartSequencerAt(ctx, blockHash)
+}
+
+// CancelSequencerHandoff removes any scheduled stop or start of the sequencer.
+func (n *adminAPI) CancelSequencerHandoff(ctx context.Context) error {
+	return n.dr.CancelSequencerHandoff(ctx)
+}
+
 // PostUnsafePayload is a special API that allows posting an unsafe payload to the L2 derivation pipeline.
 // It should only be used by op-conductor for sequencer failover scenarios.
 func (n *adminAPI) PostUnsafePayload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
@@ -98,6 +132,24 @@ func (n *adminAPI) SetRecoverMode(ctx context.Context, mode bool) error {
 	return n.dr.SetRecoverMode(ctx, mode)
 }
 
+// StartEventTrace starts capturing the event system into a new Chrome trace file with the given name,
+// in the directory configured with --debug.event-trace-dir. The name must not contain path separators.
+// The capture is bounded to maxEvents trace events, or a default bound if 0.
+func (n *adminAPI) StartEventTrace(_ context.Context, name string, maxEvents uint64) error {
+	if n.tracing == nil {
+		return errors.New("event tracing is not supported")
+	}
+	return n.tracing.Start(name, maxEvents)
+}
+
+// StopEventTrace stops the active event trace capture, and completes its trace file.
+func (n *adminAPI) StopEventTrace(_ context.Context) (*eth.EventTraceResult, error) {
+	if n.tracing == nil {
+		return nil, errors.New("event tracing is not supported")
+	}
+	return n.tracing.Stop()
+}
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
@@ -118,6 +170,46 @@ func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, sa
 	}
 }
 
+// The head subscriptions are served via optimism_subscribe, with the topic as subscription name,
+// e.g. optimism_subscribe("unsafe"). Each notification carries the L2 block reference of the changed head.
+// Subscriptions are only available via websocket.
+
+func (n *nodeAPI) Unsafe(ctx context.Context) (*gethrpc.Subscription, error) {
+	return n.subscribeHead(ctx, status.UnsafeHeadTopic)
+}
+
+func (n *nodeAPI) Safe(ctx context.Context) (*gethrpc.Subscription, error) {
+	return n.subscribeHead(ctx, status.SafeHeadTopic)
+}
+
+func (n *nodeAPI) CrossSafe(ctx context.Context) (*gethrpc.Subscription, error) {
+	return n.subscribeHead(ctx, status.CrossSafeHeadTopic)
+}
+
+func (n *nodeAPI) Finalized(ctx context.Context) (*gethrpc.Subscription, error) {
+	return n.subscribeHead(ctx, status.FinalizedHeadTopic)
+}
+
+func (n *nodeAPI) Reset(ctx context.Context) (*gethrpc.Subscription, error) {
+	return n.subscribeHead(ctx, status.PipelineResetTopic)
+}
+
+func (n *nodeAPI) L1Origin(ctx context.Context) (*gethrpc.Subscription, error) {
+	return n.subscribeHead(ctx, status.L1OriginTopic)
+}
+
+// subscribeHead forwards the changes of the given head topic to an RPC subscription.
+// The subscription is closed if the RPC subscriber lags behind and its queue of changes fills up.
+func (n *nodeAPI) subscribeHead(ctx context.Context, topic status.HeadTopic) (*gethrpc.Subscription, error) {
+	return rpc.SubscribeRPCSource(ctx, n.log.New("topic", topic), func() (rpc.Source[eth.L2BlockRef], error) {
+		sub, err := n.dr.SubscribeHeads(topic)
+		if err != nil {
+			return nil, err
+		}
+		return sub, nil
+	})
+}
+
 func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*eth.OutputResponse, error) {
 	ref, status, err := n.dr.BlockRefWithStatus(ctx, uint64(number))
 	if err != nil {
@@ -153,6 +245,50 @@ func (n *nodeAPI) SafeHeadAtL1Block(ctx context.Context, number hexutil.Uint64)
 	}, nil
 }
 
+// maxSafeHeadsRange is the maximum number of L1 blocks that can be queried with SafeHeadsInRange.
+const maxSafeHeadsRange = 100_000
+
+func (n *nodeAPI) SafeHeadsInRange(ctx context.Context, start hexutil.Uint64, end hexutil.Uint64) ([]eth.SafeHeadResponse, error) {
+	if end < start {
+		return nil, fmt.Errorf("invalid range: end %s before start %s", end, start)
+	}
+	if end-start >= maxSafeHeadsRange {
+		return nil, fmt.Errorf("range of %d L1 blocks exceeds limit of %d", end-start+1, maxSafeHeadsRange)
+	}
+	safeHeads, err := n.safeDB.SafeHeadsInRange(ctx, uint64(start), uint64(end))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get safe heads in l1 range [%s, %s]: %w", start, end, err)
+	}
+	if safeHeads == nil {
+		// Return an empty list instead of null
+		safeHeads = []eth.SafeHeadResponse{}
+	}
+	return safeHeads, nil
+}
+
+func (n *nodeAPI) L1BlockAtSafeHead(ctx context.Context, number hexutil.Uint64) (*eth.SafeHeadResponse, error) {
+	l1Block, safeHead, err := n.safeDB.L1BlockAtSafeHead(ctx, uint64(number))
+	if errors.Is(err, safedb.ErrNotFound) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get l1 block at safe head %s: %w", number, err)
+	}
+	return &eth.SafeHeadResponse{
+		L1Block:  l1Block,
+		SafeHead: safeHead,
+	}, nil
+}
+
+func (n *nodeAPI) DerivationInfoAtBlock(ctx context.Context, number hexutil.Uint64) (*eth.DerivationInfo, error) {
+	info, err := n.safeDB.DerivationInfoAtBlock(ctx, uint64(number))
+	if errors.Is(err, safedb.ErrNotFound) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get derivation info of l2 block %s: %w", number, err)
+	}
+	return &info, nil
+}
+
 func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	return n.dr.SyncStatus(ctx)
 }
@@ -34,7 +34,9 @@ type L1EndpointSetup interface {
 }
 
 type L1BeaconEndpointSetup interface {
-	Setup(ctx context.Context, log log.Logger) (cl apis.BeaconClient, fb []apis.BlobSideCarsClient, err error)
+	// Setup the L1 Beacon API client and the fallback clients for blob sidecars retrieval.
+	// The requests of all clients are recorded with rec, if not nil.
+	Setup(ctx context.Context, log log.Logger, rec client.CallRecorder) (cl apis.BeaconClient, fb []apis.BlobSideCarsClient, err error)
 	// ShouldIgnoreBeaconCheck returns true if the Beacon-node version check should not halt startup.
 	ShouldIgnoreBeaconCheck() bool
 	ShouldFetchAllSidecars() bool
@@ -105,6 +107,14 @@ func (p *PreparedL2Endpoints) Setup(ctx context.Context, log log.Logger, rollupC
 type L1EndpointConfig struct {
 	L1NodeAddr string // Address of L1 User JSON-RPC endpoint to use (eth namespace required)
 
+	// L1ExtraAddrs are addresses of additional L1 User JSON-RPC endpoints, used together with L1NodeAddr.
+	L1ExtraAddrs []string
+	// L1MultiMode specifies how the endpoints are used, if there are extra endpoints.
+	L1MultiMode client.MultiMode
+	// L1Quorum is the number of endpoints that must agree on block headers in quorum mode.
+	// A majority of the endpoints if 0. See client.MultiConfig for its constraints.
+	L1Quorum int
+
 	// L1TrustRPC: if we trust the L1 RPC we do not have to validate L1 response contents like headers
 	// against block hashes, or cached transaction sender addresses.
 	// Thus we can sync faster at the risk of the source RPC being wrong.
@@ -151,9 +161,21 @@ func (cfg *L1EndpointConfig) Check() error {
 	if cfg.CacheSize > 1_000_000 {
 		return fmt.Errorf("cache size is dangerously large: %d", cfg.CacheSize)
 	}
+	if len(cfg.L1ExtraAddrs) > 0 {
+		if err := cfg.multiConfig().Check(1 + len(cfg.L1ExtraAddrs)); err != nil {
+			return err
+		}
+		if cfg.L1MultiMode == client.MultiQuorum && cfg.L1TrustRPC {
+			return errors.New("L1 RPC quorum requires the L1 RPC to not be trusted, to verify receipts and transactions against the headers")
+		}
+	}
 	return nil
 }
 
+func (cfg *L1EndpointConfig) multiConfig() client.MultiConfig {
+	return client.NewMultiConfig(cfg.L1MultiMode, cfg.L1Quorum, 1+len(cfg.L1ExtraAddrs))
+}
+
 func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, metrics opmetrics.RPCMetricer) (client.RPC, *sources.L1ClientConfig, error) {
 	opts := []client.RPCOption{
 		client.WithHttpPollInterval(cfg.HttpPollInterval),
@@ -164,11 +186,25 @@ func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 		opts = append(opts, client.WithRateLimit(cfg.RateLimit, cfg.BatchSize))
 	}
 
-	l1RPC, err := client.NewRPC(ctx, log, cfg.L1NodeAddr, opts...)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to dial L1 address (%s): %w", cfg.L1NodeAddr, err)
+	var l1RPC client.RPC
+	var err error
+	if len(cfg.L1ExtraAddrs) > 0 {
+		addrs := append([]string{cfg.L1NodeAddr}, cfg.L1ExtraAddrs...)
+		l1RPC, err = client.NewMultiRPC(ctx, log, addrs, cfg.multiConfig(), opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to dial L1 addresses: %w", err)
+		}
+	} else {
+		l1RPC, err = client.NewRPC(ctx, log, cfg.L1NodeAddr, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to dial L1 address (%s): %w", cfg.L1NodeAddr, err)
+		}
 	}
 
+	return l1RPC, cfg.clientConfig(rollupCfg), nil
+}
+
+func (cfg *L1EndpointConfig) clientConfig(rollupCfg *rollup.Config) *sources.L1ClientConfig {
 	var l1Cfg *sources.L1ClientConfig
 	if cfg.CacheSize > 0 {
 		l1Cfg = sources.L1ClientSimpleConfig(cfg.L1TrustRPC, cfg.L1RPCKind, int(cfg.CacheSize))
@@ -177,7 +213,7 @@ func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 	}
 	l1Cfg.MaxRequestsPerBatch = cfg.BatchSize
 	l1Cfg.MaxConcurrentRequests = cfg.MaxConcurrency
-	return l1RPC, l1Cfg, nil
+	return l1Cfg
 }
 
 // PreparedL1Endpoint enables testing with an in-process pre-setup RPC connection to L1
@@ -211,7 +247,7 @@ type L1BeaconEndpointConfig struct {
 
 var _ L1BeaconEndpointSetup = (*L1BeaconEndpointConfig)(nil)
 
-func (cfg *L1BeaconEndpointConfig) Setup(ctx context.Context, log log.Logger) (cl apis.BeaconClient, fb []apis.BlobSideCarsClient, err error) {
+func (cfg *L1BeaconEndpointConfig) Setup(ctx context.Context, log log.Logger, rec client.CallRecorder) (cl apis.BeaconClient, fb []apis.BlobSideCarsClient, err error) {
 	var opts []client.BasicHTTPClientOption
 	if cfg.BeaconHeader != "" {
 		hdr, err := parseHTTPHeader(cfg.BeaconHeader)
@@ -221,12 +257,18 @@ func (cfg *L1BeaconEndpointConfig) Setup(ctx context.Context, log log.Logger) (c
 		opts = append(opts, client.WithHeader(hdr))
 	}
 
-	for _, addr := range cfg.BeaconFallbackAddrs {
-		b := client.NewBasicHTTPClient(addr, log)
+	for i, addr := range cfg.BeaconFallbackAddrs {
+		var b client.HTTP = client.NewBasicHTTPClient(addr, log)
+		if rec != nil {
+			b = client.NewRecordingHTTP(b, RecordSourceBeaconFallback(i), rec)
+		}
 		fb = append(fb, sources.NewBeaconHTTPClient(b))
 	}
 
-	a := client.NewBasicHTTPClient(cfg.BeaconAddr, log, opts...)
+	var a client.HTTP = client.NewBasicHTTPClient(cfg.BeaconAddr, log, opts...)
+	if rec != nil {
+		a = client.NewRecordingHTTP(a, RecordSourceBeacon, rec)
+	}
 	return sources.NewBeaconHTTPClient(a), fb, nil
 }
 
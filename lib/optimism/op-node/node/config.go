@@ -11,6 +11,7 @@ import (
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/flags"
+	"github.com/ethereum-optimism/optimism/op-node/node/outputcheck"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
@@ -50,6 +51,23 @@ type Config struct {
 
 	// Path to store safe head database. Disabled when set to empty string
 	SafeDBPath string
+	// Number of L1 blocks to keep safe head database entries for. All entries are kept if 0.
+	SafeDBRetention uint64
+
+	// Path to record events and external calls to, for replay with Replay. Disabled when set to empty string
+	EventRecordPath string
+
+	// Directory to write event traces to, started over the admin RPC. Event tracing is disabled when set to empty string
+	EventTraceDir string
+
+	// Path of the snapshot log to append snapshots of the L1 and L2 heads to. Disabled when set to empty string
+	SnapshotLogPath string
+
+	// Directory to persist signed unsafe payloads in, to recover the unsafe payloads queue after a restart.
+	// Disabled when set to empty string
+	UnsafePayloadsPath string
+	// Maximum total size in bytes of the persisted unsafe payloads.
+	UnsafePayloadsMaxSize uint64
 
 	// RuntimeConfigReloadInterval defines the interval between runtime config reloads.
 	// Disabled if <= 0.
@@ -57,6 +75,16 @@ type Config struct {
 	// but if log-events are not coming in (e.g. not syncing blocks) then the reload ensures the config stays accurate.
 	RuntimeConfigReloadInterval time.Duration
 
+	// P2PSignersFile is the path of a JSON file with additional unsafe block signers,
+	// to accept gossiped blocks from multiple signers during a key rotation. Disabled when set to empty string
+	P2PSignersFile string
+
+	// NetworksDir is the directory of custom networks to watch for changes. Disabled when set to empty string
+	NetworksDir string
+
+	// OutputCheck configures the checks of the output proposals on L1 against the local safe chain.
+	OutputCheck outputcheck.Config
+
 	// Optional
 	Tracer Tracer
 
@@ -179,6 +207,9 @@ func (cfg *Config) Check() error {
 	if !(cfg.RollupHalt == "" || cfg.RollupHalt == "major" || cfg.RollupHalt == "minor" || cfg.RollupHalt == "patch") {
 		return fmt.Errorf("invalid rollup halting option: %q", cfg.RollupHalt)
 	}
+	if err := cfg.Driver.SequencerOriginPolicy.Check(); err != nil {
+		return fmt.Errorf("sequencer origin policy config error: %w", err)
+	}
 	if cfg.ConductorEnabled {
 		if state, _ := cfg.ConfigPersistence.SequencerState(); state != StateUnset {
 			return fmt.Errorf("config persistence must be disabled when conductor is enabled")
@@ -190,6 +221,9 @@ func (cfg *Config) Check() error {
 	if err := cfg.AltDA.Check(); err != nil {
 		return fmt.Errorf("altDA config error: %w", err)
 	}
+	if err := cfg.OutputCheck.Check(); err != nil {
+		return fmt.Errorf("output check config error: %w", err)
+	}
 	if cfg.AltDA.Enabled {
 		log.Warn("Alt-DA Mode is a Beta feature of the MIT licensed OP Stack.  While it has received initial review from core contributors, it is still undergoing testing, and may have bugs or other issues.")
 	}
@@ -0,0 +1,115 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// defaultEventTraceMaxEvents bounds an event trace capture if no bound is specified.
+const defaultEventTraceMaxEvents = 1_000_000
+
+var (
+	ErrEventTraceActive   = errors.New("event trace capture already active")
+	ErrNoEventTraceActive = errors.New("no event trace capture active")
+	ErrEventTraceDisabled = errors.New("event tracing is disabled, no event trace directory configured")
+)
+
+// eventTraceCapture manages a capture of the event system into a Chrome trace file.
+// At most one capture is active at a time.
+type eventTraceCapture struct {
+	mu sync.Mutex
+
+	log log.Logger
+	sys event.System
+	dir string
+
+	tracer *event.ChromeTracer
+	file   *os.File
+}
+
+// newEventTraceCapture creates a capture that writes trace files into dir.
+// Captures can't be started if dir is empty.
+func newEventTraceCapture(log log.Logger, sys event.System, dir string) *eventTraceCapture {
+	return &eventTraceCapture{log: log, sys: sys, dir: dir}
+}
+
+// Start starts capturing up to maxEvents trace events into a new file with the given name,
+// in the configured trace directory. The name must be a plain file name, without any path
+// components. Existing files are not overwritten.
+func (c *eventTraceCapture) Start(name string, maxEvents uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.dir == "" {
+		return ErrEventTraceDisabled
+	}
+	if c.tracer != nil {
+		return ErrEventTraceActive
+	}
+	if err := checkEventTraceName(name); err != nil {
+		return err
+	}
+	path := filepath.Join(c.dir, name)
+	if maxEvents == 0 {
+		maxEvents = defaultEventTraceMaxEvents
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create event trace file: %w", err)
+	}
+	c.file = f
+	c.tracer = event.NewChromeTracer(f, maxEvents)
+	c.sys.AddTracer(c.tracer)
+	c.log.Info("Started event trace capture", "path", path, "max_events", maxEvents)
+	return nil
+}
+
+// checkEventTraceName checks that name is a plain file name, that can't escape the trace directory.
+func checkEventTraceName(name string) error {
+	if name == "" || name == "." || name == ".." {
+		return fmt.Errorf("invalid event trace file name %q", name)
+	}
+	if strings.ContainsAny(name, `/\`) || filepath.Base(name) != name {
+		return fmt.Errorf("event trace file name %q must not contain path separators", name)
+	}
+	return nil
+}
+
+// Stop stops the active capture, and completes its trace file.
+func (c *eventTraceCapture) Stop() (*eth.EventTraceResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tracer == nil {
+		return nil, ErrNoEventTraceActive
+	}
+	c.sys.RemoveTracer(c.tracer)
+	tracer, f := c.tracer, c.file
+	c.tracer, c.file = nil, nil
+
+	events, dropped := tracer.Stats()
+	result := &eth.EventTraceResult{Path: f.Name(), Events: events, Dropped: dropped}
+	if err := tracer.Close(); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to write event trace: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close event trace file: %w", err)
+	}
+	c.log.Info("Stopped event trace capture", "path", result.Path, "events", events, "dropped", dropped)
+	return result, nil
+}
+
+// Active returns whether a capture is active.
+func (c *eventTraceCapture) Active() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tracer != nil
+}
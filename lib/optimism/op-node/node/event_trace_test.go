@@ -0,0 +1,88 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type traceTestEvent struct{}
+
+func (traceTestEvent) String() string { return "trace-test" }
+
+func TestEventTraceCapture(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	ex := event.NewGlobalSynchronous(context.Background())
+	sys := event.NewSystem(logger, ex)
+	t.Cleanup(sys.Stop)
+	em := sys.Register("test", event.DeriverFunc(func(ev event.Event) bool {
+		_, ok := ev.(traceTestEvent)
+		return ok
+	}), event.DefaultRegisterOpts())
+
+	dir := t.TempDir()
+	capture := newEventTraceCapture(logger, sys, dir)
+	_, err := capture.Stop()
+	require.ErrorIs(t, err, ErrNoEventTraceActive)
+
+	require.NoError(t, capture.Start("trace.json", 100))
+	require.ErrorIs(t, capture.Start("trace.json", 100), ErrEventTraceActive)
+	require.True(t, capture.Active())
+
+	em.Emit(traceTestEvent{})
+	require.NoError(t, ex.Drain())
+
+	result, err := capture.Stop()
+	require.NoError(t, err)
+	require.False(t, capture.Active())
+	path := filepath.Join(dir, "trace.json")
+	require.Equal(t, path, result.Path)
+	require.NotZero(t, result.Events)
+	require.Zero(t, result.Dropped)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var trace []map[string]any
+	require.NoError(t, json.Unmarshal(data, &trace))
+	require.Len(t, trace, int(result.Events))
+
+	// existing files are not overwritten
+	require.Error(t, capture.Start("trace.json", 100))
+	require.False(t, capture.Active())
+}
+
+func TestEventTraceCaptureFileName(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	sys := event.NewSystem(logger, event.NewGlobalSynchronous(context.Background()))
+	t.Cleanup(sys.Stop)
+
+	disabled := newEventTraceCapture(logger, sys, "")
+	require.ErrorIs(t, disabled.Start("trace.json", 100), ErrEventTraceDisabled)
+	require.False(t, disabled.Active())
+
+	dir := t.TempDir()
+	capture := newEventTraceCapture(logger, sys, dir)
+	for _, name := range []string{
+		"",
+		".",
+		"..",
+		"../trace.json",
+		"sub/trace.json",
+		`sub\trace.json`,
+		filepath.Join(t.TempDir(), "trace.json"),
+	} {
+		require.Errorf(t, capture.Start(name, 100), "name %q", name)
+		require.False(t, capture.Active())
+	}
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"reflect"
 	gosync "sync"
 	"sync/atomic"
 	"time"
@@ -13,21 +15,26 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	gethevent "github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/outputcheck"
 	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/clsync"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/interop"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/interop/managed"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -35,7 +42,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 )
 
 var ErrAlreadyClosed = errors.New("node is already closed")
@@ -58,8 +67,16 @@ type OpNode struct {
 	l1FinalizedSub ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 
 	eventSys   event.System
+	eventTrace *eventTraceCapture
 	eventDrain event.Drainer
 
+	// records events and external calls for replay, if enabled
+	eventRecorder   *event.Recorder
+	eventRecordFile *os.File
+	snapshotLog     *status.SnapshotLog
+	snapshotLogFile *os.File
+
+	l1RPC     client.RPC            // L1 RPC client, to call L1 contracts with
 	l1Source  *sources.L1Client     // L1 Client to fetch data from
 	l2Driver  *driver.Driver        // L2 Engine to Sync
 	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
@@ -72,6 +89,10 @@ type OpNode struct {
 
 	safeDB closableSafeDB
 
+	customChains *chaincfg.CustomChainsWatcher // keeps the custom networks up to date, if enabled
+
+	outputChecker *outputcheck.Checker // checks L1 output proposals against the safe chain, if enabled
+
 	rollupHalt string // when to halt the rollup, disabled if empty
 
 	pprofService *oppprof.Service
@@ -136,6 +157,12 @@ func (n *OpNode) init(ctx context.Context, cfg *Config) error {
 		return fmt.Errorf("failed to init the trace: %w", err)
 	}
 	n.initEventSystem()
+	if err := n.initCustomChains(cfg); err != nil {
+		return fmt.Errorf("failed to init the custom networks: %w", err)
+	}
+	if err := n.initEventRecorder(cfg); err != nil {
+		return fmt.Errorf("failed to init the event recorder: %w", err)
+	}
 	if err := n.initL1(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init L1: %w", err)
 	}
@@ -145,6 +172,12 @@ func (n *OpNode) init(ctx context.Context, cfg *Config) error {
 	if err := n.initL2(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init L2: %w", err)
 	}
+	if err := n.initSnapshotLog(cfg); err != nil { // depends on L2, to read the sync status of the driver
+		return fmt.Errorf("failed to init the snapshot log: %w", err)
+	}
+	if err := n.initOutputCheck(cfg); err != nil { // depends on L1 and L2, to compare the proposals with the safe chain
+		return fmt.Errorf("failed to init the output check: %w", err)
+	}
 	if err := n.initRuntimeConfig(ctx, cfg); err != nil { // depends on L2, to signal initial runtime values to
 		return fmt.Errorf("failed to init the runtime config: %w", err)
 	}
@@ -177,6 +210,90 @@ func (n *OpNode) initEventSystem() {
 	sys.Register("node", event.DeriverFunc(n.onEvent), event.DefaultRegisterOpts())
 	n.eventSys = sys
 	n.eventDrain = executor
+	n.eventTrace = newEventTraceCapture(n.log, sys, n.cfg.EventTraceDir)
+}
+
+func (n *OpNode) initCustomChains(cfg *Config) error {
+	if cfg.NetworksDir == "" {
+		return nil
+	}
+	chainID := eth.ChainIDFromBig(cfg.Rollup.L2ChainID)
+	initial := chaincfg.CustomChainByID(chainID)
+	watcher, err := chaincfg.WatchCustomChains(n.log, cfg.NetworksDir, func(chains *chaincfg.CustomChains) {
+		if initial == nil {
+			return
+		}
+		// The rollup config of a running node can not change, changes only apply after a restart.
+		if chain := chains.ByChainID(chainID); chain == nil || !reflect.DeepEqual(chain.Rollup, initial.Rollup) {
+			n.log.Warn("Rollup config of the custom network changed, restart the node to apply it", "network", initial.Name)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	n.customChains = watcher
+	return nil
+}
+
+func (n *OpNode) initEventRecorder(cfg *Config) error {
+	if cfg.EventRecordPath == "" {
+		return nil
+	}
+	f, err := os.OpenFile(cfg.EventRecordPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create event recording file: %w", err)
+	}
+	n.eventRecordFile = f
+	n.eventRecorder = event.NewRecorder(f)
+	n.eventSys.AddTracer(n.eventRecorder)
+	n.log.Warn("Recording events and external calls, the recording file grows without bound", "path", cfg.EventRecordPath)
+	return nil
+}
+
+func (n *OpNode) initSnapshotLog(cfg *Config) error {
+	if cfg.SnapshotLogPath == "" {
+		return nil
+	}
+	f, err := os.OpenFile(cfg.SnapshotLogPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot log file: %w", err)
+	}
+	n.snapshotLogFile = f
+	n.snapshotLog = status.NewSnapshotLog(f, func() *eth.SyncStatus {
+		st, _ := n.l2Driver.SyncStatus(context.Background())
+		return st
+	})
+	n.eventSys.AddTracer(n.snapshotLog)
+	n.log.Warn("Writing snapshots of the L1 and L2 heads, the snapshot log file grows without bound", "path", cfg.SnapshotLogPath)
+	return nil
+}
+
+func (n *OpNode) initOutputCheck(cfg *Config) error {
+	if !cfg.OutputCheck.Enabled() {
+		return nil
+	}
+	caller := batching.NewMultiCaller(n.l1RPC, batching.DefaultBatchSize)
+	var proposalSources []outputcheck.ProposalSource
+	if cfg.OutputCheck.DisputeGameFactory != (common.Address{}) {
+		proposalSources = append(proposalSources,
+			outputcheck.NewDisputeGameFactory(caller, cfg.OutputCheck.DisputeGameFactory, cfg.OutputCheck.GameTypes))
+	}
+	if cfg.OutputCheck.L2OutputOracle != (common.Address{}) {
+		oracle, err := outputcheck.NewL2OutputOracle(caller, cfg.OutputCheck.L2OutputOracle)
+		if err != nil {
+			return err
+		}
+		proposalSources = append(proposalSources, oracle)
+	}
+	n.outputChecker = outputcheck.NewChecker(n.log.New("module", "outputcheck"), n.metrics, cfg.OutputCheck, n.l2Source,
+		func() eth.L2BlockRef {
+			st, err := n.l2Driver.SyncStatus(context.Background())
+			if err != nil {
+				return eth.L2BlockRef{}
+			}
+			return st.SafeL2
+		}, proposalSources...)
+	return nil
 }
 
 func (n *OpNode) initTracer(ctx context.Context, cfg *Config) error {
@@ -193,7 +310,11 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to get L1 RPC client: %w", err)
 	}
+	if n.eventRecorder != nil {
+		l1RPC = client.NewRecordingClient(l1RPC, RecordSourceL1, n.eventRecorder)
+	}
 
+	n.l1RPC = l1RPC
 	n.l1Source, err = sources.NewL1Client(l1RPC, n.log, n.metrics.L1SourceCache, l1Cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create L1 source: %w", err)
@@ -229,7 +350,7 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 
 func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	// attempt to load runtime config, repeat N times
-	n.runCfg = NewRuntimeConfig(n.log, n.l1Source, &cfg.Rollup)
+	n.runCfg = NewRuntimeConfig(n.log, n.l1Source, &cfg.Rollup, cfg.P2PSignersFile)
 
 	confDepth := cfg.Driver.VerifierConfDepth
 	reload := func(ctx context.Context) (eth.L1BlockRef, error) {
@@ -333,7 +454,11 @@ func (n *OpNode) initL1BeaconAPI(ctx context.Context, cfg *Config) error {
 
 	// We always initialize a client. We will get an error on requests if the client does not work.
 	// This way the op-node can continue non-L1 functionality when the user chooses to ignore the Beacon API requirement.
-	beaconClient, fallbacks, err := cfg.Beacon.Setup(ctx, n.log)
+	var rec client.CallRecorder
+	if n.eventRecorder != nil {
+		rec = n.eventRecorder
+	}
+	beaconClient, fallbacks, err := cfg.Beacon.Setup(ctx, n.log, rec)
 	if err != nil {
 		return fmt.Errorf("failed to setup L1 Beacon API client: %w", err)
 	}
@@ -388,6 +513,9 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to setup L2 execution-engine RPC client: %w", err)
 	}
+	if n.eventRecorder != nil {
+		rpcClient = client.NewRecordingClient(rpcClient, RecordSourceEngine, n.eventRecorder)
+	}
 
 	n.l2Source, err = sources.NewEngineClient(rpcClient, n.log, n.metrics.L2SourceCache, rpcCfg)
 	if err != nil {
@@ -423,8 +551,8 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config) error {
 	}
 	altDA := altda.NewAltDA(n.log, cfg.AltDA, rpCfg, n.metrics.AltDAMetrics)
 	if cfg.SafeDBPath != "" {
-		n.log.Info("Safe head database enabled", "path", cfg.SafeDBPath)
-		safeDB, err := safedb.NewSafeDB(n.log, cfg.SafeDBPath)
+		n.log.Info("Safe head database enabled", "path", cfg.SafeDBPath, "retention", cfg.SafeDBRetention)
+		safeDB, err := safedb.NewSafeDBWithRetention(n.log, cfg.SafeDBPath, cfg.SafeDBRetention)
 		if err != nil {
 			return fmt.Errorf("failed to create safe head database at %v: %w", cfg.SafeDBPath, err)
 		}
@@ -437,8 +565,20 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config) error {
 		return fmt.Errorf("cfg.Rollup.ChainOpConfig is nil. Please see https://github.com/ethereum-optimism/optimism/releases/tag/op-node/v1.11.0: %w", err)
 	}
 
+	var payloadsStore clsync.PayloadsStore
+	if cfg.UnsafePayloadsPath != "" {
+		n.log.Info("Unsafe payloads persistence enabled", "path", cfg.UnsafePayloadsPath, "max_size", cfg.UnsafePayloadsMaxSize)
+		// the runtime config is loaded after the L2 init, the signer is only needed when the driver runs.
+		signers := func() []opsigner.AuthorizedSigner { return n.runCfg.P2PSequencerSigners() }
+		store, err := clsync.NewDiskPayloadsStore(n.log, &cfg.Rollup, cfg.UnsafePayloadsPath, cfg.UnsafePayloadsMaxSize, signers)
+		if err != nil {
+			return fmt.Errorf("failed to open unsafe payloads store at %v: %w", cfg.UnsafePayloadsPath, err)
+		}
+		payloadsStore = store
+	}
+
 	n.l2Driver = driver.NewDriver(n.eventSys, n.eventDrain, &cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source,
-		n.beacon, n, n, n.log, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, altDA, managedMode)
+		n.beacon, n, n, n.log, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, altDA, payloadsStore, managedMode)
 	return nil
 }
 
@@ -453,10 +593,14 @@ func (n *OpNode) initRPCServer(cfg *Config) error {
 		})
 		n.log.Info("P2P RPC enabled")
 	}
+	server.AddAPI(rpc.API{
+		Namespace: "optimism",
+		Service:   outputcheck.NewAPI(n.outputChecker),
+	})
 	if cfg.RPC.EnableAdmin {
 		server.AddAPI(rpc.API{
 			Namespace: "admin",
-			Service:   NewAdminAPI(n.l2Driver, n.log),
+			Service:   NewAdminAPI(n.l2Driver, n.eventTrace, n.log),
 		})
 		n.log.Info("Admin RPC enabled")
 	}
@@ -547,6 +691,9 @@ func (n *OpNode) Start(ctx context.Context) error {
 		n.log.Error("Could not start a rollup node", "err", err)
 		return err
 	}
+	if n.outputChecker != nil {
+		n.outputChecker.Start()
+	}
 	log.Info("Rollup node started")
 	return nil
 }
@@ -619,6 +766,18 @@ func (n *OpNode) PublishL2Payload(ctx context.Context, envelope *eth.ExecutionPa
 }
 
 func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) error {
+	return n.onUnsafeL2Payload(ctx, from, envelope, func(ctx context.Context) error {
+		return n.l2Driver.OnUnsafeL2Payload(ctx, envelope)
+	})
+}
+
+func (n *OpNode) OnSignedUnsafeL2Payload(ctx context.Context, from peer.ID, signed *opsigner.SignedExecutionPayloadEnvelope) error {
+	return n.onUnsafeL2Payload(ctx, from, signed.Envelope, func(ctx context.Context) error {
+		return n.l2Driver.OnSignedUnsafeL2Payload(ctx, signed)
+	})
+}
+
+func (n *OpNode) onUnsafeL2Payload(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope, notify func(ctx context.Context) error) error {
 	// ignore if it's from ourselves
 	if p2pNode := n.getP2PNodeIfEnabled(); p2pNode != nil && from == p2pNode.Host().ID() {
 		return nil
@@ -633,7 +792,7 @@ func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, envelope *
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 
-	if err := n.l2Driver.OnUnsafeL2Payload(ctx, envelope); err != nil {
+	if err := notify(ctx); err != nil {
 		n.log.Warn("failed to notify engine driver of new L2 payload", "err", err, "id", envelope.ExecutionPayload.ID())
 	}
 
@@ -684,6 +843,10 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		}
 	}
 
+	if n.outputChecker != nil {
+		n.outputChecker.Stop()
+	}
+
 	// Stop sequencer and report last hash. l2Driver can be nil if we're cleaning up a failed init.
 	if n.l2Driver != nil {
 		latestHead, err := n.l2Driver.StopSequencer(ctx)
@@ -745,10 +908,44 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		}
 	}
 
+	// complete the trace file of an active event trace capture
+	if n.eventTrace != nil && n.eventTrace.Active() {
+		if _, err := n.eventTrace.Stop(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to stop event trace capture: %w", err))
+		}
+	}
+
 	if n.eventSys != nil {
 		n.eventSys.Stop()
 	}
 
+	if n.eventRecorder != nil {
+		if err := n.eventRecorder.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to write event recording: %w", err))
+		}
+		if err := n.eventRecordFile.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close event recording file: %w", err))
+		}
+	}
+
+	if n.snapshotLog != nil {
+		if n.eventSys != nil {
+			n.eventSys.RemoveTracer(n.snapshotLog)
+		}
+		if err := n.snapshotLog.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to write snapshot log: %w", err))
+		}
+		if err := n.snapshotLogFile.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close snapshot log file: %w", err))
+		}
+	}
+
+	if n.customChains != nil {
+		if err := n.customChains.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to stop watching custom networks: %w", err))
+		}
+	}
+
 	if n.safeDB != nil {
 		if err := n.safeDB.Close(); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close safe head db: %w", err))
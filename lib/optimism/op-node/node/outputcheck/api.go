@@ -0,0 +1,22 @@
+package outputcheck
+
+import "context"
+
+// API serves the detected output divergences, in the optimism RPC namespace.
+type API struct {
+	checker *Checker
+}
+
+// NewAPI creates the API of the given checker, which is nil if the output checks are disabled.
+func NewAPI(checker *Checker) *API {
+	return &API{checker: checker}
+}
+
+// OutputDivergences returns the most recently detected proposals with a root claim
+// that does not match the output root of the local safe chain, oldest first.
+func (a *API) OutputDivergences(_ context.Context) ([]Divergence, error) {
+	if a.checker == nil {
+		return nil, ErrDisabled
+	}
+	return a.checker.Divergences(), nil
+}
@@ -0,0 +1,302 @@
+package outputcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+)
+
+const (
+	ResultMatch    = "match"
+	ResultMismatch = "mismatch"
+	ResultError    = "error"
+	// ResultUnavailable is the result of proposals that can not be checked,
+	// because the L2 state of the proposed block is no longer available.
+	ResultUnavailable = "unavailable"
+
+	// maxPending bounds the proposals that wait for their L2 block to become safe.
+	maxPending = 1000
+	// maxDivergences bounds the divergences that are kept, to serve over RPC.
+	maxDivergences = 100
+	// maxFetch bounds the proposals that are fetched from a source per poll.
+	maxFetch = 100
+	// maxAttempts bounds the attempts to check a proposal, if computing the output root fails.
+	maxAttempts = 10
+)
+
+// proposalBlock is the L1 block that proposals are read at. Proposals are only checked once finalized,
+// so a proposal that is reorged out of L1 is never reported as divergence.
+var proposalBlock = rpcblock.Finalized
+
+var ErrDisabled = errors.New("output checks are disabled")
+
+// Config configures the output checks. The checks are disabled if no contract address is set.
+type Config struct {
+	// DisputeGameFactory is the address of the DisputeGameFactory to check the dispute games of.
+	DisputeGameFactory common.Address
+	// GameTypes are the types of dispute games to check. Only game types that claim an output root can be checked.
+	GameTypes []uint32
+	// L2OutputOracle is the address of the legacy L2OutputOracle to check the output proposals of.
+	L2OutputOracle common.Address
+	// PollInterval is the interval to poll for new proposals at.
+	PollInterval time.Duration
+	// Backfill is the number of most recent proposals of each contract to check at startup.
+	Backfill uint64
+}
+
+func (c *Config) Enabled() bool {
+	return c.DisputeGameFactory != (common.Address{}) || c.L2OutputOracle != (common.Address{})
+}
+
+func (c *Config) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.PollInterval <= 0 {
+		return errors.New("output check poll interval must be positive")
+	}
+	if c.DisputeGameFactory != (common.Address{}) && len(c.GameTypes) == 0 {
+		return errors.New("no dispute game types to check")
+	}
+	return nil
+}
+
+// Divergence is a proposal of which the root claim does not match the output root of the local safe chain.
+type Divergence struct {
+	Proposal
+	// Expected is the output root of the local safe chain at the L2 block of the proposal.
+	Expected eth.Bytes32 `json:"expected"`
+	// L2Block is the local safe block that the output root was computed for.
+	L2Block eth.BlockID `json:"l2Block"`
+	// DetectedAt is the unix timestamp at which the divergence was detected.
+	DetectedAt uint64 `json:"detectedAt"`
+}
+
+type L2Source interface {
+	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+}
+
+type Metrics interface {
+	RecordOutputCheck(source string, result string)
+}
+
+// pendingProposal is a proposal that is yet to be checked.
+type pendingProposal struct {
+	Proposal
+	// attempts is the number of failed attempts to check the proposal.
+	attempts int
+}
+
+type sourceState struct {
+	ProposalSource
+	// next is the index of the next proposal to fetch
+	next        uint64
+	initialized bool
+}
+
+// Checker watches the L1 output proposals, and compares each root claim with the output root
+// of the local safe chain, once the proposed L2 block is safe.
+// Proposals of blocks that are already finalized and pruned from the L2 state can not be checked.
+type Checker struct {
+	log     log.Logger
+	metrics Metrics
+	cfg     Config
+
+	l2   L2Source
+	safe func() eth.L2BlockRef
+
+	sources []*sourceState
+	pending []pendingProposal
+
+	mu          sync.Mutex
+	divergences []Divergence
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewChecker creates a Checker of the given proposal sources.
+// The safe function returns the current local safe head, up to which proposals can be checked.
+func NewChecker(log log.Logger, metrics Metrics, cfg Config, l2 L2Source, safe func() eth.L2BlockRef, sources ...ProposalSource) *Checker {
+	c := &Checker{
+		log:     log,
+		metrics: metrics,
+		cfg:     cfg,
+		l2:      l2,
+		safe:    safe,
+	}
+	for _, src := range sources {
+		c.sources = append(c.sources, &sourceState{ProposalSource: src})
+	}
+	return c
+}
+
+// Start starts polling for new proposals in the background.
+func (c *Checker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(c.cfg.PollInterval)
+		defer ticker.Stop()
+		for {
+			c.Poll(ctx)
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops polling, and waits for an ongoing poll to complete.
+func (c *Checker) Stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+}
+
+// Poll fetches the new proposals of all sources, and checks the pending proposals that are safe.
+// Poll is not safe for concurrent use.
+func (c *Checker) Poll(ctx context.Context) {
+	for _, src := range c.sources {
+		if err := c.fetch(ctx, src); err != nil {
+			c.log.Warn("Failed to fetch output proposals", "source", src.Name(), "err", err)
+		}
+	}
+	safe := c.safe()
+	remaining := c.pending[:0]
+	for _, p := range c.pending {
+		if p.L2BlockNumber > safe.Number {
+			remaining = append(remaining, p)
+			continue
+		}
+		if ctx.Err() != nil {
+			// check it in the next poll instead
+			remaining = append(remaining, p)
+			continue
+		}
+		if !c.check(ctx, &p) {
+			remaining = append(remaining, p)
+		}
+	}
+	c.pending = remaining
+}
+
+func (c *Checker) fetch(ctx context.Context, src *sourceState) error {
+	count, err := src.Count(ctx, proposalBlock)
+	if err != nil {
+		return err
+	}
+	if !src.initialized {
+		src.next = count - min(count, c.cfg.Backfill)
+		src.initialized = true
+		c.log.Info("Checking output proposals", "source", src.Name(), "proposals", count, "first", src.next)
+	}
+	if count <= src.next {
+		return nil
+	}
+	end := min(count, src.next+maxFetch)
+	proposals, err := src.Proposals(ctx, proposalBlock, src.next, end)
+	if err != nil {
+		return err
+	}
+	src.next = end
+	for _, p := range proposals {
+		c.pending = append(c.pending, pendingProposal{Proposal: p})
+	}
+	if len(c.pending) > maxPending {
+		dropped := len(c.pending) - maxPending
+		c.log.Warn("Too many pending output proposals, dropping the oldest", "dropped", dropped)
+		c.pending = append(c.pending[:0], c.pending[dropped:]...)
+	}
+	return nil
+}
+
+// check compares the root claim of the proposal with the local output root.
+// It returns false if the proposal should be checked again in the next poll.
+func (c *Checker) check(ctx context.Context, p *pendingProposal) bool {
+	logger := c.log.New("source", p.Source, "index", p.Index, "address", p.Address, "l2_block", p.L2BlockNumber)
+	expected, ref, err := c.outputRoot(ctx, p.L2BlockNumber)
+	if ctx.Err() != nil {
+		// the poll was cancelled, which says nothing about the proposal
+		return false
+	}
+	if err != nil && stateUnavailable(err) {
+		logger.Warn("Output proposal can not be checked, the L2 state is no longer available", "err", err)
+		c.metrics.RecordOutputCheck(p.Source, ResultUnavailable)
+		return true
+	}
+	if err != nil {
+		p.attempts++
+		c.metrics.RecordOutputCheck(p.Source, ResultError)
+		if p.attempts >= maxAttempts {
+			logger.Error("Failed to compute output root to check proposal, giving up", "attempts", p.attempts, "err", err)
+			return true
+		}
+		logger.Warn("Failed to compute output root to check proposal, retrying", "attempts", p.attempts, "err", err)
+		return false
+	}
+	if expected == p.RootClaim {
+		logger.Debug("Output proposal matches", "output_root", expected)
+		c.metrics.RecordOutputCheck(p.Source, ResultMatch)
+		return true
+	}
+	logger.Error("Output proposal diverges from local safe chain", "root_claim", p.RootClaim, "expected", expected, "l2_ref", ref)
+	c.metrics.RecordOutputCheck(p.Source, ResultMismatch)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.divergences = append(c.divergences, Divergence{
+		Proposal:   p.Proposal,
+		Expected:   expected,
+		L2Block:    ref.ID(),
+		DetectedAt: uint64(time.Now().Unix()),
+	})
+	if len(c.divergences) > maxDivergences {
+		c.divergences = c.divergences[len(c.divergences)-maxDivergences:]
+	}
+	return true
+}
+
+func (c *Checker) outputRoot(ctx context.Context, num uint64) (eth.Bytes32, eth.L2BlockRef, error) {
+	ref, err := c.l2.L2BlockRefByNumber(ctx, num)
+	if err != nil {
+		return eth.Bytes32{}, eth.L2BlockRef{}, fmt.Errorf("failed to get L2 block %d: %w", num, err)
+	}
+	output, err := c.l2.OutputV0AtBlock(ctx, ref.Hash)
+	if err != nil {
+		return eth.Bytes32{}, ref, fmt.Errorf("failed to get output at L2 block %s: %w", ref, err)
+	}
+	return eth.OutputRoot(output), ref, nil
+}
+
+// stateUnavailable returns whether the error indicates that the L2 state of a block is not available,
+// like the state of blocks that are pruned by the execution client, so retrying can not succeed.
+func stateUnavailable(err error) bool {
+	errText := strings.ToLower(err.Error())
+	return strings.Contains(errText, "missing trie node") || // hash-based state scheme
+		strings.Contains(errText, "historical state") || // path-based state scheme
+		strings.Contains(errText, "state is not available")
+}
+
+// Divergences returns the most recently detected divergences, oldest first.
+func (c *Checker) Divergences() []Divergence {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]Divergence, len(c.divergences))
+	copy(out, c.divergences)
+	return out
+}
@@ -0,0 +1,247 @@
+package outputcheck
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeSource struct {
+	proposals []Proposal
+	// blocks are the L1 blocks that the proposals were read at
+	blocks []rpcblock.Block
+}
+
+func (f *fakeSource) Name() string {
+	return SourceL2OutputOracle
+}
+
+func (f *fakeSource) Count(ctx context.Context, block rpcblock.Block) (uint64, error) {
+	f.blocks = append(f.blocks, block)
+	return uint64(len(f.proposals)), nil
+}
+
+func (f *fakeSource) Proposals(ctx context.Context, block rpcblock.Block, start, end uint64) ([]Proposal, error) {
+	f.blocks = append(f.blocks, block)
+	return append([]Proposal(nil), f.proposals[start:end]...), nil
+}
+
+func (f *fakeSource) propose(l2Block uint64, root eth.Bytes32) {
+	f.proposals = append(f.proposals, Proposal{
+		Source:        SourceL2OutputOracle,
+		Index:         uint64(len(f.proposals)),
+		L2BlockNumber: l2Block,
+		RootClaim:     root,
+	})
+}
+
+// fakeL2 has a block at every number, with the state root set to the block number.
+type fakeL2 struct {
+	missing map[uint64]bool
+	// pruned blocks have no state to compute the output root with
+	pruned map[uint64]bool
+}
+
+func (f *fakeL2) L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error) {
+	if f.missing[num] {
+		return eth.L2BlockRef{}, errors.New("not found")
+	}
+	return eth.L2BlockRef{Hash: common.BigToHash(new(big.Int).SetUint64(num)), Number: num}, nil
+}
+
+func (f *fakeL2) OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error) {
+	if f.pruned[new(big.Int).SetBytes(blockHash[:]).Uint64()] {
+		return nil, errors.New("missing trie node 0123 (path ) state 0x0123 is not available")
+	}
+	return &eth.OutputV0{StateRoot: eth.Bytes32(blockHash), BlockHash: blockHash}, nil
+}
+
+func outputRootAt(t *testing.T, l2 *fakeL2, num uint64) eth.Bytes32 {
+	ref, err := l2.L2BlockRefByNumber(context.Background(), num)
+	require.NoError(t, err)
+	output, err := l2.OutputV0AtBlock(context.Background(), ref.Hash)
+	require.NoError(t, err)
+	return eth.OutputRoot(output)
+}
+
+type fakeMetrics map[string]int
+
+func (m fakeMetrics) RecordOutputCheck(source string, result string) {
+	m[source+"/"+result]++
+}
+
+type checkerTest struct {
+	checker *Checker
+	source  *fakeSource
+	l2      *fakeL2
+	metrics fakeMetrics
+	safe    uint64
+}
+
+func newCheckerTest(t *testing.T, backfill uint64) *checkerTest {
+	ct := &checkerTest{
+		source:  &fakeSource{},
+		l2:      &fakeL2{missing: make(map[uint64]bool), pruned: make(map[uint64]bool)},
+		metrics: make(fakeMetrics),
+	}
+	cfg := Config{
+		L2OutputOracle: common.Address{0xaa},
+		PollInterval:   time.Minute,
+		Backfill:       backfill,
+	}
+	ct.checker = NewChecker(testlog.Logger(t, log.LevelDebug), ct.metrics, cfg, ct.l2, func() eth.L2BlockRef {
+		return eth.L2BlockRef{Number: ct.safe}
+	}, ct.source)
+	return ct
+}
+
+func TestChecker(t *testing.T) {
+	t.Run("Match", func(t *testing.T) {
+		ct := newCheckerTest(t, 0)
+		ct.checker.Poll(context.Background())
+		ct.source.propose(10, outputRootAt(t, ct.l2, 10))
+		ct.safe = 10
+		ct.checker.Poll(context.Background())
+		require.Equal(t, 1, ct.metrics["l2oo/match"])
+		require.Empty(t, ct.checker.Divergences())
+		for _, block := range ct.source.blocks {
+			require.Equal(t, rpcblock.Finalized, block, "proposals must be read at the finalized L1 block")
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		ct := newCheckerTest(t, 0)
+		ct.checker.Poll(context.Background())
+		ct.source.propose(10, eth.Bytes32{0x01})
+		ct.safe = 10
+		ct.checker.Poll(context.Background())
+		require.Equal(t, 1, ct.metrics["l2oo/mismatch"])
+		divergences := ct.checker.Divergences()
+		require.Len(t, divergences, 1)
+		require.Equal(t, eth.Bytes32{0x01}, divergences[0].RootClaim)
+		require.Equal(t, outputRootAt(t, ct.l2, 10), divergences[0].Expected)
+		require.Equal(t, uint64(10), divergences[0].L2Block.Number)
+
+		api := NewAPI(ct.checker)
+		result, err := api.OutputDivergences(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, divergences, result)
+	})
+
+	t.Run("PendingUntilSafe", func(t *testing.T) {
+		ct := newCheckerTest(t, 0)
+		ct.checker.Poll(context.Background())
+		ct.source.propose(10, outputRootAt(t, ct.l2, 10))
+		ct.safe = 9
+		ct.checker.Poll(context.Background())
+		require.Empty(t, ct.metrics)
+		require.Len(t, ct.checker.pending, 1)
+
+		ct.safe = 10
+		ct.checker.Poll(context.Background())
+		require.Equal(t, 1, ct.metrics["l2oo/match"])
+		require.Empty(t, ct.checker.pending)
+	})
+
+	t.Run("Backfill", func(t *testing.T) {
+		ct := newCheckerTest(t, 2)
+		for i := uint64(1); i <= 5; i++ {
+			ct.source.propose(i*10, eth.Bytes32{byte(i)})
+		}
+		ct.safe = 100
+		ct.checker.Poll(context.Background())
+		require.Equal(t, 2, ct.metrics["l2oo/mismatch"])
+		divergences := ct.checker.Divergences()
+		require.Len(t, divergences, 2)
+		require.Equal(t, uint64(3), divergences[0].Index)
+		require.Equal(t, uint64(4), divergences[1].Index)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		ct := newCheckerTest(t, 0)
+		ct.checker.Poll(context.Background())
+		ct.l2.missing[10] = true
+		ct.source.propose(10, outputRootAt(t, ct.l2, 11))
+		ct.safe = 10
+		ct.checker.Poll(context.Background())
+		require.Equal(t, 1, ct.metrics["l2oo/error"])
+		require.Empty(t, ct.checker.Divergences())
+		require.Len(t, ct.checker.pending, 1, "proposal is retried after a transient error")
+
+		// the proposal is checked once the L2 block is available again
+		ct.l2.missing[10] = false
+		ct.checker.Poll(context.Background())
+		require.Equal(t, 1, ct.metrics["l2oo/mismatch"])
+		require.Empty(t, ct.checker.pending)
+	})
+
+	t.Run("RetryLimit", func(t *testing.T) {
+		ct := newCheckerTest(t, 0)
+		ct.checker.Poll(context.Background())
+		ct.l2.missing[10] = true
+		ct.source.propose(10, eth.Bytes32{0x01})
+		ct.safe = 10
+		for i := 1; i < maxAttempts; i++ {
+			ct.checker.Poll(context.Background())
+			require.Len(t, ct.checker.pending, 1)
+		}
+		ct.checker.Poll(context.Background())
+		require.Equal(t, maxAttempts, ct.metrics["l2oo/error"])
+		require.Empty(t, ct.checker.pending, "proposal is dropped after the retry limit")
+		require.Empty(t, ct.checker.Divergences())
+	})
+
+	t.Run("StateUnavailable", func(t *testing.T) {
+		ct := newCheckerTest(t, 0)
+		ct.checker.Poll(context.Background())
+		ct.l2.pruned[10] = true
+		ct.source.propose(10, eth.Bytes32{0x01})
+		ct.safe = 10
+		ct.checker.Poll(context.Background())
+		require.Equal(t, 1, ct.metrics["l2oo/unavailable"])
+		require.Zero(t, ct.metrics["l2oo/error"])
+		require.Empty(t, ct.checker.pending, "proposal of pruned block is not retried")
+		require.Empty(t, ct.checker.Divergences())
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ct := newCheckerTest(t, 0)
+		ct.checker.Poll(context.Background())
+		ct.source.propose(10, outputRootAt(t, ct.l2, 10))
+		ct.safe = 10
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		ct.checker.Poll(ctx)
+		require.Empty(t, ct.metrics)
+		require.Len(t, ct.checker.pending, 1, "cancelled poll keeps the proposal pending")
+
+		ct.checker.Poll(context.Background())
+		require.Equal(t, 1, ct.metrics["l2oo/match"])
+		require.Empty(t, ct.checker.pending)
+	})
+}
+
+func TestAPIDisabled(t *testing.T) {
+	_, err := NewAPI(nil).OutputDivergences(context.Background())
+	require.ErrorIs(t, err, ErrDisabled)
+}
+
+func TestConfigCheck(t *testing.T) {
+	require.NoError(t, (&Config{}).Check(), "disabled config is valid")
+	cfg := Config{DisputeGameFactory: common.Address{0xaa}, PollInterval: time.Minute}
+	require.ErrorContains(t, cfg.Check(), "game types")
+	cfg.GameTypes = []uint32{0}
+	require.NoError(t, cfg.Check())
+	cfg.PollInterval = 0
+	require.ErrorContains(t, cfg.Check(), "poll interval")
+}
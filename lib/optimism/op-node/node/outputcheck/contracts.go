@@ -0,0 +1,185 @@
+package outputcheck
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+const (
+	SourceDisputeGameFactory = "dgf"
+	SourceL2OutputOracle     = "l2oo"
+
+	methodGameCount       = "gameCount"
+	methodGameAtIndex     = "gameAtIndex"
+	methodRootClaim       = "rootClaim"
+	methodL2BlockNumber   = "l2BlockNumber"
+	methodNextOutputIndex = "nextOutputIndex"
+	methodGetL2Output     = "getL2Output"
+)
+
+// Proposal is an output root that is proposed on L1, for the given L2 block.
+type Proposal struct {
+	Source string `json:"source"`
+	Index  uint64 `json:"index"`
+	// Address is the dispute game of the proposal, or the L2OutputOracle for legacy proposals.
+	Address common.Address `json:"address"`
+	// GameType is only set for dispute games.
+	GameType      *uint32     `json:"gameType,omitempty"`
+	L2BlockNumber uint64      `json:"l2BlockNumber"`
+	RootClaim     eth.Bytes32 `json:"rootClaim"`
+}
+
+// ProposalSource lists the output proposals of a L1 contract, in order of proposal.
+type ProposalSource interface {
+	// Name identifies the source in logs, metrics and divergences.
+	Name() string
+	// Count returns the number of proposals as of the given L1 block.
+	Count(ctx context.Context, block rpcblock.Block) (uint64, error)
+	// Proposals returns the proposals with index in [start, end), as of the given L1 block.
+	// Proposals that are not of an output root are left out.
+	Proposals(ctx context.Context, block rpcblock.Block, start, end uint64) ([]Proposal, error)
+}
+
+// DisputeGameFactory lists the dispute games of the configured game types as proposals.
+type DisputeGameFactory struct {
+	caller    *batching.MultiCaller
+	contract  *batching.BoundContract
+	gameABI   *abi.ABI
+	gameTypes []uint32
+}
+
+var _ ProposalSource = (*DisputeGameFactory)(nil)
+
+// NewDisputeGameFactory creates a proposal source of the dispute games of the given game types.
+// Only game types that claim an output root, like the fault dispute games, can be checked.
+func NewDisputeGameFactory(caller *batching.MultiCaller, addr common.Address, gameTypes []uint32) *DisputeGameFactory {
+	return &DisputeGameFactory{
+		caller:    caller,
+		contract:  batching.NewBoundContract(snapshots.LoadDisputeGameFactoryABI(), addr),
+		gameABI:   snapshots.LoadFaultDisputeGameABI(),
+		gameTypes: gameTypes,
+	}
+}
+
+func (f *DisputeGameFactory) Name() string {
+	return SourceDisputeGameFactory
+}
+
+func (f *DisputeGameFactory) Count(ctx context.Context, block rpcblock.Block) (uint64, error) {
+	result, err := f.caller.SingleCall(ctx, block, f.contract.Call(methodGameCount))
+	if err != nil {
+		return 0, fmt.Errorf("failed to load game count: %w", err)
+	}
+	return result.GetBigInt(0).Uint64(), nil
+}
+
+func (f *DisputeGameFactory) Proposals(ctx context.Context, block rpcblock.Block, start, end uint64) ([]Proposal, error) {
+	if start >= end {
+		return nil, nil
+	}
+	calls := make([]batching.Call, 0, end-start)
+	for i := start; i < end; i++ {
+		calls = append(calls, f.contract.Call(methodGameAtIndex, new(big.Int).SetUint64(i)))
+	}
+	results, err := f.caller.Call(ctx, block, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load games %d to %d: %w", start, end, err)
+	}
+	var proposals []Proposal
+	calls = calls[:0]
+	for i, result := range results {
+		gameType := result.GetUint32(0)
+		if !slices.Contains(f.gameTypes, gameType) {
+			continue
+		}
+		game := batching.NewBoundContract(f.gameABI, result.GetAddress(2))
+		proposals = append(proposals, Proposal{
+			Source:   SourceDisputeGameFactory,
+			Index:    start + uint64(i),
+			Address:  game.Addr(),
+			GameType: &gameType,
+		})
+		calls = append(calls, game.Call(methodRootClaim), game.Call(methodL2BlockNumber))
+	}
+	if len(proposals) == 0 {
+		return nil, nil
+	}
+	results, err = f.caller.Call(ctx, block, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game claims: %w", err)
+	}
+	for i := range proposals {
+		proposals[i].RootClaim = results[2*i].GetBytes32(0)
+		proposals[i].L2BlockNumber = results[2*i+1].GetBigInt(0).Uint64()
+	}
+	return proposals, nil
+}
+
+// L2OutputOracle lists the output proposals of the legacy L2OutputOracle.
+type L2OutputOracle struct {
+	caller   *batching.MultiCaller
+	contract *batching.BoundContract
+}
+
+var _ ProposalSource = (*L2OutputOracle)(nil)
+
+func NewL2OutputOracle(caller *batching.MultiCaller, addr common.Address) (*L2OutputOracle, error) {
+	oracleABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load L2OutputOracle ABI: %w", err)
+	}
+	return &L2OutputOracle{
+		caller:   caller,
+		contract: batching.NewBoundContract(oracleABI, addr),
+	}, nil
+}
+
+func (o *L2OutputOracle) Name() string {
+	return SourceL2OutputOracle
+}
+
+func (o *L2OutputOracle) Count(ctx context.Context, block rpcblock.Block) (uint64, error) {
+	result, err := o.caller.SingleCall(ctx, block, o.contract.Call(methodNextOutputIndex))
+	if err != nil {
+		return 0, fmt.Errorf("failed to load next output index: %w", err)
+	}
+	return result.GetBigInt(0).Uint64(), nil
+}
+
+func (o *L2OutputOracle) Proposals(ctx context.Context, block rpcblock.Block, start, end uint64) ([]Proposal, error) {
+	if start >= end {
+		return nil, nil
+	}
+	calls := make([]batching.Call, 0, end-start)
+	for i := start; i < end; i++ {
+		calls = append(calls, o.contract.Call(methodGetL2Output, new(big.Int).SetUint64(i)))
+	}
+	results, err := o.caller.Call(ctx, block, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load outputs %d to %d: %w", start, end, err)
+	}
+	proposals := make([]Proposal, 0, len(results))
+	for i, result := range results {
+		var output bindings.TypesOutputProposal
+		result.GetStruct(0, &output)
+		proposals = append(proposals, Proposal{
+			Source:        SourceL2OutputOracle,
+			Index:         start + uint64(i),
+			Address:       o.contract.Addr(),
+			L2BlockNumber: output.L2BlockNumber.Uint64(),
+			RootClaim:     output.OutputRoot,
+		})
+	}
+	return proposals, nil
+}
@@ -0,0 +1,220 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/clsync"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// The sources that the external calls of the op-node are recorded with, see Config.EventRecordPath.
+const (
+	RecordSourceL1     = "l1"
+	RecordSourceEngine = "engine"
+	RecordSourceBeacon = "beacon"
+)
+
+// RecordSourceBeaconFallback returns the source that the calls to the i-th L1 Beacon API fallback endpoint are recorded with.
+func RecordSourceBeaconFallback(i int) string {
+	return fmt.Sprintf("%s-fallback-%d", RecordSourceBeacon, i)
+}
+
+// ReplayEventTypes returns the types of all events that the op-node derivers may emit,
+// to decode the events of an op-node recording.
+func ReplayEventTypes() event.EventTypes {
+	types := make(event.EventTypes)
+	types.Register(
+		rollup.CriticalErrorEvent{},
+		rollup.EngineTemporaryErrorEvent{},
+		rollup.ResetEvent{},
+		rollup.ForceResetEvent{},
+
+		driver.ResetStepBackoffEvent{},
+		driver.StepDelayedReqEvent{},
+		driver.StepReqEvent{},
+		driver.StepAttemptEvent{},
+		driver.StepEvent{},
+
+		engine.PayloadInvalidEvent{},
+		engine.BuildCancelEvent{},
+		engine.BuildInvalidEvent{},
+		engine.InvalidPayloadAttributesEvent{},
+		engine.BuildStartedEvent{},
+		engine.ResetEngineRequestEvent{},
+		engine.PayloadProcessEvent{},
+		engine.BuildSealedEvent{},
+		engine.PayloadSealInvalidEvent{},
+		engine.PayloadSealExpiredErrorEvent{},
+		engine.BuildSealEvent{},
+		engine.BuildStartEvent{},
+		engine.ForkchoiceRequestEvent{},
+		engine.ForkchoiceUpdateEvent{},
+		engine.PromoteUnsafeEvent{},
+		engine.RequestCrossUnsafeEvent{},
+		engine.UnsafeUpdateEvent{},
+		engine.PromoteCrossUnsafeEvent{},
+		engine.CrossUnsafeUpdateEvent{},
+		engine.PendingSafeUpdateEvent{},
+		engine.PromotePendingSafeEvent{},
+		engine.PromoteLocalSafeEvent{},
+		engine.RequestCrossSafeEvent{},
+		engine.CrossSafeUpdateEvent{},
+		engine.LocalSafeUpdateEvent{},
+		engine.PromoteSafeEvent{},
+		engine.SafeDerivedEvent{},
+		engine.ProcessAttributesEvent{},
+		engine.PendingSafeRequestEvent{},
+		engine.ProcessUnsafePayloadEvent{},
+		engine.TryBackupUnsafeReorgEvent{},
+		engine.TryUpdateEngineEvent{},
+		engine.EngineResetConfirmedEvent{},
+		engine.PromoteFinalizedEvent{},
+		engine.FinalizedUpdateEvent{},
+		engine.RequestFinalizedUpdateEvent{},
+		engine.CrossUpdateRequestEvent{},
+		engine.InteropInvalidateBlockEvent{},
+		engine.InteropReplacedBlockEvent{},
+		engine.PayloadSuccessEvent{},
+
+		derive.DeriverIdleEvent{},
+		derive.DeriverMoreEvent{},
+		derive.ConfirmReceivedAttributesEvent{},
+		derive.ConfirmPipelineResetEvent{},
+		derive.DerivedAttributesEvent{},
+		derive.PipelineStepEvent{},
+		derive.DepositsOnlyPayloadAttributesRequestEvent{},
+
+		clsync.ReceivedUnsafePayloadEvent{},
+		status.L1UnsafeEvent{},
+		status.L1SafeEvent{},
+		sequencing.SequencerActionEvent{},
+		finality.FinalizeL1Event{},
+		finality.TryFinalizeEvent{},
+	)
+	return types
+}
+
+// ReplayConfig configures the derivers that an op-node recording is replayed with.
+// It must match the config of the op-node that made the recording, for the derivers to
+// make the same external calls, and emit the same events.
+type ReplayConfig struct {
+	Rollup *rollup.Config
+	Driver driver.Config
+	Sync   sync.Config
+
+	L1     *sources.L1ClientConfig
+	L2     *sources.EngineClientConfig
+	Beacon sources.L1BeaconClientConfig
+	// BeaconFallbacks is the number of L1 Beacon API fallback endpoints of the recording op-node.
+	BeaconFallbacks int
+
+	// SafeHeadListener is notified of safe head updates, as the safe head database of the recording op-node.
+	// Safe head updates are dropped if nil.
+	SafeHeadListener rollup.SafeHeadListener
+}
+
+// NewReplayConfig returns the config to replay the recording of an op-node with the given config.
+func NewReplayConfig(cfg *Config) (*ReplayConfig, error) {
+	out := &ReplayConfig{
+		Rollup: &cfg.Rollup,
+		Driver: cfg.Driver,
+		Sync:   cfg.Sync,
+		L2:     sources.EngineClientDefaultConfig(&cfg.Rollup),
+	}
+	switch l1 := cfg.L1.(type) {
+	case *L1EndpointConfig:
+		out.L1 = l1.clientConfig(&cfg.Rollup)
+	case *PreparedL1Endpoint:
+		out.L1 = sources.L1ClientDefaultConfig(&cfg.Rollup, l1.TrustRPC, l1.RPCProviderKind)
+	default:
+		return nil, fmt.Errorf("unsupported L1 endpoint config %T", cfg.L1)
+	}
+	if cfg.Beacon != nil {
+		out.Beacon.FetchAllSidecars = cfg.Beacon.ShouldFetchAllSidecars()
+		if beacon, ok := cfg.Beacon.(*L1BeaconEndpointConfig); ok {
+			out.BeaconFallbacks = len(beacon.BeaconFallbackAddrs)
+		}
+	}
+	return out, nil
+}
+
+// Replay re-drives the derivers of the op-node with a recording, made with Config.EventRecordPath.
+// The derivers are served the recorded L1, engine and L1 Beacon API responses, and the events that they
+// emit are compared against the recording, see event.Replay.
+//
+// Replay is deterministic for the derivation of verifier nodes. The sequencer decides when to build
+// blocks based on wall-clock time, so the replay of a sequencer recording may diverge.
+// Interop and Alt-DA recordings are not supported, since their external calls are not recorded.
+func Replay(ctx context.Context, log log.Logger, cfg *ReplayConfig, rec event.Recording) error {
+	if cfg.Rollup.InteropTime != nil {
+		return errors.New("replaying interop recordings is not supported")
+	}
+	if cfg.Rollup.AltDAEnabled() {
+		return errors.New("replaying Alt-DA recordings is not supported")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	executor := event.NewGlobalSynchronous(ctx)
+	sys := event.NewSystem(log, executor)
+	defer sys.Stop()
+
+	l1, err := sources.NewL1Client(client.NewReplayClient(rec.Calls(RecordSourceL1)), log, nil, cfg.L1)
+	if err != nil {
+		return fmt.Errorf("failed to create L1 client: %w", err)
+	}
+	l2, err := sources.NewEngineClient(client.NewReplayClient(rec.Calls(RecordSourceEngine)), log, nil, cfg.L2)
+	if err != nil {
+		return fmt.Errorf("failed to create engine client: %w", err)
+	}
+	var fallbacks []apis.BlobSideCarsClient
+	for i := 0; i < cfg.BeaconFallbacks; i++ {
+		fallbacks = append(fallbacks, sources.NewBeaconHTTPClient(client.NewReplayHTTP(rec.Calls(RecordSourceBeaconFallback(i)))))
+	}
+	beacon := sources.NewL1BeaconClient(
+		sources.NewBeaconHTTPClient(client.NewReplayHTTP(rec.Calls(RecordSourceBeacon))), cfg.Beacon, fallbacks...)
+
+	safeHeadListener := cfg.SafeHeadListener
+	if safeHeadListener == nil {
+		safeHeadListener = safedb.Disabled
+	}
+	driver.NewDriver(sys, executor, &cfg.Driver, cfg.Rollup, l2, l1, beacon, replayAltSync{}, replayNetwork{},
+		log, metrics.NewMetrics("replay"), DisabledConfigPersistence{}, safeHeadListener, &cfg.Sync,
+		&conductor.NoOpConductor{}, altda.Disabled, nil, false)
+
+	return event.Replay(sys, executor, ReplayEventTypes(), rec)
+}
+
+// replayAltSync ignores the requests for alternative sync, unsafe payloads are replayed as events.
+type replayAltSync struct{}
+
+func (replayAltSync) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef) error {
+	return nil
+}
+
+// replayNetwork drops the payloads published by the sequencer.
+type replayNetwork struct{}
+
+func (replayNetwork) PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
+	return nil
+}
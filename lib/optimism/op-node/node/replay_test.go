@@ -0,0 +1,111 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestReplayEventTypes(t *testing.T) {
+	types := ReplayEventTypes()
+	var buf bytes.Buffer
+	recorder := event.NewRecorder(&buf)
+	for _, typ := range types {
+		recorder.OnEmit("test", event.AnnotatedEvent{Event: reflect.Zero(typ).Interface().(event.Event)}, 0, time.Now())
+	}
+	errEv := rollup.ResetEvent{Err: errors.New("test reset")}
+	recorder.OnEmit("test", event.AnnotatedEvent{Event: errEv}, 0, time.Now())
+	timedEv := engine.BuildStartedEvent{BuildStarted: time.Now()}
+	recorder.OnEmit("test", event.AnnotatedEvent{Event: timedEv}, 0, time.Now())
+	require.NoError(t, recorder.Close())
+
+	rec, err := event.ReadRecording(&buf)
+	require.NoError(t, err)
+	require.Len(t, rec, len(types)+2)
+	for _, entry := range rec {
+		require.Emptyf(t, entry.EncodeError, "event type %s", entry.EventType)
+		_, err := types.Decode(entry)
+		require.NoErrorf(t, err, "event type %s", entry.EventType)
+	}
+	ev, err := types.Decode(rec[len(types)])
+	require.NoError(t, err)
+	require.EqualError(t, ev.(rollup.ResetEvent).Err, "test reset")
+	ev, err = types.Decode(rec[len(types)+1])
+	require.NoError(t, err)
+	require.True(t, timedEv.BuildStarted.Equal(ev.(engine.BuildStartedEvent).BuildStarted))
+}
+
+func TestReplay(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	rollupCfg := chaincfg.OPSepolia()
+	cfg := &ReplayConfig{
+		Rollup: rollupCfg,
+		L1:     sources.L1ClientDefaultConfig(rollupCfg, false, sources.RPCKindStandard),
+		L2:     sources.EngineClientDefaultConfig(rollupCfg),
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		require.NoError(t, Replay(context.Background(), logger, cfg, nil))
+	})
+	t.Run("diverged", func(t *testing.T) {
+		// the derivers don't finalize anything in response to a new L1 head
+		l1Unsafe, err := json.Marshal(status.L1UnsafeEvent{L1Unsafe: eth.L1BlockRef{Number: 1}})
+		require.NoError(t, err)
+		rec := event.Recording{
+			{Kind: event.RecordEmit, Emitter: "driver", EventType: event.EventType(status.L1UnsafeEvent{}), Event: l1Unsafe},
+			{Kind: event.RecordEmit, Emitter: "engine", DerivContext: 1, EventType: event.EventType(engine.PromoteFinalizedEvent{}), Event: []byte(`{}`)},
+		}
+		require.ErrorIs(t, Replay(context.Background(), logger, cfg, rec), event.ErrReplayDiverged)
+	})
+	t.Run("interop", func(t *testing.T) {
+		interopCfg := *rollupCfg
+		interopCfg.InteropTime = new(uint64)
+		cfg := *cfg
+		cfg.Rollup = &interopCfg
+		require.ErrorContains(t, Replay(context.Background(), logger, &cfg, nil), "not supported")
+	})
+}
+
+func TestNewReplayConfig(t *testing.T) {
+	rollupCfg := chaincfg.OPSepolia()
+	cfg := &Config{
+		Rollup: *rollupCfg,
+		L1: &L1EndpointConfig{
+			L1RPCKind:      sources.RPCKindBasic,
+			BatchSize:      20,
+			MaxConcurrency: 10,
+		},
+		Beacon: &L1BeaconEndpointConfig{
+			BeaconFallbackAddrs:    []string{"a", "b"},
+			BeaconFetchAllSidecars: true,
+		},
+	}
+	cfg.Driver.VerifierConfDepth = 3
+	replayCfg, err := NewReplayConfig(cfg)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), replayCfg.Driver.VerifierConfDepth)
+	require.Equal(t, sources.RPCKindBasic, replayCfg.L1.RPCProviderKind)
+	require.Equal(t, 20, replayCfg.L1.MaxRequestsPerBatch)
+	require.True(t, replayCfg.Beacon.FetchAllSidecars)
+	require.Equal(t, 2, replayCfg.BeaconFallbacks)
+
+	cfg.L1 = nil
+	_, err = NewReplayConfig(cfg)
+	require.Error(t, err)
+}
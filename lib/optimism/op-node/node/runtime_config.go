@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 )
 
 var (
@@ -34,6 +35,7 @@ type RuntimeCfgL1Source interface {
 
 type ReadonlyRuntimeConfig interface {
 	P2PSequencerAddress() common.Address
+	P2PSequencerSigners() []opsigner.AuthorizedSigner
 	RequiredProtocolVersion() params.ProtocolVersion
 	RecommendedProtocolVersion() params.ProtocolVersion
 }
@@ -50,6 +52,9 @@ type RuntimeConfig struct {
 	l1Client  RuntimeCfgL1Source
 	rollupCfg *rollup.Config
 
+	// signersFile optionally lists additional p2p block signers, reloaded together with the L1 config.
+	signersFile string
+
 	// l1Ref is the current source of the data,
 	// if this is invalidated with a reorg the data will have to be reloaded.
 	l1Ref eth.L1BlockRef
@@ -60,6 +65,8 @@ type RuntimeConfig struct {
 // runtimeConfigData is a flat bundle of configurable data, easy and light to copy around.
 type runtimeConfigData struct {
 	p2pBlockSignerAddr common.Address
+	// p2pSigners are the p2p block signers of the signers file, in addition to the L1 configured signer.
+	p2pSigners []opsigner.AuthorizedSigner
 
 	// superchain protocol version signals
 	recommended params.ProtocolVersion
@@ -68,11 +75,12 @@ type runtimeConfigData struct {
 
 var _ p2p.GossipRuntimeConfig = (*RuntimeConfig)(nil)
 
-func NewRuntimeConfig(log log.Logger, l1Client RuntimeCfgL1Source, rollupCfg *rollup.Config) *RuntimeConfig {
+func NewRuntimeConfig(log log.Logger, l1Client RuntimeCfgL1Source, rollupCfg *rollup.Config, signersFile string) *RuntimeConfig {
 	return &RuntimeConfig{
-		log:       log,
-		l1Client:  l1Client,
-		rollupCfg: rollupCfg,
+		log:         log,
+		l1Client:    l1Client,
+		rollupCfg:   rollupCfg,
+		signersFile: signersFile,
 	}
 }
 
@@ -82,6 +90,18 @@ func (r *RuntimeConfig) P2PSequencerAddress() common.Address {
 	return r.p2pBlockSignerAddr
 }
 
+// P2PSequencerSigners returns the L1 configured p2p block signer, which is active at any time,
+// followed by the signers of the signers file, if any.
+func (r *RuntimeConfig) P2PSequencerSigners() []opsigner.AuthorizedSigner {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]opsigner.AuthorizedSigner, 0, len(r.p2pSigners)+1)
+	if r.p2pBlockSignerAddr != (common.Address{}) {
+		out = append(out, opsigner.AuthorizedSigner{Address: r.p2pBlockSignerAddr})
+	}
+	return append(out, r.p2pSigners...)
+}
+
 func (r *RuntimeConfig) RequiredProtocolVersion() params.ProtocolVersion {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -116,12 +136,20 @@ func (r *RuntimeConfig) Load(ctx context.Context, l1Ref eth.L1BlockRef) error {
 		}
 		recommendedProtoVersion = params.ProtocolVersion(recommendedVal)
 	}
+	var p2pSigners []opsigner.AuthorizedSigner
+	if r.signersFile != "" {
+		p2pSigners, err = opsigner.LoadAuthorizedSigners(r.signersFile)
+		if err != nil {
+			return fmt.Errorf("failed to load p2p signers from %s: %w", r.signersFile, err)
+		}
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.l1Ref = l1Ref
 	r.p2pBlockSignerAddr = common.BytesToAddress(p2pSignerVal[:])
+	r.p2pSigners = p2pSigners
 	r.required = requiredProtVersion
 	r.recommended = recommendedProtoVersion
-	r.log.Info("loaded new runtime config values!", "p2p_seq_address", r.p2pBlockSignerAddr)
+	r.log.Info("loaded new runtime config values!", "p2p_seq_address", r.p2pBlockSignerAddr, "p2p_signers", len(r.p2pSigners))
 	return nil
 }
@@ -27,10 +27,28 @@ func (d *DisabledDB) SafeHeadAtL1(_ context.Context, _ uint64) (l1 eth.BlockID,
 	return
 }
 
+func (d *DisabledDB) SafeHeadsInRange(_ context.Context, _ uint64, _ uint64) ([]eth.SafeHeadResponse, error) {
+	return nil, ErrNotEnabled
+}
+
+func (d *DisabledDB) L1BlockAtSafeHead(_ context.Context, _ uint64) (l1 eth.BlockID, safeHead eth.BlockID, err error) {
+	err = ErrNotEnabled
+	return
+}
+
 func (d *DisabledDB) SafeHeadReset(_ eth.L2BlockRef) error {
 	return nil
 }
 
+func (d *DisabledDB) DerivationInfoUpdated(_ eth.DerivationInfo) error {
+	return nil
+}
+
+func (d *DisabledDB) DerivationInfoAtBlock(_ context.Context, _ uint64) (info eth.DerivationInfo, err error) {
+	err = ErrNotEnabled
+	return
+}
+
 func (d *DisabledDB) Close() error {
 	return nil
 }
@@ -0,0 +1,244 @@
+package safedb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/rand"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// The export file format is a header, followed by one tagged record per safe head entry, in ascending L1
+// block order, and a footer with the number of records and the SHA-256 checksum of all preceding bytes:
+//
+//	header: magic (8 bytes) | version (1 byte)
+//	record: 0x01 | l1 number (8 bytes) | l1 hash (32 bytes) | l2 hash (32 bytes) | l2 number (8 bytes)
+//	footer: 0x00 | record count (8 bytes) | checksum (32 bytes)
+const (
+	exportVersion byte = 1
+	recordTag     byte = 1
+	footerTag     byte = 0
+	recordSize         = 8 + 72
+
+	// importBatchSize is the number of entries written to the database per batch on import.
+	importBatchSize = 10_000
+)
+
+var (
+	exportMagic = [8]byte{'o', 'p', 's', 'a', 'f', 'e', 'd', 'b'}
+
+	ErrInvalidExport = errors.New("invalid safe head export")
+	ErrNotEmpty      = errors.New("safe head database is not empty")
+)
+
+// Export writes all safe head entries to w, in the portable export format.
+// It returns the number of exported entries.
+func (d *SafeDB) Export(ctx context.Context, w io.Writer) (uint64, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	iter, err := d.db.NewIterWithContext(ctx, safeByL1BlockNumKey.IterRange())
+	if err != nil {
+		return 0, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	bw := bufio.NewWriter(w)
+	checksum := sha256.New()
+	out := io.MultiWriter(bw, checksum)
+	if _, err := out.Write(append(exportMagic[:], exportVersion)); err != nil {
+		return 0, fmt.Errorf("failed to write header: %w", err)
+	}
+	var count uint64
+	for valid := iter.First(); valid; valid = iter.Next() {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return count, fmt.Errorf("failed to read entry: %w", err)
+		}
+		// Validate the entry instead of copying it, so corrupt entries are not exported.
+		l1, l2, err := decodeSafeByL1BlockNum(iter.Key(), val)
+		if err != nil {
+			return count, fmt.Errorf("failed to decode entry %x: %w", iter.Key(), err)
+		}
+		if _, err := out.Write(encodeRecord(l1, l2)); err != nil {
+			return count, fmt.Errorf("failed to write entry: %w", err)
+		}
+		count++
+	}
+	footer := binary.BigEndian.AppendUint64([]byte{footerTag}, count)
+	if _, err := out.Write(footer); err != nil {
+		return count, fmt.Errorf("failed to write footer: %w", err)
+	}
+	if _, err := bw.Write(checksum.Sum(nil)); err != nil {
+		return count, fmt.Errorf("failed to write checksum: %w", err)
+	}
+	return count, bw.Flush()
+}
+
+func encodeRecord(l1 eth.BlockID, l2 eth.BlockID) []byte {
+	rec := make([]byte, 0, 1+recordSize)
+	rec = append(rec, recordTag)
+	rec = binary.BigEndian.AppendUint64(rec, l1.Number)
+	return append(rec, safeByL1BlockNumValue(l1, l2)...)
+}
+
+// SpotCheckFn verifies an imported entry, e.g. against the L2 execution engine.
+type SpotCheckFn func(ctx context.Context, l1 eth.BlockID, safeHead eth.BlockID) error
+
+// Import reads safe head entries in the export format from r into the database, which must be empty.
+// The checksum and the continuity of the entries are verified: L1 block numbers must be strictly increasing,
+// and safe head numbers must not decrease. If check is not nil, it is called for up to spotChecks entries,
+// sampled uniformly. If any verification fails, all imported entries are removed again.
+// It returns the number of imported entries.
+func (d *SafeDB) Import(ctx context.Context, r io.Reader, spotChecks int, check SpotCheckFn) (uint64, error) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	iter, err := d.db.NewIter(safeByL1BlockNumKey.IterRange())
+	if err != nil {
+		return 0, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	empty := !iter.First()
+	if err := iter.Close(); err != nil {
+		return 0, fmt.Errorf("failed to close iterator: %w", err)
+	}
+	if !empty {
+		return 0, ErrNotEmpty
+	}
+
+	count, samples, err := d.importEntries(ctx, r, spotChecks)
+	if err == nil && check != nil {
+		for _, s := range samples {
+			if err = check(ctx, s.L1Block, s.SafeHead); err != nil {
+				err = fmt.Errorf("spot check of safe head %v at L1 block %v failed: %w", s.SafeHead, s.L1Block, err)
+				break
+			}
+		}
+	}
+	if err != nil {
+		if delErr := d.db.DeleteRange(safeByL1BlockNumKey.Of(0), safeByL1BlockNumKey.Max(), d.writeOpts); delErr != nil {
+			return 0, fmt.Errorf("%w (failed to remove imported entries: %w)", err, delErr)
+		}
+		return 0, err
+	}
+	return count, nil
+}
+
+// importEntries writes the entries of the export read from r, and returns the number of entries,
+// and a uniform sample of up to sampleSize entries.
+func (d *SafeDB) importEntries(ctx context.Context, r io.Reader, sampleSize int) (uint64, []eth.SafeHeadResponse, error) {
+	checksum := sha256.New()
+	in := &checksumReader{r: bufio.NewReader(r), h: checksum}
+
+	var header [9]byte
+	if _, err := io.ReadFull(in, header[:]); err != nil {
+		return 0, nil, fmt.Errorf("%w: failed to read header: %w", ErrInvalidExport, err)
+	}
+	if !bytes.Equal(header[:8], exportMagic[:]) {
+		return 0, nil, fmt.Errorf("%w: unexpected magic %x", ErrInvalidExport, header[:8])
+	}
+	if header[8] != exportVersion {
+		return 0, nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidExport, header[8])
+	}
+
+	batch := d.db.NewBatch()
+	defer func() { batch.Close() }()
+	commit := func() error {
+		if err := batch.Commit(d.writeOpts); err != nil {
+			return fmt.Errorf("failed to commit imported entries: %w", err)
+		}
+		batch.Close()
+		batch = d.db.NewBatch()
+		return nil
+	}
+
+	var (
+		count   uint64
+		prevL1  eth.BlockID
+		prevL2  eth.BlockID
+		samples []eth.SafeHeadResponse
+		rec     [recordSize]byte
+		tag     [1]byte
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return count, nil, err
+		}
+		if _, err := io.ReadFull(in, tag[:]); err != nil {
+			return count, nil, fmt.Errorf("%w: failed to read entry %d: %w", ErrInvalidExport, count, err)
+		}
+		if tag[0] == footerTag {
+			break
+		} else if tag[0] != recordTag {
+			return count, nil, fmt.Errorf("%w: unexpected tag %d of entry %d", ErrInvalidExport, tag[0], count)
+		}
+		if _, err := io.ReadFull(in, rec[:]); err != nil {
+			return count, nil, fmt.Errorf("%w: failed to read entry %d: %w", ErrInvalidExport, count, err)
+		}
+		key := safeByL1BlockNumKey.Of(binary.BigEndian.Uint64(rec[:8]))
+		l1, l2, err := decodeSafeByL1BlockNum(key, rec[8:])
+		if err != nil {
+			return count, nil, fmt.Errorf("%w: entry %d: %w", ErrInvalidExport, count, err)
+		}
+		if count > 0 && (l1.Number <= prevL1.Number || l2.Number < prevL2.Number) {
+			return count, nil, fmt.Errorf("%w: entry %d (l1 %v, safe head %v) does not follow l1 %v, safe head %v",
+				ErrInvalidExport, count, l1, l2, prevL1, prevL2)
+		}
+		if err := batch.Set(key, rec[8:], d.writeOpts); err != nil {
+			return count, nil, fmt.Errorf("failed to import entry %d: %w", count, err)
+		}
+		// reservoir sampling, to pick spot checks uniformly without knowing the number of entries upfront
+		if entry := (eth.SafeHeadResponse{L1Block: l1, SafeHead: l2}); len(samples) < sampleSize {
+			samples = append(samples, entry)
+		} else if i := rand.Int63n(int64(count + 1)); i < int64(sampleSize) {
+			samples[i] = entry
+		}
+		prevL1, prevL2 = l1, l2
+		count++
+		if count%importBatchSize == 0 {
+			if err := commit(); err != nil {
+				return count, nil, err
+			}
+		}
+	}
+
+	var footer [8]byte
+	if _, err := io.ReadFull(in, footer[:]); err != nil {
+		return count, nil, fmt.Errorf("%w: failed to read footer: %w", ErrInvalidExport, err)
+	}
+	expectedSum := checksum.Sum(nil)
+	var sum [32]byte
+	if _, err := io.ReadFull(in.r, sum[:]); err != nil {
+		return count, nil, fmt.Errorf("%w: failed to read checksum: %w", ErrInvalidExport, err)
+	}
+	if !bytes.Equal(sum[:], expectedSum) {
+		return count, nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidExport)
+	}
+	if expectedCount := binary.BigEndian.Uint64(footer[:]); expectedCount != count {
+		return count, nil, fmt.Errorf("%w: read %d entries but expected %d", ErrInvalidExport, count, expectedCount)
+	}
+	if err := commit(); err != nil {
+		return count, nil, err
+	}
+	return count, samples, nil
+}
+
+// checksumReader hashes all bytes read through it.
+type checksumReader struct {
+	r io.Reader
+	h hash.Hash
+}
+
+func (c *checksumReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.h.Write(p[:n])
+	return n, err
+}
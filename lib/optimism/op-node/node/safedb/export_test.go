@@ -0,0 +1,124 @@
+package safedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func exportTestDB(t *testing.T, entries int) (*SafeDB, []eth.SafeHeadResponse) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	var expected []eth.SafeHeadResponse
+	for i := 0; i < entries; i++ {
+		l1 := eth.BlockID{Hash: common.Hash{0x01, byte(i), byte(i >> 8)}, Number: 100 + uint64(i)*2}
+		l2 := eth.L2BlockRef{Hash: common.Hash{0x02, byte(i), byte(i >> 8)}, Number: 20 + uint64(i)*3}
+		require.NoError(t, db.SafeHeadUpdated(l2, l1))
+		expected = append(expected, eth.SafeHeadResponse{L1Block: l1, SafeHead: l2.ID()})
+	}
+	return db, expected
+}
+
+func emptyTestDB(t *testing.T) *SafeDB {
+	db, err := NewSafeDB(testlog.Logger(t, log.LvlInfo), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestExportImport(t *testing.T) {
+	src, expected := exportTestDB(t, importBatchSize+5)
+	var buf bytes.Buffer
+	count, err := src.Export(context.Background(), &buf)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(expected)), count)
+
+	dst := emptyTestDB(t)
+	var checked []eth.SafeHeadResponse
+	check := func(_ context.Context, l1 eth.BlockID, safeHead eth.BlockID) error {
+		checked = append(checked, eth.SafeHeadResponse{L1Block: l1, SafeHead: safeHead})
+		return nil
+	}
+	count, err = dst.Import(context.Background(), bytes.NewReader(buf.Bytes()), 10, check)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(expected)), count)
+	require.Len(t, checked, 10)
+	for _, c := range checked {
+		require.Contains(t, expected, c)
+	}
+
+	actual, err := dst.SafeHeadsInRange(context.Background(), 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	// Only empty databases can be imported into
+	_, err = dst.Import(context.Background(), bytes.NewReader(buf.Bytes()), 0, nil)
+	require.ErrorIs(t, err, ErrNotEmpty)
+}
+
+func TestExportImportEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	count, err := emptyTestDB(t).Export(context.Background(), &buf)
+	require.NoError(t, err)
+	require.Zero(t, count)
+	count, err = emptyTestDB(t).Import(context.Background(), &buf, 10, nil)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestImportInvalid(t *testing.T) {
+	src, _ := exportTestDB(t, 5)
+	var buf bytes.Buffer
+	_, err := src.Export(context.Background(), &buf)
+	require.NoError(t, err)
+	export := buf.Bytes()
+	recordOffset := func(i int) int { return 9 + i*(1+recordSize) }
+
+	requireImportFails := func(t *testing.T, data []byte, check SpotCheckFn, expectedErr error) {
+		db := emptyTestDB(t)
+		_, err := db.Import(context.Background(), bytes.NewReader(data), 5, check)
+		require.ErrorIs(t, err, expectedErr)
+		// Nothing is left behind
+		entries, err := db.SafeHeadsInRange(context.Background(), 0, math.MaxUint64)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	}
+
+	t.Run("Truncated", func(t *testing.T) {
+		requireImportFails(t, export[:len(export)-1], nil, ErrInvalidExport)
+	})
+	t.Run("BadMagic", func(t *testing.T) {
+		data := bytes.Clone(export)
+		data[0] = 'x'
+		requireImportFails(t, data, nil, ErrInvalidExport)
+	})
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		data := bytes.Clone(export)
+		data[recordOffset(2)+20]++ // part of the L1 hash
+		requireImportFails(t, data, nil, ErrInvalidExport)
+	})
+	t.Run("Discontinuity", func(t *testing.T) {
+		// Swap two records, so that the L1 block numbers decrease
+		data := bytes.Clone(export)
+		a, b := recordOffset(1), recordOffset(2)
+		recA := bytes.Clone(data[a:b])
+		copy(data[a:b], data[b:b+len(recA)])
+		copy(data[b:], recA)
+		requireImportFails(t, data, nil, ErrInvalidExport)
+	})
+	t.Run("SpotCheckFailure", func(t *testing.T) {
+		errMismatch := errors.New("mismatch")
+		check := func(context.Context, eth.BlockID, eth.BlockID) error { return errMismatch }
+		requireImportFails(t, export, check, errMismatch)
+	})
+}
@@ -3,6 +3,7 @@ package safedb
 import (
 	"context"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -21,11 +22,16 @@ var (
 
 const (
 	// Keys are prefixed with a constant byte to allow us to differentiate different "columns" within the data
-	keyPrefixSafeByL1BlockNum byte = 0
+	keyPrefixSafeByL1BlockNum        byte = 0
+	keyPrefixDerivationInfoByL2Block byte = 1
 )
 
+// pruneInterval is the minimum number of L1 blocks between pruning runs when a retention is configured.
+const pruneInterval = 100
+
 var (
-	safeByL1BlockNumKey = uint64Key{prefix: keyPrefixSafeByL1BlockNum}
+	safeByL1BlockNumKey        = uint64Key{prefix: keyPrefixSafeByL1BlockNum}
+	derivationInfoByL2BlockKey = uint64Key{prefix: keyPrefixDerivationInfoByL2Block}
 )
 
 type uint64Key struct {
@@ -58,6 +64,11 @@ type SafeDB struct {
 
 	writeOpts *pebble.WriteOptions
 
+	// retention is the number of L1 blocks to keep entries for, or 0 to keep all entries.
+	retention uint64
+	// prunedBefore is the L1 block number that entries were last pruned before.
+	prunedBefore uint64
+
 	closed bool
 }
 
@@ -82,6 +93,12 @@ func decodeSafeByL1BlockNum(key []byte, val []byte) (l1 eth.BlockID, l2 eth.Bloc
 }
 
 func NewSafeDB(logger log.Logger, path string) (*SafeDB, error) {
+	return NewSafeDBWithRetention(logger, path, 0)
+}
+
+// NewSafeDBWithRetention opens a SafeDB that prunes entries older than the given number of L1 blocks.
+// A retention of 0 keeps all entries.
+func NewSafeDBWithRetention(logger log.Logger, path string, retention uint64) (*SafeDB, error) {
 	db, err := pebble.Open(path, &pebble.Options{})
 	if err != nil {
 		return nil, err
@@ -90,6 +107,7 @@ func NewSafeDB(logger log.Logger, path string) (*SafeDB, error) {
 		log:       logger,
 		db:        db,
 		writeOpts: &pebble.WriteOptions{Sync: true},
+		retention: retention,
 	}, nil
 }
 
@@ -109,12 +127,67 @@ func (d *SafeDB) SafeHeadUpdated(safeHead eth.L2BlockRef, l1Head eth.BlockID) er
 	if err := batch.Commit(d.writeOpts); err != nil {
 		return fmt.Errorf("failed to commit safe head update: %w", err)
 	}
+	if d.retention > 0 && l1Head.Number > d.retention {
+		if cutoff := l1Head.Number - d.retention; cutoff >= d.prunedBefore+pruneInterval || d.prunedBefore == 0 {
+			if err := d.prune(cutoff); err != nil {
+				// The update itself was recorded, pruning is retried with the next update.
+				d.log.Warn("Failed to prune safe head database", "cutoff", cutoff, "err", err)
+			} else {
+				d.prunedBefore = cutoff
+			}
+		}
+	}
+	return nil
+}
+
+// prune deletes all entries of L1 blocks before cutoff, and the derivation info of the
+// L2 blocks that became safe in them. The last entry before cutoff is kept, because it
+// is still the safe head at the L1 blocks from it up to the next entry.
+func (d *SafeDB) prune(cutoff uint64) error {
+	iter, err := d.db.NewIter(safeByL1BlockNumKey.IterRange())
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	if valid := iter.SeekLT(safeByL1BlockNumKey.Of(cutoff)); !valid {
+		// Nothing to prune
+		return nil
+	}
+	keep := slices.Clone(iter.Key())
+	if valid := iter.Prev(); !valid {
+		// Only the entry to keep is before cutoff
+		return nil
+	}
+	val, err := iter.ValueAndErr()
+	if err != nil {
+		return fmt.Errorf("failed to read entry: %w", err)
+	}
+	_, lastPrunedL2, err := decodeSafeByL1BlockNum(iter.Key(), val)
+	if err != nil {
+		return fmt.Errorf("encountered invalid entry: %w", err)
+	}
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	if err := batch.DeleteRange(safeByL1BlockNumKey.Of(0), keep, d.writeOpts); err != nil {
+		return fmt.Errorf("failed to delete entries before %v: %w", cutoff, err)
+	}
+	if err := batch.DeleteRange(derivationInfoByL2BlockKey.Of(0), derivationInfoByL2BlockKey.Of(lastPrunedL2.Number+1), d.writeOpts); err != nil {
+		return fmt.Errorf("failed to delete derivation info up to %v: %w", lastPrunedL2.Number, err)
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("failed to commit pruning: %w", err)
+	}
+	d.log.Info("Pruned safe head database", "before_l1", cutoff, "up_to_l2", lastPrunedL2.Number)
 	return nil
 }
 
 func (d *SafeDB) SafeHeadReset(safeHead eth.L2BlockRef) error {
 	d.m.Lock()
 	defer d.m.Unlock()
+	// Blocks after the new safe head will be derived again, possibly from different L1 data.
+	if err := d.db.DeleteRange(derivationInfoByL2BlockKey.Of(safeHead.Number+1), derivationInfoByL2BlockKey.Max(), d.writeOpts); err != nil {
+		return fmt.Errorf("reset failed to delete derivation info after %v: %w", safeHead.Number, err)
+	}
 	iter, err := d.db.NewIter(safeByL1BlockNumKey.IterRange())
 	if err != nil {
 		return fmt.Errorf("reset failed to create iterator: %w", err)
@@ -184,6 +257,138 @@ func (d *SafeDB) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block e
 	return
 }
 
+// SafeHeadsInRange returns all safe head updates recorded for L1 blocks from start to end, inclusive,
+// in ascending order.
+func (d *SafeDB) SafeHeadsInRange(ctx context.Context, start uint64, end uint64) ([]eth.SafeHeadResponse, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	upper := safeByL1BlockNumKey.Max()
+	if end < math.MaxUint64 {
+		upper = safeByL1BlockNumKey.Of(end + 1)
+	}
+	iter, err := d.db.NewIterWithContext(ctx, &pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey.Of(start),
+		UpperBound: upper,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var out []eth.SafeHeadResponse
+	for valid := iter.First(); valid; valid = iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, err
+		}
+		l1Block, safeHead, err := decodeSafeByL1BlockNum(iter.Key(), val)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, eth.SafeHeadResponse{L1Block: l1Block, SafeHead: safeHead})
+	}
+	return out, nil
+}
+
+// L1BlockAtSafeHead returns the first L1 block at which the L2 block with the given number was safe,
+// together with the safe head recorded at that L1 block.
+// ErrNotFound is returned if the L2 block is not safe yet, or already was before the first recorded entry.
+func (d *SafeDB) L1BlockAtSafeHead(ctx context.Context, l2BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	iter, err := d.db.NewIterWithContext(ctx, safeByL1BlockNumKey.IterRange())
+	if err != nil {
+		return
+	}
+	defer iter.Close()
+	current := func() (eth.BlockID, eth.BlockID, error) {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return eth.BlockID{}, eth.BlockID{}, err
+		}
+		return decodeSafeByL1BlockNum(iter.Key(), val)
+	}
+	// entryAt returns the entry at or before the given L1 block.
+	entryAt := func(l1BlockNum uint64) (eth.BlockID, eth.BlockID, error) {
+		if valid := iter.SeekLT(safeByL1BlockNumKey.Of(l1BlockNum + 1)); !valid {
+			return eth.BlockID{}, eth.BlockID{}, ErrNotFound
+		}
+		return current()
+	}
+	if valid := iter.Last(); !valid {
+		err = ErrNotFound
+		return
+	}
+	last, lastSafe, err := current()
+	if err != nil {
+		return
+	}
+	if lastSafe.Number < l2BlockNum {
+		err = ErrNotFound
+		return
+	}
+	iter.First()
+	first, firstSafe, err := current()
+	if err != nil {
+		return
+	}
+	if firstSafe.Number >= l2BlockNum {
+		if firstSafe.Number > l2BlockNum {
+			// The block was already safe before the first entry, so we don't know when it became safe.
+			err = ErrNotFound
+			return
+		}
+		return first, firstSafe, nil
+	}
+	// The safe head only increases with the L1 block number, so binary search for the first entry
+	// with a safe head at or after the L2 block. The entry at lo is before it, the entry at hi is not.
+	lo, hi := first.Number, last.Number
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		l1, safe, err := entryAt(mid)
+		if err != nil {
+			return eth.BlockID{}, eth.BlockID{}, err
+		}
+		if safe.Number >= l2BlockNum {
+			hi = l1.Number
+		} else {
+			lo = mid
+		}
+	}
+	return entryAt(hi)
+}
+
+// DerivationInfoUpdated records the L1 data that a new safe block was derived from.
+func (d *SafeDB) DerivationInfoUpdated(info eth.DerivationInfo) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	val, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("failed to encode derivation info: %w", err)
+	}
+	if err := d.db.Set(derivationInfoByL2BlockKey.Of(info.L2Block.Number), val, d.writeOpts); err != nil {
+		return fmt.Errorf("failed to record derivation info: %w", err)
+	}
+	return nil
+}
+
+// DerivationInfoAtBlock returns the L1 data that the safe L2 block with the given number was derived from.
+func (d *SafeDB) DerivationInfoAtBlock(ctx context.Context, l2BlockNum uint64) (info eth.DerivationInfo, err error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	val, closer, err := d.db.Get(derivationInfoByL2BlockKey.Of(l2BlockNum))
+	if errors.Is(err, pebble.ErrNotFound) {
+		err = ErrNotFound
+		return
+	} else if err != nil {
+		return
+	}
+	defer closer.Close()
+	if err = json.Unmarshal(val, &info); err != nil {
+		err = fmt.Errorf("%w: %w", ErrInvalidEntry, err)
+	}
+	return
+}
+
 func (d *SafeDB) Close() error {
 	d.m.Lock()
 	defer d.m.Unlock()
@@ -83,6 +83,128 @@ func TestSafeHeadAtL1_EmptyDatabase(t *testing.T) {
 	require.ErrorIs(t, err, ErrNotFound)
 }
 
+func TestSafeHeadsInRange(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	safeHeads, err := db.SafeHeadsInRange(context.Background(), 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Empty(t, safeHeads)
+
+	var expected []eth.SafeHeadResponse
+	for i := uint64(0); i < 5; i++ {
+		l1 := eth.BlockID{Hash: common.Hash{0x01, byte(i)}, Number: 100 + i*2}
+		l2 := eth.L2BlockRef{Hash: common.Hash{0x02, byte(i)}, Number: 20 + i*3}
+		require.NoError(t, db.SafeHeadUpdated(l2, l1))
+		expected = append(expected, eth.SafeHeadResponse{L1Block: l1, SafeHead: l2.ID()})
+	}
+
+	safeHeads, err = db.SafeHeadsInRange(context.Background(), 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, expected, safeHeads)
+
+	// Range is inclusive at both ends
+	safeHeads, err = db.SafeHeadsInRange(context.Background(), 102, 106)
+	require.NoError(t, err)
+	require.Equal(t, expected[1:4], safeHeads)
+
+	safeHeads, err = db.SafeHeadsInRange(context.Background(), 103, 103)
+	require.NoError(t, err)
+	require.Empty(t, safeHeads)
+}
+
+func TestL1BlockAtSafeHead(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, _, err = db.L1BlockAtSafeHead(context.Background(), 10)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// L1 block 100+i*2 makes L2 block 20+i*3 safe
+	for i := uint64(0); i < 50; i++ {
+		l1 := eth.BlockID{Hash: common.Hash{0x01, byte(i)}, Number: 100 + i*2}
+		l2 := eth.L2BlockRef{Hash: common.Hash{0x02, byte(i)}, Number: 20 + i*3}
+		require.NoError(t, db.SafeHeadUpdated(l2, l1))
+	}
+
+	// Already safe before the first entry
+	_, _, err = db.L1BlockAtSafeHead(context.Background(), 19)
+	require.ErrorIs(t, err, ErrNotFound)
+	// Not safe yet
+	_, _, err = db.L1BlockAtSafeHead(context.Background(), 20+49*3+1)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	for l2Num := uint64(20); l2Num <= 20+49*3; l2Num++ {
+		// First entry with a safe head at or after the L2 block
+		i := (l2Num - 20 + 2) / 3
+		l1, safeHead, err := db.L1BlockAtSafeHead(context.Background(), l2Num)
+		require.NoError(t, err)
+		require.Equal(t, eth.BlockID{Hash: common.Hash{0x01, byte(i)}, Number: 100 + i*2}, l1, "l2 block %v", l2Num)
+		require.Equal(t, eth.BlockID{Hash: common.Hash{0x02, byte(i)}, Number: 20 + i*3}, safeHead)
+	}
+}
+
+func TestPruneWithRetention(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDBWithRetention(logger, t.TempDir(), 150)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for l1Num := uint64(1); l1Num <= 300; l1Num++ {
+		l2 := eth.L2BlockRef{Hash: common.Hash{0x02, byte(l1Num)}, Number: l1Num * 2}
+		require.NoError(t, db.SafeHeadUpdated(l2, eth.BlockID{Hash: common.Hash{0x01, byte(l1Num)}, Number: l1Num}))
+		require.NoError(t, db.DerivationInfoUpdated(eth.DerivationInfo{L2Block: l2.ID()}))
+	}
+
+	// Pruned before L1 block 101 when L1 block 251 was recorded, and not again since.
+	// The last entry before the cutoff is kept.
+	_, _, err = db.SafeHeadAtL1(context.Background(), 99)
+	require.ErrorIs(t, err, ErrNotFound)
+	l1, _, err := db.SafeHeadAtL1(context.Background(), 100)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), l1.Number)
+	l1, _, err = db.SafeHeadAtL1(context.Background(), 101)
+	require.NoError(t, err)
+	require.Equal(t, uint64(101), l1.Number)
+	_, err = db.DerivationInfoAtBlock(context.Background(), 198)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = db.DerivationInfoAtBlock(context.Background(), 200)
+	require.NoError(t, err)
+}
+
+func TestPruneKeepsSafeHeadAtCutoff(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDBWithRetention(logger, t.TempDir(), 100)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Sparse updates, the safe head at L1 blocks 50 up to 249 is the one recorded at L1 block 50.
+	for _, l1Num := range []uint64{10, 50, 250} {
+		l2 := eth.L2BlockRef{Hash: common.Hash{0x02, byte(l1Num)}, Number: l1Num * 2}
+		require.NoError(t, db.SafeHeadUpdated(l2, eth.BlockID{Hash: common.Hash{0x01, byte(l1Num)}, Number: l1Num}))
+		require.NoError(t, db.DerivationInfoUpdated(eth.DerivationInfo{L2Block: l2.ID()}))
+	}
+
+	// Pruned before L1 block 150 when L1 block 250 was recorded.
+	l1, l2, err := db.SafeHeadAtL1(context.Background(), 150)
+	require.NoError(t, err)
+	require.Equal(t, uint64(50), l1.Number)
+	require.Equal(t, uint64(100), l2.Number)
+	l1, _, err = db.SafeHeadAtL1(context.Background(), 149)
+	require.NoError(t, err)
+	require.Equal(t, uint64(50), l1.Number)
+	_, _, err = db.SafeHeadAtL1(context.Background(), 49)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = db.DerivationInfoAtBlock(context.Background(), 20)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = db.DerivationInfoAtBlock(context.Background(), 100)
+	require.NoError(t, err)
+}
+
 func TestTruncateOnSafeHeadReset(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	dir := t.TempDir()
@@ -367,3 +489,52 @@ func TestDecodeSafeByL1BlockNum(t *testing.T) {
 		require.ErrorIs(t, err, ErrInvalidEntry)
 	})
 }
+
+func TestStoreDerivationInfo(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	blobIndex := uint64(2)
+	info := func(num uint64) eth.DerivationInfo {
+		return eth.DerivationInfo{
+			L2Block:   eth.BlockID{Hash: common.Hash{0x02, byte(num)}, Number: num},
+			L1Block:   eth.BlockID{Hash: common.Hash{0x01, byte(num)}, Number: 100 + num},
+			ChannelID: []byte{0x03, byte(num)},
+			BatchKind: eth.SpanBatchKind,
+			Frames: []eth.DerivationFrame{
+				{FrameNumber: 0, InclusionBlock: eth.BlockID{Hash: common.Hash{0x01}, Number: 99 + num}, TxHash: common.Hash{0x04, byte(num)}},
+				{FrameNumber: 1, InclusionBlock: eth.BlockID{Hash: common.Hash{0x01, byte(num)}, Number: 100 + num}, TxHash: common.Hash{0x05, byte(num)}, BlobIndex: &blobIndex},
+			},
+		}
+	}
+	for _, num := range []uint64{20, 21, 22} {
+		require.NoError(t, db.DerivationInfoUpdated(info(num)))
+	}
+
+	verify := func(db *SafeDB, present []uint64, absent []uint64) {
+		for _, num := range present {
+			actual, err := db.DerivationInfoAtBlock(context.Background(), num)
+			require.NoError(t, err)
+			require.Equal(t, info(num), actual)
+		}
+		for _, num := range absent {
+			_, err := db.DerivationInfoAtBlock(context.Background(), num)
+			require.ErrorIs(t, err, ErrNotFound)
+		}
+	}
+	verify(db, []uint64{20, 21, 22}, []uint64{19, 23})
+
+	// Should still be available after reopening the database
+	require.NoError(t, db.Close())
+	db, err = NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+	verify(db, []uint64{20, 21, 22}, []uint64{19, 23})
+
+	// Blocks after the reset safe head are removed
+	require.NoError(t, db.SafeHeadReset(eth.L2BlockRef{Number: 20, L1Origin: eth.BlockID{Number: 100}}))
+	verify(db, []uint64{20}, []uint64{21, 22})
+}
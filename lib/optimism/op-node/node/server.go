@@ -15,6 +15,7 @@ func newRPCServer(rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthCli
 		oprpc.WithLogger(log),
 		oprpc.WithCORSHosts([]string{"*"}), // CORS is not important on op-node, but we used to do this on the old op-node RPC server, so kept for compatibility.
 		oprpc.WithRPCRecorder(metrics.NewRecorder("main")),
+		oprpc.WithWebsocketEnabled(), // for optimism_subscribe
 	)
 	api := NewNodeAPI(rollupCfg, l2Client, dr, safeDB, log)
 	server.AddAPI(rpc.API{
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -14,11 +15,15 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
@@ -245,6 +250,136 @@ func TestSafeHeadAtL1Block(t *testing.T) {
 	safeReader.Mock.AssertExpectations(t)
 }
 
+func TestSafeHeadsInRangeAndL1BlockAtSafeHead(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	safeHeads := []eth.SafeHeadResponse{
+		{L1Block: eth.BlockID{Hash: common.Hash{0xd1}, Number: 101}, SafeHead: eth.BlockID{Hash: common.Hash{0xe1}, Number: 220}},
+		{L1Block: eth.BlockID{Hash: common.Hash{0xd2}, Number: 104}, SafeHead: eth.BlockID{Hash: common.Hash{0xe2}, Number: 223}},
+	}
+	safeReader.ExpectSafeHeadsInRange(100, 110, safeHeads, nil)
+	safeReader.ExpectL1BlockAtSafeHead(222, safeHeads[1].L1Block, safeHeads[1].SafeHead, nil)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	m := &opmetrics.NoopRPCMetrics{}
+	server := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, m, "0.0")
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop())
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Endpoint(), rpcclient.WithDialAttempts(3))
+	require.NoError(t, err)
+
+	var outRange []eth.SafeHeadResponse
+	err = client.CallContext(context.Background(), &outRange, "optimism_safeHeadsInRange", hexutil.Uint64(100).String(), hexutil.Uint64(110).String())
+	require.NoError(t, err)
+	require.Equal(t, safeHeads, outRange)
+
+	err = client.CallContext(context.Background(), &outRange, "optimism_safeHeadsInRange", hexutil.Uint64(110).String(), hexutil.Uint64(100).String())
+	require.ErrorContains(t, err, "invalid range")
+
+	var out *eth.SafeHeadResponse
+	err = client.CallContext(context.Background(), &out, "optimism_l1BlockAtSafeHead", hexutil.Uint64(222).String())
+	require.NoError(t, err)
+	require.Equal(t, &safeHeads[1], out)
+	safeReader.Mock.AssertExpectations(t)
+}
+
+func TestDerivationInfoAtBlock(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	l2BlockNum := uint64(223)
+	blobIndex := uint64(3)
+	expected := &eth.DerivationInfo{
+		L2Block:   eth.BlockID{Hash: common.Hash{0xee}, Number: l2BlockNum},
+		L1Block:   eth.BlockID{Hash: common.Hash{0xdd}, Number: 5221},
+		ChannelID: hexutil.Bytes{0x01, 0x02},
+		BatchKind: eth.SpanBatchKind,
+		Frames: []eth.DerivationFrame{
+			{FrameNumber: 0, InclusionBlock: eth.BlockID{Hash: common.Hash{0xcc}, Number: 5220}, TxHash: common.Hash{0xaa}},
+			{FrameNumber: 1, InclusionBlock: eth.BlockID{Hash: common.Hash{0xdd}, Number: 5221}, TxHash: common.Hash{0xbb}, BlobIndex: &blobIndex},
+		},
+	}
+	safeReader.ExpectDerivationInfoAtBlock(l2BlockNum, *expected, nil)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	m := &opmetrics.NoopRPCMetrics{}
+	server := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, m, "0.0")
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop())
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Endpoint(), rpcclient.WithDialAttempts(3))
+	require.NoError(t, err)
+
+	var out *eth.DerivationInfo
+	err = client.CallContext(context.Background(), &out, "optimism_derivationInfoAtBlock", hexutil.Uint64(l2BlockNum).String())
+	require.NoError(t, err)
+	require.Equal(t, expected, out)
+	safeReader.Mock.AssertExpectations(t)
+}
+
+func TestSubscribeHeads(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{}
+	m := &opmetrics.NoopRPCMetrics{}
+	server := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, m, "0.0")
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop())
+	}()
+
+	st := status.NewStatusTracker(log, metrics.NoopMetrics)
+	drClient.Mock.On("SubscribeHeads", status.UnsafeHeadTopic).Return(st)
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "ws://"+server.Endpoint(), rpcclient.WithDialAttempts(3))
+	require.NoError(t, err)
+	defer client.Close()
+	rollupClient := sources.NewRollupClient(client)
+
+	ch := make(chan eth.L2BlockRef, 1)
+	sub, err := rollupClient.SubscribeHeads(context.Background(), "unsafe", ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	_, err = rollupClient.SubscribeHeads(context.Background(), "foo", make(chan eth.L2BlockRef))
+	require.ErrorContains(t, err, "no \"foo\" subscription")
+
+	ref := testutils.RandomL2BlockRef(rand.New(rand.NewSource(123)))
+	// the subscription is registered with the status tracker before it is confirmed to the client
+	st.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: ref})
+	select {
+	case got := <-ch:
+		require.Equal(t, ref, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification")
+	}
+}
+
 type mockDriverClient struct {
 	mock.Mock
 }
@@ -278,6 +413,20 @@ func (c *mockDriverClient) SequencerActive(ctx context.Context) (bool, error) {
 	return c.Mock.MethodCalled("SequencerActive").Get(0).(bool), nil
 }
 
+func (c *mockDriverClient) StopSequencerAt(ctx context.Context, target eth.SequencerStopTarget) (common.Hash, error) {
+	return c.Mock.MethodCalled("StopSequencerAt", target).Get(0).(common.Hash), nil
+}
+
+func (c *mockDriverClient) StartSequencerAt(ctx context.Context, blockHash common.Hash) error {
+	c.Mock.MethodCalled("StartSequencerAt", blockHash)
+	return nil
+}
+
+func (c *mockDriverClient) CancelSequencerHandoff(ctx context.Context) error {
+	c.Mock.MethodCalled("CancelSequencerHandoff")
+	return nil
+}
+
 func (c *mockDriverClient) OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
 	return c.Mock.MethodCalled("OnUnsafeL2Payload").Get(0).(error)
 }
@@ -295,6 +444,11 @@ func (c *mockDriverClient) SetRecoverMode(ctx context.Context, mode bool) error
 	return nil
 }
 
+func (c *mockDriverClient) SubscribeHeads(topic status.HeadTopic) (*status.HeadSubscription, error) {
+	m := c.Mock.MethodCalled("SubscribeHeads", topic)
+	return m[0].(*status.StatusTracker).SubscribeHeads(topic)
+}
+
 type mockSafeDBReader struct {
 	mock.Mock
 }
@@ -307,3 +461,30 @@ func (m *mockSafeDBReader) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64)
 func (m *mockSafeDBReader) ExpectSafeHeadAtL1(l1BlockNum uint64, l1 eth.BlockID, safeHead eth.BlockID, err error) {
 	m.Mock.On("SafeHeadAtL1", l1BlockNum).Return(l1, safeHead, &err)
 }
+
+func (m *mockSafeDBReader) SafeHeadsInRange(ctx context.Context, start uint64, end uint64) ([]eth.SafeHeadResponse, error) {
+	r := m.Mock.MethodCalled("SafeHeadsInRange", start, end)
+	return r[0].([]eth.SafeHeadResponse), *r[1].(*error)
+}
+
+func (m *mockSafeDBReader) ExpectSafeHeadsInRange(start uint64, end uint64, safeHeads []eth.SafeHeadResponse, err error) {
+	m.Mock.On("SafeHeadsInRange", start, end).Return(safeHeads, &err)
+}
+
+func (m *mockSafeDBReader) L1BlockAtSafeHead(ctx context.Context, l2BlockNum uint64) (l1 eth.BlockID, l2 eth.BlockID, err error) {
+	r := m.Mock.MethodCalled("L1BlockAtSafeHead", l2BlockNum)
+	return r[0].(eth.BlockID), r[1].(eth.BlockID), *r[2].(*error)
+}
+
+func (m *mockSafeDBReader) ExpectL1BlockAtSafeHead(l2BlockNum uint64, l1 eth.BlockID, safeHead eth.BlockID, err error) {
+	m.Mock.On("L1BlockAtSafeHead", l2BlockNum).Return(l1, safeHead, &err)
+}
+
+func (m *mockSafeDBReader) DerivationInfoAtBlock(ctx context.Context, l2BlockNum uint64) (eth.DerivationInfo, error) {
+	r := m.Mock.MethodCalled("DerivationInfoAtBlock", l2BlockNum)
+	return r[0].(eth.DerivationInfo), *r[1].(*error)
+}
+
+func (m *mockSafeDBReader) ExpectDerivationInfoAtBlock(l2BlockNum uint64, info eth.DerivationInfo, err error) {
+	m.Mock.On("DerivationInfoAtBlock", l2BlockNum).Return(info, &err)
+}
@@ -58,7 +58,9 @@ type GossipSetupConfigurables interface {
 }
 
 type GossipRuntimeConfig interface {
-	P2PSequencerAddress() common.Address
+	// P2PSequencerSigners returns the signers that are allowed to sign gossiped blocks,
+	// each within their own activation window.
+	P2PSequencerSigners() []opsigner.AuthorizedSigner
 }
 
 //go:generate mockery --name GossipMetricer
@@ -300,12 +302,6 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 		signature := eth.Bytes65(data[:65])
 		payloadBytes := data[65:]
 
-		// [REJECT] if the signature by the sequencer is not valid
-		result := verifyBlockSignature(log, cfg, runCfg, id, signature, payloadBytes)
-		if result != pubsub.ValidationAccept {
-			return result
-		}
-
 		var envelope eth.ExecutionPayloadEnvelope
 
 		// [REJECT] if the block encoding is not valid
@@ -325,6 +321,12 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 
 		payload := envelope.ExecutionPayload
 
+		// [REJECT] if the signature is not valid, or not by a signer that is active at the block timestamp
+		result := verifyBlockSignature(log, cfg, runCfg, id, signature, payloadBytes, uint64(payload.Timestamp))
+		if result != pubsub.ValidationAccept {
+			return result
+		}
+
 		// rounding down to seconds is fine here.
 		now := uint64(time.Now().Unix())
 
@@ -421,19 +423,20 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 		// but validator concurrency is limited anyway)
 		seen.markSeen(payload.BlockHash)
 
-		// remember the decoded payload for later usage in topic subscriber.
-		message.ValidatorData = &envelope
+		// remember the decoded payload and its signature for later usage in topic subscriber.
+		message.ValidatorData = &opsigner.SignedExecutionPayloadEnvelope{Envelope: &envelope, Signature: signature}
 		return pubsub.ValidationAccept
 	}
 }
 
-func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, id peer.ID, signature eth.Bytes65, payloadBytes []byte) pubsub.ValidationResult {
+func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, id peer.ID, signature eth.Bytes65, payloadBytes []byte, timestamp uint64) pubsub.ValidationResult {
 	authCtx := &opsigner.OPStackP2PBlockAuthV1{
-		Allowed: runCfg.P2PSequencerAddress(),
-		Chain:   eth.ChainIDFromBig(cfg.L2ChainID),
+		Signers:   runCfg.P2PSequencerSigners(),
+		Timestamp: timestamp,
+		Chain:     eth.ChainIDFromBig(cfg.L2ChainID),
 	}
-	if authCtx.Allowed == (common.Address{}) {
-		log.Warn("no configured p2p sequencer address, ignoring gossiped block", "peer", id, "addr", authCtx.Allowed)
+	if len(authCtx.Signers) == 0 {
+		log.Warn("no configured p2p sequencer address, ignoring gossiped block", "peer", id)
 		return pubsub.ValidationIgnore
 	}
 	block := opsigner.SignedP2PBlock{
@@ -449,6 +452,8 @@ func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 
 type GossipIn interface {
 	OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error
+	// OnSignedUnsafeL2Payload is called with gossiped payloads, together with their verified signature.
+	OnSignedUnsafeL2Payload(ctx context.Context, from peer.ID, msg *opsigner.SignedExecutionPayloadEnvelope) error
 }
 
 type GossipTopicInfo interface {
@@ -694,7 +699,7 @@ func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log l
 		return nil, fmt.Errorf("failed to subscribe to blocks gossip topic: %w", err)
 	}
 
-	subscriber := MakeSubscriber(log, BlocksHandler(gossipIn.OnUnsafeL2Payload))
+	subscriber := MakeSubscriber(log, BlocksHandler(gossipIn.OnSignedUnsafeL2Payload))
 	go subscriber(ctx, subscription)
 
 	return &blockTopic{
@@ -707,11 +712,11 @@ func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log l
 type TopicSubscriber func(ctx context.Context, sub *pubsub.Subscription)
 type MessageHandler func(ctx context.Context, from peer.ID, msg any) error
 
-func BlocksHandler(onBlock func(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error) MessageHandler {
+func BlocksHandler(onBlock func(ctx context.Context, from peer.ID, msg *opsigner.SignedExecutionPayloadEnvelope) error) MessageHandler {
 	return func(ctx context.Context, from peer.ID, msg any) error {
-		payload, ok := msg.(*eth.ExecutionPayloadEnvelope)
+		payload, ok := msg.(*opsigner.SignedExecutionPayloadEnvelope)
 		if !ok {
-			return fmt.Errorf("expected topic validator to parse and validate data into execution payload, but got %T", msg)
+			return fmt.Errorf("expected topic validator to parse and validate data into signed execution payload, but got %T", msg)
 		}
 		return onBlock(ctx, from, payload)
 	}
@@ -70,7 +70,7 @@ func TestVerifyBlockSignature(t *testing.T) {
 		signer := &PreparedSigner{Signer: opsigner.NewLocalSigner(secrets)}
 		sig, err := signer.SignBlockV1(context.Background(), eth.ChainIDFromBig(cfg.L2ChainID), opsigner.PayloadHash(msg))
 		require.NoError(t, err)
-		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg)
+		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg, 0)
 		require.Equal(t, pubsub.ValidationAccept, result)
 	})
 
@@ -79,14 +79,14 @@ func TestVerifyBlockSignature(t *testing.T) {
 		signer := &PreparedSigner{Signer: opsigner.NewLocalSigner(secrets)}
 		sig, err := signer.SignBlockV1(context.Background(), eth.ChainIDFromBig(cfg.L2ChainID), opsigner.PayloadHash(msg))
 		require.NoError(t, err)
-		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg)
+		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg, 0)
 		require.Equal(t, pubsub.ValidationReject, result)
 	})
 
 	t.Run("InvalidSignature", func(t *testing.T) {
 		runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: crypto.PubkeyToAddress(secrets.PublicKey)}
 		sig := eth.Bytes65{}
-		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg)
+		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg, 0)
 		require.Equal(t, pubsub.ValidationReject, result)
 	})
 
@@ -95,9 +95,28 @@ func TestVerifyBlockSignature(t *testing.T) {
 		signer := &PreparedSigner{Signer: opsigner.NewLocalSigner(secrets)}
 		sig, err := signer.SignBlockV1(context.Background(), eth.ChainIDFromBig(cfg.L2ChainID), opsigner.PayloadHash(msg))
 		require.NoError(t, err)
-		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg)
+		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg, 0)
 		require.Equal(t, pubsub.ValidationIgnore, result)
 	})
+
+	t.Run("SignerWindow", func(t *testing.T) {
+		activation, expiry := uint64(100), uint64(200)
+		runCfg := &testutils.MockRuntimeConfig{
+			P2PSeqAddress: common.HexToAddress("0x1234"),
+			P2PSigners: []opsigner.AuthorizedSigner{{
+				Address:        crypto.PubkeyToAddress(secrets.PublicKey),
+				ActivationTime: &activation,
+				ExpiryTime:     &expiry,
+			}},
+		}
+		signer := &PreparedSigner{Signer: opsigner.NewLocalSigner(secrets)}
+		sig, err := signer.SignBlockV1(context.Background(), eth.ChainIDFromBig(cfg.L2ChainID), opsigner.PayloadHash(msg))
+		require.NoError(t, err)
+		require.Equal(t, pubsub.ValidationReject, verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg, 99))
+		require.Equal(t, pubsub.ValidationAccept, verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg, 100))
+		require.Equal(t, pubsub.ValidationAccept, verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg, 199))
+		require.Equal(t, pubsub.ValidationReject, verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg, 200))
+	})
 }
 
 type MarshalSSZ interface {
@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
@@ -84,6 +85,10 @@ func (m *mockGossipIn) OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg
 	return nil
 }
 
+func (m *mockGossipIn) OnSignedUnsafeL2Payload(ctx context.Context, from peer.ID, msg *opsigner.SignedExecutionPayloadEnvelope) error {
+	return m.OnUnsafeL2Payload(ctx, from, msg.Envelope)
+}
+
 // Full setup, using negotiated transport security and muxes
 func TestP2PFull(t *testing.T) {
 	pA, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
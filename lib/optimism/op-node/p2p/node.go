@@ -151,6 +151,8 @@ func (n *NodeP2P) init(
 			// register the sync protocol with libp2p host
 			payloadByNumber := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_number"), n.syncSrv.HandleSyncRequest)
 			n.host.SetStreamHandler(PayloadByNumberProtocolID(rollupCfg.L2ChainID), payloadByNumber)
+			payloadsByRange := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_range"), n.syncSrv.HandleRangeRequest)
+			n.host.SetStreamHandler(PayloadsByRangeProtocolID(rollupCfg.L2ChainID), payloadsByRange)
 		}
 	}
 	n.scorer = NewScorer(rollupCfg, eps, metrics, n.appScorer, log)
@@ -150,6 +150,7 @@ func (r *requestIdMap) delete(key uint64) {
 
 type SyncClientMetrics interface {
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ClientPayloadsByRangeEvent(start uint64, received uint64, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
 }
 
@@ -181,6 +182,8 @@ type SyncPeerScorer interface {
 //
 // - Peers each have their own routine for processing requests.
 //   - They fetch the requested block by number, parse and validate it, and then send it back to the main loop
+//   - Consecutive requested blocks are fetched as a single range from peers that support the payloads_by_range protocol,
+//     every block of the range is validated and sent back to the main loop as it is received.
 //   - If peers fail to fetch or process it, or fail to send it back to the main loop within timeout,
 //     then the doRequest returns an error. It then marks the in-flight request as completed.
 //
@@ -233,6 +236,7 @@ type SyncClient struct {
 
 	newStreamFn     newStreamFn
 	payloadByNumber protocol.ID
+	payloadsByRange protocol.ID
 
 	peersLock sync.Mutex
 	// syncing worker per peer
@@ -291,6 +295,7 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, host HostNewStream, rcv r
 		appScorer:           appScorer,
 		newStreamFn:         host.NewStream,
 		payloadByNumber:     PayloadByNumberProtocolID(cfg.L2ChainID),
+		payloadsByRange:     PayloadsByRangeProtocolID(cfg.L2ChainID),
 		peers:               make(map[peer.ID]context.CancelFunc),
 		quarantineByNum:     make(map[uint64]common.Hash),
 		rangeRequests:       make(chan rangeRequest), // blocking
@@ -575,6 +580,12 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 		peerRequests = nil
 	}
 
+	// Peers that do not support the payloads_by_range protocol are requested blocks by number.
+	rangeSupported := true
+	rangeRL := rate.NewLimiter(peerServerRangeRateLimit, peerServerRangeBurst)
+	// requests taken from the queue, that are yet to be requested from this peer
+	var pending []peerRequest
+
 	for {
 		// wait for a global allocation to be available
 		if err := s.globalRL.Wait(ctx); err != nil {
@@ -586,57 +597,96 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 		}
 
 		// once the peer is available, wait for a sync request.
-		select {
-		case pr := <-peerRequests:
-			if !s.activeRangeRequests.get(pr.rangeReqId) {
-				log.Debug("dropping cancelled p2p sync request", "num", pr.num)
-				s.inFlight.delete(pr.num)
-				continue
+		var pr peerRequest
+		if len(pending) > 0 {
+			pr, pending = pending[0], pending[1:]
+		} else {
+			select {
+			case pr = <-peerRequests:
+			case <-ctx.Done():
+				return
 			}
+		}
+		if !s.activeRangeRequests.get(pr.rangeReqId) {
+			log.Debug("dropping cancelled p2p sync request", "num", pr.num)
+			s.inFlight.delete(pr.num)
+			continue
+		}
 
-			// We already established the peer is available w.r.t. rate-limiting,
-			// and this is the only loop over this peer, so we can request now.
-			start := time.Now()
-
-			resultCode := ResultCodeSuccess
-			err := panicGuard(s.doRequest)(ctx, id, pr.num)
-			if err != nil {
-				s.inFlight.delete(pr.num)
-				log.Warn("failed p2p sync request", "num", pr.num, "err", err)
-				resultCode = ResultCodeNotFoundErr
-				sendResponseError := true
-
-				if re, ok := err.(requestResultErr); ok {
-					resultCode = re.ResultCode()
-					if resultCode == ResultCodeNotFoundErr {
-						log.Warn("cancelling p2p sync range request", "rangeReqId", pr.rangeReqId)
-						s.activeRangeRequests.delete(pr.rangeReqId)
-						sendResponseError = false // don't penalize peer for this error
-					}
-				}
-
-				if sendResponseError {
-					s.appScorer.onResponseError(id)
-				}
-
-				// If we hit an error, then count it as many requests.
-				// We'd like to avoid making more requests for a while, so back off.
-				if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
-					return
-				}
-			} else {
-				log.Debug("completed p2p sync request", "num", pr.num)
-				s.appScorer.onValidResponse(id)
+		if rangeSupported && len(pending) == 0 {
+			nums, next := collectRange(pr, peerRequests)
+			if next != nil {
+				pending = append(pending, *next)
+			}
+			if err := rangeRL.Wait(ctx); err != nil {
+				return
+			}
+			err := s.requestRange(ctx, log, id, rl, nums, pr.rangeReqId)
+			if err == nil {
+				continue
 			}
+			if !errors.Is(err, errRangeNotSupported) {
+				return
+			}
+			log.Info("Peer does not support payloads by range, requesting payloads by number")
+			rangeSupported = false
+			// request the rest of the collected range by number, before any other requests
+			rest := make([]peerRequest, 0, len(nums)-1+len(pending))
+			for _, num := range nums[1:] {
+				rest = append(rest, peerRequest{num: num, rangeReqId: pr.rangeReqId})
+			}
+			pending = append(rest, pending...)
+		}
 
-			took := time.Since(start)
-			s.metrics.ClientPayloadByNumberEvent(pr.num, resultCode, took)
-		case <-ctx.Done():
+		if err := s.requestByNumber(ctx, log, id, rl, pr); err != nil {
 			return
 		}
 	}
 }
 
+// requestByNumber requests a single block by number from the peer.
+// An error is only returned if the context is done.
+func (s *SyncClient) requestByNumber(ctx context.Context, log log.Logger, id peer.ID, rl *rate.Limiter, pr peerRequest) error {
+	// We already established the peer is available w.r.t. rate-limiting,
+	// and this is the only loop over this peer, so we can request now.
+	start := time.Now()
+
+	resultCode := ResultCodeSuccess
+	err := panicGuard(s.doRequest)(ctx, id, pr.num)
+	if err != nil {
+		s.inFlight.delete(pr.num)
+		log.Warn("failed p2p sync request", "num", pr.num, "err", err)
+		resultCode = ResultCodeNotFoundErr
+		sendResponseError := true
+
+		if re, ok := err.(requestResultErr); ok {
+			resultCode = re.ResultCode()
+			if resultCode == ResultCodeNotFoundErr {
+				log.Warn("cancelling p2p sync range request", "rangeReqId", pr.rangeReqId)
+				s.activeRangeRequests.delete(pr.rangeReqId)
+				sendResponseError = false // don't penalize peer for this error
+			}
+		}
+
+		if sendResponseError {
+			s.appScorer.onResponseError(id)
+		}
+
+		// If we hit an error, then count it as many requests.
+		// We'd like to avoid making more requests for a while, so back off.
+		if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
+			return err
+		}
+	} else {
+		log.Debug("completed p2p sync request", "num", pr.num)
+		s.appScorer.onValidResponse(id)
+	}
+
+	took := time.Since(start)
+	s.metrics.ClientPayloadByNumberEvent(pr.num, resultCode, took)
+	return nil
+}
+
 type requestResultErr byte
 
 func (r requestResultErr) Error() string {
@@ -781,6 +831,18 @@ func verifyBlock(envelope *eth.ExecutionPayloadEnvelope, expectedNum uint64) err
 type peerStat struct {
 	// Requests tokenizes each request to sync
 	Requests *rate.Limiter
+	// RangeRequests tokenizes each request to sync a range of blocks
+	RangeRequests *rate.Limiter
+	// RangeBytes tokenizes each byte of range responses
+	RangeBytes *rate.Limiter
+}
+
+func newPeerStat() *peerStat {
+	return &peerStat{
+		Requests:      rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
+		RangeRequests: rate.NewLimiter(peerServerRangeRateLimit, peerServerRangeBurst),
+		RangeBytes:    rate.NewLimiter(peerServerRangeBytesRate, peerServerRangeBytesBurst),
+	}
 }
 
 type L2Chain interface {
@@ -789,6 +851,7 @@ type L2Chain interface {
 
 type ReqRespServerMetrics interface {
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ServerPayloadsByRangeEvent(start uint64, served uint64, resultCode byte, duration time.Duration)
 }
 
 type ReqRespServer struct {
@@ -802,6 +865,9 @@ type ReqRespServer struct {
 	peerStatsLock  sync.Mutex
 
 	globalRequestsRL *rate.Limiter
+
+	globalRangeRequestsRL *rate.Limiter
+	globalRangeBytesRL    *rate.Limiter
 }
 
 func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetrics) *ReqRespServer {
@@ -812,12 +878,26 @@ func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetri
 	globalRequestsRL := rate.NewLimiter(globalServerBlocksRateLimit, globalServerBlocksBurst)
 
 	return &ReqRespServer{
-		cfg:              cfg,
-		l2:               l2,
-		metrics:          metrics,
-		peerRateLimits:   peerRateLimits,
-		globalRequestsRL: globalRequestsRL,
+		cfg:                   cfg,
+		l2:                    l2,
+		metrics:               metrics,
+		peerRateLimits:        peerRateLimits,
+		globalRequestsRL:      globalRequestsRL,
+		globalRangeRequestsRL: rate.NewLimiter(globalServerRangeRateLimit, globalServerRangeBurst),
+		globalRangeBytesRL:    rate.NewLimiter(globalServerRangeBytesRate, globalServerRangeBytesBurst),
+	}
+}
+
+// peerStat finds the rate limiting data of the peer, or adds it otherwise.
+func (srv *ReqRespServer) peerStat(id peer.ID) *peerStat {
+	srv.peerStatsLock.Lock()
+	defer srv.peerStatsLock.Unlock()
+	ps, _ := srv.peerRateLimits.Get(id)
+	if ps == nil {
+		ps = newPeerStat()
+		srv.peerRateLimits.Add(id, ps)
 	}
+	return ps
 }
 
 // HandleSyncRequest is a stream handler function to register the L2 unsafe payloads alt-sync protocol.
@@ -869,9 +949,7 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	srv.peerStatsLock.Lock()
 	ps, _ := srv.peerRateLimits.Get(peerId)
 	if ps == nil {
-		ps = &peerStat{
-			Requests: rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
-		}
+		ps = newPeerStat()
 		srv.peerRateLimits.Add(peerId, ps)
 		ps.Requests.Reserve() // count the hit, but make it delay the next request rather than immediately waiting
 	} else {
@@ -0,0 +1,393 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	msmux "github.com/multiformats/go-multistream"
+	"golang.org/x/time/rate"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// The payloads_by_range protocol serves a range of consecutive payloads over a single stream.
+//
+// The request is the start block number and the number of requested blocks, both as little-endian uint64.
+// The response is a stream of chunks, one per payload, in ascending block number order, starting at the start block.
+// Each chunk consists of:
+//   - 1 byte result code
+//   - 4 byte little-endian version: 0 for an SSZ encoded payload, 1 for an SSZ encoded envelope (since Ecotone)
+//   - 4 byte little-endian length of the data
+//   - the SSZ encoded data, with Snappy block compression
+//
+// A chunk with a non-zero result code has no other content, and ends the response.
+// The server may serve fewer blocks than requested, and then ends the response by closing the stream.
+const (
+	// maxRangeRequestBlocks is the maximum number of blocks served for a single range request.
+	maxRangeRequestBlocks = 64
+	// maxRangeResponseBytes is the maximum number of compressed payload bytes served for a single range request.
+	// The first payload of the range is always served, even if it is larger.
+	maxRangeResponseBytes = 4 * maxGossipSize
+	// Do not serve more than 10 range requests per second
+	globalServerRangeRateLimit rate.Limit = 10
+	// Allows a burst of 2x our range rate limit
+	globalServerRangeBurst = 20
+	// Do not serve more than 2 range requests per second to the same peer
+	peerServerRangeRateLimit rate.Limit = 2
+	// Allow a peer to request 4 ranges at once
+	peerServerRangeBurst = 4
+	// Do not serve more than 40 MiB of range responses per second
+	globalServerRangeBytesRate  rate.Limit = 40 << 20
+	globalServerRangeBytesBurst            = 4 * maxGossipSize
+	// Do not serve more than 10 MiB of range responses per second to the same peer.
+	// The burst must fit the largest possible payload.
+	peerServerRangeBytesRate  rate.Limit = 10 << 20
+	peerServerRangeBytesBurst            = 2 * maxGossipSize
+)
+
+func PayloadsByRangeProtocolID(l2ChainID *big.Int) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/opstack/req/payloads_by_range/%d/0", l2ChainID))
+}
+
+// errRangeNotSupported is returned when a peer does not support the payloads_by_range protocol.
+var errRangeNotSupported = errors.New("peer does not support payloads by range")
+
+// collectRange takes the requests that directly follow pr in descending block number order
+// from the queue of requests, to request them as a single range, up to maxRangeRequestBlocks.
+// The returned numbers are in descending order.
+// The first request taken from the queue that does not extend the range is returned separately.
+func collectRange(pr peerRequest, requests <-chan peerRequest) (nums []uint64, next *peerRequest) {
+	nums = []uint64{pr.num}
+	for len(nums) < maxRangeRequestBlocks {
+		select {
+		case r := <-requests:
+			if r.rangeReqId == pr.rangeReqId && r.num+1 == nums[len(nums)-1] {
+				nums = append(nums, r.num)
+				continue
+			}
+			return nums, &r
+		default:
+			return nums, nil
+		}
+	}
+	return nums, nil
+}
+
+// requestRange requests the given block numbers, in descending order, as a single range from the peer.
+// Blocks that are not received are no longer marked as in-flight, to be requested again later.
+// An error is returned if the peer does not support ranges, or if the context is done.
+// The peer rate limiter rl is used to back off after a failed request.
+func (s *SyncClient) requestRange(ctx context.Context, log log.Logger, id peer.ID, rl *rate.Limiter, nums []uint64, rangeReqId uint64) error {
+	lowest := nums[len(nums)-1]
+	count := uint64(len(nums))
+
+	start := time.Now()
+	received, err := s.doRangeRequest(ctx, id, lowest, count)
+	if errors.Is(err, errRangeNotSupported) {
+		// the blocks remain in-flight, to be requested by number instead
+		return err
+	}
+	for _, num := range nums {
+		if num >= lowest+received {
+			s.inFlight.delete(num)
+		}
+	}
+
+	resultCode := ResultCodeSuccess
+	if err != nil {
+		log.Warn("failed p2p sync range request", "start", lowest, "count", count, "received", received, "err", err)
+		resultCode = ResultCodeNotFoundErr
+		sendResponseError := true
+
+		if re, ok := err.(requestResultErr); ok {
+			resultCode = re.ResultCode()
+			if resultCode == ResultCodeNotFoundErr {
+				log.Warn("cancelling p2p sync range request", "rangeReqId", rangeReqId)
+				s.activeRangeRequests.delete(rangeReqId)
+				sendResponseError = false // don't penalize peer for this error
+			}
+		}
+
+		if sendResponseError {
+			s.appScorer.onResponseError(id)
+		}
+
+		// If we hit an error, then count it as many requests.
+		// We'd like to avoid making more requests for a while, so back off.
+		if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
+			return err
+		}
+	} else {
+		log.Debug("completed p2p sync range request", "start", lowest, "count", count, "received", received)
+		s.appScorer.onValidResponse(id)
+	}
+
+	s.metrics.ClientPayloadsByRangeEvent(lowest, received, resultCode, time.Since(start))
+	return nil
+}
+
+// doRangeRequest requests count blocks, starting at the given block number, from the peer.
+// The received payloads are passed on to the main loop as they come in.
+// The number of received payloads is returned, also when the request fails partway.
+func (s *SyncClient) doRangeRequest(ctx context.Context, id peer.ID, start, count uint64) (received uint64, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("recovered from a panic: %v", r)
+		}
+	}()
+
+	// open stream to peer
+	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+	str, err := s.newStreamFn(reqCtx, id, s.payloadsByRange)
+	reqCancel()
+	if err != nil {
+		if errors.Is(err, msmux.ErrNotSupported[protocol.ID]{}) {
+			return 0, errRangeNotSupported
+		}
+		return 0, fmt.Errorf("failed to open stream: %w", err)
+	}
+	defer str.Close()
+	// set write timeout (if available)
+	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
+	var req [16]byte
+	binary.LittleEndian.PutUint64(req[:8], start)
+	binary.LittleEndian.PutUint64(req[8:], count)
+	if _, err := str.Write(req[:]); err != nil {
+		return 0, fmt.Errorf("failed to write range request (%d, %d): %w", start, count, err)
+	}
+	if err := str.CloseWrite(); err != nil {
+		return 0, fmt.Errorf("failed to close writer side while making request: %w", err)
+	}
+
+	var parent *eth.ExecutionPayload
+	for received < count {
+		expectedBlockNum := start + received
+		// reset the read timeout (if available) for every chunk
+		_ = str.SetReadDeadline(time.Now().Add(clientReadResponsetimeout))
+		envelope, err := s.readRangeChunk(str, expectedBlockNum)
+		if errors.Is(err, io.EOF) {
+			// the server may serve fewer blocks than requested
+			break
+		} else if err != nil {
+			return received, err
+		}
+		if err := verifyBlock(envelope, expectedBlockNum); err != nil {
+			return received, fmt.Errorf("received execution payload is invalid: %w", err)
+		}
+		if parent != nil && envelope.ExecutionPayload.ParentHash != parent.BlockHash {
+			return received, fmt.Errorf("received execution payload %s does not build on previous payload %s",
+				envelope.ExecutionPayload.ID(), parent.ID())
+		}
+		parent = envelope.ExecutionPayload
+		select {
+		case s.results <- syncResult{payload: envelope, peer: id}:
+		case <-ctx.Done():
+			return received, fmt.Errorf("failed to process response, sync client is too busy: %w", ctx.Err())
+		}
+		received++
+	}
+	return received, nil
+}
+
+// readRangeChunk reads a single payload chunk of a range response.
+// It returns io.EOF if the response ended before the chunk.
+func (s *SyncClient) readRangeChunk(r io.Reader, expectedBlockNum uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	var result [1]byte
+	if _, err := io.ReadFull(r, result[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read result part of response: %w", err)
+	}
+	if res := result[0]; res != 0 {
+		return nil, requestResultErr(res)
+	}
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read header part of response: %w", err)
+	}
+	version := binary.LittleEndian.Uint32(header[:4])
+	length := binary.LittleEndian.Uint32(header[4:])
+	// Limit input, as well as output, as the compressed data may otherwise decode into much more data (zip-bomb)
+	if length > uint32(snappy.MaxEncodedLen(maxGossipSize)) {
+		return nil, fmt.Errorf("response chunk of %d bytes is too large", length)
+	}
+	compressed := make([]byte, length)
+	if _, err := io.ReadFull(r, compressed); err != nil {
+		return nil, fmt.Errorf("failed to read response chunk: %w", err)
+	}
+	if n, err := snappy.DecodedLen(compressed); err != nil {
+		return nil, fmt.Errorf("invalid response chunk: %w", err)
+	} else if n > maxGossipSize {
+		return nil, fmt.Errorf("response chunk decodes into %d bytes, too large", n)
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response chunk: %w", err)
+	}
+
+	isCanyon := s.cfg.IsCanyon(s.cfg.TimestampForBlock(expectedBlockNum))
+	isIsthmus := s.cfg.IsIsthmus(s.cfg.TimestampForBlock(expectedBlockNum))
+	return readExecutionPayload(version, data, isCanyon, isIsthmus)
+}
+
+// encodeRangeChunk encodes a payload as chunk of a range response.
+func (srv *ReqRespServer) encodeRangeChunk(envelope *eth.ExecutionPayloadEnvelope) ([]byte, error) {
+	var buf bytes.Buffer
+	var version uint32
+	if srv.cfg.IsEcotone(uint64(envelope.ExecutionPayload.Timestamp)) {
+		version = 1
+		if _, err := envelope.MarshalSSZ(&buf); err != nil {
+			return nil, fmt.Errorf("failed to encode payload envelope: %w", err)
+		}
+	} else {
+		if _, err := envelope.ExecutionPayload.MarshalSSZ(&buf); err != nil {
+			return nil, fmt.Errorf("failed to encode payload: %w", err)
+		}
+	}
+	compressed := snappy.Encode(nil, buf.Bytes())
+	chunk := make([]byte, 9, 9+len(compressed))
+	// chunk[0] - resultCode: success = 0
+	binary.LittleEndian.PutUint32(chunk[1:5], version)
+	binary.LittleEndian.PutUint32(chunk[5:9], uint32(len(compressed)))
+	return append(chunk, compressed...), nil
+}
+
+type rangeReq struct {
+	start uint64
+	count uint64
+}
+
+// HandleRangeRequest is a stream handler function to register the L2 unsafe payloads_by_range alt-sync protocol.
+// See MakeStreamHandler to transform this into a LibP2P handler function.
+//
+// Note that the same peer may open parallel streams.
+//
+// The caller must Close the stream.
+func (srv *ReqRespServer) HandleRangeRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	start := time.Now()
+
+	// Like for single payloads, we throttle the peer instead of disconnecting,
+	// unless the delay reaches a threshold that is unreasonable to wait for.
+	ctx, cancel := context.WithTimeout(ctx, maxThrottleDelay)
+	req, served, err := srv.handleRangeRequest(ctx, stream)
+	cancel()
+
+	resultCode := ResultCodeSuccess
+	if err != nil {
+		log.Warn("failed to serve p2p sync range request", "start", req.start, "count", req.count, "served", served, "err", err)
+		if errors.Is(err, ethereum.NotFound) {
+			resultCode = ResultCodeNotFoundErr
+		} else if errors.Is(err, errInvalidRequest) {
+			resultCode = ResultCodeInvalidErr
+		} else {
+			resultCode = ResultCodeUnknownErr
+		}
+		// try to write error code, so the other peer can understand the reason for failure.
+		_, _ = stream.Write([]byte{resultCode})
+	} else {
+		log.Debug("successfully served sync range response", "start", req.start, "count", req.count, "served", served)
+	}
+	srv.metrics.ServerPayloadsByRangeEvent(req.start, served, resultCode, time.Since(start))
+}
+
+func (srv *ReqRespServer) handleRangeRequest(ctx context.Context, stream network.Stream) (req rangeReq, served uint64, err error) {
+	peerId := stream.Conn().RemotePeer()
+
+	// take a token from the global rate-limiter,
+	// to make sure there's not too much concurrent server work between different peers.
+	if err := srv.globalRangeRequestsRL.Wait(ctx); err != nil {
+		return req, 0, fmt.Errorf("timed out waiting for global sync range rate limit: %w", err)
+	}
+
+	ps := srv.peerStat(peerId)
+	if err := ps.RangeRequests.Wait(ctx); err != nil {
+		return req, 0, fmt.Errorf("timed out waiting for peer sync range rate limit: %w", err)
+	}
+
+	// Set read deadline, if available
+	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
+
+	// Read the request
+	var data [16]byte
+	if _, err := io.ReadFull(stream, data[:]); err != nil {
+		return req, 0, fmt.Errorf("failed to read requested block range: %w", err)
+	}
+	req.start = binary.LittleEndian.Uint64(data[:8])
+	req.count = binary.LittleEndian.Uint64(data[8:])
+	if err := stream.CloseRead(); err != nil {
+		return req, 0, fmt.Errorf("failed to close reading-side of a P2P sync range request call: %w", err)
+	}
+
+	// Check the request is within the expected range of blocks
+	if req.count == 0 {
+		return req, 0, fmt.Errorf("cannot serve empty range request: %w", errInvalidRequest)
+	}
+	if req.start < srv.cfg.Genesis.L2.Number {
+		return req, 0, fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", req.start, srv.cfg.Genesis.L2.Number, errInvalidRequest)
+	}
+	max, err := srv.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
+	if err != nil {
+		return req, 0, fmt.Errorf("cannot determine max target block number to verify request: %w", errInvalidRequest)
+	}
+	if req.start > max {
+		return req, 0, fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", req.start, max, errInvalidRequest)
+	}
+	end := min(req.start+min(req.count, maxRangeRequestBlocks)-1, max)
+
+	var written uint64
+	for num := req.start; num <= end; num++ {
+		envelope, err := srv.l2.PayloadByNumber(ctx, num)
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				return req, served, fmt.Errorf("peer requested unknown block by number: %w", err)
+			} else {
+				return req, served, fmt.Errorf("failed to retrieve payload to serve to peer: %w", err)
+			}
+		}
+		chunk, err := srv.encodeRangeChunk(envelope)
+		if err != nil {
+			return req, served, err
+		}
+		size := uint64(len(chunk))
+		if served > 0 && written+size > maxRangeResponseBytes {
+			break
+		}
+		// Throttle on the byte quota of the peer, and of all peers.
+		// If the quota does not allow for the payload in time, we end the response with what we served so far.
+		if err := ps.RangeBytes.WaitN(ctx, len(chunk)); err != nil {
+			if served > 0 {
+				break
+			}
+			return req, served, fmt.Errorf("timed out waiting for peer sync range byte quota: %w", err)
+		}
+		if err := srv.globalRangeBytesRL.WaitN(ctx, len(chunk)); err != nil {
+			if served > 0 {
+				break
+			}
+			return req, served, fmt.Errorf("timed out waiting for global sync range byte quota: %w", err)
+		}
+
+		// We set write deadline, if available, to safely write without blocking on a throttling peer connection
+		_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
+		if _, err := stream.Write(chunk); err != nil {
+			return req, served, fmt.Errorf("failed to write payload %d to sync range response: %w", num, err)
+		}
+		written += size
+		served++
+	}
+	return req, served, nil
+}
@@ -466,3 +466,121 @@ func TestRequestResultErr_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestRangeSync(t *testing.T) {
+	t.Parallel() // Takes a while, but can run in parallel
+
+	log := testlog.Logger(t, log.LevelError)
+
+	cfg, payloads := setupSyncTestData(100)
+
+	// Serving payloads: just load them from the map, if they exist
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayloadEnvelope, error) {
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+
+	// collect received payloads in a buffered channel, so we can verify we get everything
+	received := make(chan *eth.ExecutionPayloadEnvelope, 100)
+	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayloadEnvelope) error {
+		received <- payload
+		return nil
+	})
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Setup host A as the server, serving only ranges of payloads
+	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+	payloadsByRange := MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleRangeRequest)
+	hostA.SetStreamHandler(PayloadsByRangeProtocolID(cfg.L2ChainID), payloadsByRange)
+
+	// Setup host B as the client
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
+	cl.AddPeer(hostA.ID())
+	cl.Start()
+	defer cl.Close()
+
+	// request to start syncing between 5 and 95, which takes multiple range requests
+	_, err = cl.RequestL2Range(ctx, payloads.getBlockRef(5), payloads.getBlockRef(95))
+	require.NoError(t, err)
+
+	// and wait for the sync results to come in (in reverse order)
+	for i := uint64(94); i > 5; i-- {
+		var p *eth.ExecutionPayloadEnvelope
+		select {
+		case p = <-received:
+		case <-time.After(30 * time.Second):
+			t.Fatalf("timed out waiting for payload %d", i)
+		}
+		require.Equal(t, i, uint64(p.ExecutionPayload.BlockNumber), "expecting payloads in order")
+		exp, ok := payloads.getPayload(i)
+		require.True(t, ok, "expecting known payload")
+		require.Equal(t, exp.ExecutionPayload.BlockHash, p.ExecutionPayload.BlockHash, "expecting the correct payload")
+		require.Equal(t, exp.ParentBeaconBlockRoot, p.ParentBeaconBlockRoot)
+	}
+}
+
+func TestRangeServer(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+
+	cfg, payloads := setupSyncTestData(100)
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayloadEnvelope, error) {
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+	hostA.SetStreamHandler(PayloadsByRangeProtocolID(cfg.L2ChainID),
+		MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleRangeRequest))
+
+	// the client is not started, results are read from the results channel directly
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB, nil, metrics.NoopMetrics, &NoopApplicationScorer{})
+	cl.results = make(chan syncResult, 2*maxRangeRequestBlocks)
+
+	t.Run("capped range", func(t *testing.T) {
+		received, err := cl.doRangeRequest(ctx, hostA.ID(), 1, 2*maxRangeRequestBlocks)
+		require.NoError(t, err)
+		require.Equal(t, uint64(maxRangeRequestBlocks), received, "server serves at most the max range")
+		for i := uint64(1); i <= maxRangeRequestBlocks; i++ {
+			res := <-cl.results
+			require.Equal(t, i, uint64(res.payload.ExecutionPayload.BlockNumber))
+		}
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		payloads.deletePayload(50)
+		received, err := cl.doRangeRequest(ctx, hostA.ID(), 47, 10)
+		require.Equal(t, requestResultErr(ResultCodeNotFoundErr), err)
+		require.Equal(t, uint64(3), received, "blocks before the unknown block are served")
+		for i := 0; i < 3; i++ {
+			<-cl.results
+		}
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := cl.doRangeRequest(ctx, hostA.ID(), 1<<40, 10)
+		require.Equal(t, requestResultErr(ResultCodeInvalidErr), err, "range after the max expected block")
+	})
+}
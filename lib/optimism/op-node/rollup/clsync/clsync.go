@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 )
 
 // Max memory used for buffering unsafe payloads
@@ -31,6 +32,11 @@ type CLSync struct {
 	mu sync.Mutex
 
 	unsafePayloads *PayloadsQueue // queue of unsafe payloads, ordered by ascending block number, may have gaps and duplicates
+
+	// store persists the signed payloads of the queue, if not nil.
+	store PayloadsStore
+	// loaded is set once the persisted payloads are loaded into the queue.
+	loaded bool
 }
 
 func NewCLSync(log log.Logger, cfg *rollup.Config, metrics Metrics) *CLSync {
@@ -46,6 +52,14 @@ func (eq *CLSync) AttachEmitter(em event.Emitter) {
 	eq.emitter = em
 }
 
+// AttachStore attaches a store to persist the signed unsafe payloads in.
+// The persisted payloads are loaded into the queue upon the first forkchoice update,
+// once the finalized head is known to validate them against.
+// AttachStore must be called before any events are processed.
+func (eq *CLSync) AttachStore(store PayloadsStore) {
+	eq.store = store
+}
+
 // LowestQueuedUnsafeBlock retrieves the first queued-up L2 unsafe payload, or a zeroed reference if there is none.
 func (eq *CLSync) LowestQueuedUnsafeBlock() eth.L2BlockRef {
 	payload := eq.unsafePayloads.Peek()
@@ -61,6 +75,9 @@ func (eq *CLSync) LowestQueuedUnsafeBlock() eth.L2BlockRef {
 
 type ReceivedUnsafePayloadEvent struct {
 	Envelope *eth.ExecutionPayloadEnvelope
+	// Signature is the signature the payload was gossiped with,
+	// or nil if the payload was not received with a signature.
+	Signature *eth.Bytes65
 }
 
 func (ev ReceivedUnsafePayloadEvent) String() string {
@@ -97,6 +114,7 @@ func (eq *CLSync) onInvalidPayload(x engine.PayloadInvalidEvent) {
 			"hash", block.BlockHash, "number", uint64(block.BlockNumber),
 			"timestamp", uint64(block.Timestamp))
 		eq.unsafePayloads.Pop()
+		eq.pruneStore()
 	}
 }
 
@@ -107,6 +125,10 @@ func (eq *CLSync) onForkchoiceUpdate(x engine.ForkchoiceUpdateEvent) {
 	eq.log.Debug("CL sync received forkchoice update",
 		"unsafe", x.UnsafeL2Head, "safe", x.SafeL2Head, "finalized", x.FinalizedL2Head)
 
+	if eq.store != nil && !eq.loaded {
+		eq.loadStore(x.FinalizedL2Head)
+	}
+
 	for {
 		pop, abort := eq.fromQueue(x)
 		if abort {
@@ -118,6 +140,7 @@ func (eq *CLSync) onForkchoiceUpdate(x engine.ForkchoiceUpdateEvent) {
 			break
 		}
 	}
+	eq.pruneStore()
 
 	firstEnvelope := eq.unsafePayloads.Peek()
 
@@ -176,6 +199,14 @@ func (eq *CLSync) onUnsafePayload(x ReceivedUnsafePayloadEvent) {
 		eq.log.Warn("Could not add unsafe payload", "id", envelope.ExecutionPayload.ID(), "timestamp", uint64(envelope.ExecutionPayload.Timestamp), "err", err)
 		return
 	}
+	if eq.store != nil && x.Signature != nil && eq.unsafePayloads.Has(envelope.ExecutionPayload.BlockHash) {
+		signed := &opsigner.SignedExecutionPayloadEnvelope{Envelope: envelope, Signature: *x.Signature}
+		if err := eq.store.Put(signed); err != nil {
+			eq.log.Warn("Could not persist unsafe payload", "id", signed.ID(), "err", err)
+		}
+	}
+	// pushing may have evicted older payloads
+	eq.pruneStore()
 	p := eq.unsafePayloads.Peek()
 	eq.metrics.RecordUnsafePayloadsBuffer(uint64(eq.unsafePayloads.Len()), eq.unsafePayloads.MemSize(), p.ExecutionPayload.ID())
 	eq.log.Trace("Next unsafe payload to process", "next", p.ExecutionPayload.ID(), "timestamp", uint64(p.ExecutionPayload.Timestamp))
@@ -183,3 +214,40 @@ func (eq *CLSync) onUnsafePayload(x ReceivedUnsafePayloadEvent) {
 	// request forkchoice signal, so we can process the payload maybe
 	eq.emitter.Emit(engine.ForkchoiceRequestEvent{})
 }
+
+// loadStore loads the persisted payloads into the queue.
+// Payloads that are not newer than the finalized block are dropped.
+func (eq *CLSync) loadStore(finalized eth.L2BlockRef) {
+	eq.loaded = true
+	payloads, err := eq.store.Load(finalized)
+	if err != nil {
+		eq.log.Error("Failed to load persisted unsafe payloads", "err", err)
+		return
+	}
+	for _, signed := range payloads {
+		if err := eq.unsafePayloads.Push(signed.Envelope); err != nil {
+			eq.log.Warn("Could not add persisted unsafe payload", "id", signed.ID(), "err", err)
+		}
+	}
+	eq.pruneStore()
+	if p := eq.unsafePayloads.Peek(); p != nil {
+		eq.metrics.RecordUnsafePayloadsBuffer(uint64(eq.unsafePayloads.Len()), eq.unsafePayloads.MemSize(), p.ExecutionPayload.ID())
+	}
+	eq.log.Info("Loaded persisted unsafe payloads", "count", len(payloads), "queued", eq.unsafePayloads.Len())
+}
+
+// pruneStore removes the persisted payloads that are no longer in the queue.
+// Nothing is removed before the persisted payloads are loaded into the queue.
+func (eq *CLSync) pruneStore() {
+	if eq.store == nil || !eq.loaded {
+		return
+	}
+	for _, hash := range eq.store.Hashes() {
+		if eq.unsafePayloads.Has(hash) {
+			continue
+		}
+		if err := eq.store.Delete(hash); err != nil {
+			eq.log.Warn("Could not remove persisted unsafe payload", "hash", hash, "err", err)
+		}
+	}
+}
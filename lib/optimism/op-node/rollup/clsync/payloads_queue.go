@@ -127,14 +127,20 @@ func (upq *PayloadsQueue) Push(e *eth.ExecutionPayloadEnvelope) error {
 		size:     size,
 	})
 	upq.currentSize += size
+	upq.blockHashes[e.ExecutionPayload.BlockHash] = struct{}{}
 	for upq.currentSize > upq.MaxSize {
 		env := upq.Pop()
 		upq.log.Info("Dropping payload from payload queue because the payload queue is too large", "id", env.ExecutionPayload.ID())
 	}
-	upq.blockHashes[e.ExecutionPayload.BlockHash] = struct{}{}
 	return nil
 }
 
+// Has returns true if a payload with the given block hash is in the queue.
+func (upq *PayloadsQueue) Has(hash common.Hash) bool {
+	_, ok := upq.blockHashes[hash]
+	return ok
+}
+
 // Peek retrieves the payload with the lowest block number from the queue in O(1), or nil if the queue is empty.
 func (upq *PayloadsQueue) Peek() *eth.ExecutionPayloadEnvelope {
 	if len(upq.pq) == 0 {
@@ -0,0 +1,268 @@
+package clsync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+)
+
+// PayloadsStore persists the signed payloads of the unsafe payloads queue, to recover them after a restart.
+type PayloadsStore interface {
+	// Put persists the signed payload. Payloads that do not fit in the store are not persisted.
+	Put(signed *opsigner.SignedExecutionPayloadEnvelope) error
+	// Delete removes the payload with the given block hash, if it is persisted.
+	Delete(hash common.Hash) error
+	// Hashes returns the block hashes of all persisted payloads.
+	Hashes() []common.Hash
+	// Load returns the persisted payloads that are still valid, ordered by block number.
+	// Persisted payloads that fail validation are removed.
+	Load(finalized eth.L2BlockRef) ([]*opsigner.SignedExecutionPayloadEnvelope, error)
+}
+
+const payloadFileExt = ".payload"
+
+type storedPayload struct {
+	number uint64
+	path   string
+	size   uint64
+}
+
+// DiskPayloadsStore is a PayloadsStore that keeps each payload in a file in a directory,
+// in the same signed encoding as the payloads are gossiped in.
+// The signature of each payload is verified again when loaded,
+// against the unsafe block signers that are configured at that time.
+// DiskPayloadsStore is not safe to use concurrently.
+type DiskPayloadsStore struct {
+	log     log.Logger
+	cfg     *rollup.Config
+	dir     string
+	maxSize uint64
+	signers func() []opsigner.AuthorizedSigner
+
+	entries map[common.Hash]storedPayload
+	size    uint64
+}
+
+var _ PayloadsStore = (*DiskPayloadsStore)(nil)
+
+// NewDiskPayloadsStore opens the payloads store in the given directory, creating it if it does not exist.
+// The store holds at most maxSize bytes of payloads.
+// The signers returns the currently allowed unsafe block signers, to verify the payloads with when loading.
+func NewDiskPayloadsStore(log log.Logger, cfg *rollup.Config, dir string, maxSize uint64, signers func() []opsigner.AuthorizedSigner) (*DiskPayloadsStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create payloads store directory: %w", err)
+	}
+	s := &DiskPayloadsStore{
+		log:     log,
+		cfg:     cfg,
+		dir:     dir,
+		maxSize: maxSize,
+		signers: signers,
+		entries: make(map[common.Hash]storedPayload),
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payloads store directory: %w", err)
+	}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), payloadFileExt) {
+			continue
+		}
+		path := filepath.Join(dir, f.Name())
+		number, hash, err := parsePayloadFileName(f.Name())
+		if err != nil {
+			log.Warn("Removing unrecognized file from payloads store", "path", path, "err", err)
+			_ = os.Remove(path)
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat payload file %s: %w", path, err)
+		}
+		s.entries[hash] = storedPayload{number: number, path: path, size: uint64(info.Size())}
+		s.size += uint64(info.Size())
+	}
+	return s, nil
+}
+
+func payloadFileName(id eth.BlockID) string {
+	return fmt.Sprintf("%020d-%s%s", id.Number, id.Hash, payloadFileExt)
+}
+
+func parsePayloadFileName(name string) (uint64, common.Hash, error) {
+	numStr, hashStr, ok := strings.Cut(strings.TrimSuffix(name, payloadFileExt), "-")
+	if !ok {
+		return 0, common.Hash{}, errors.New("missing block number separator")
+	}
+	number, err := strconv.ParseUint(numStr, 10, 64)
+	if err != nil {
+		return 0, common.Hash{}, fmt.Errorf("invalid block number: %w", err)
+	}
+	var hash common.Hash
+	if err := hash.UnmarshalText([]byte(hashStr)); err != nil {
+		return 0, common.Hash{}, fmt.Errorf("invalid block hash: %w", err)
+	}
+	return number, hash, nil
+}
+
+// encodeSignedPayload encodes the payload like it is gossiped: the signature, followed by the SSZ encoded payload.
+// The payload is encoded as envelope if it has a parent beacon block root (since Ecotone).
+func encodeSignedPayload(signed *opsigner.SignedExecutionPayloadEnvelope) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(signed.Signature[:])
+	if signed.Envelope.ParentBeaconBlockRoot != nil {
+		if _, err := signed.Envelope.MarshalSSZ(&buf); err != nil {
+			return nil, fmt.Errorf("failed to encode execution payload envelope: %w", err)
+		}
+	} else {
+		if _, err := signed.Envelope.ExecutionPayload.MarshalSSZ(&buf); err != nil {
+			return nil, fmt.Errorf("failed to encode execution payload: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeSignedPayload decodes a payload of the given block number, as encoded by encodeSignedPayload.
+func (s *DiskPayloadsStore) decodeSignedPayload(number uint64, data []byte) (*opsigner.SignedExecutionPayloadEnvelope, error) {
+	if len(data) < 65 {
+		return nil, errors.New("missing signature")
+	}
+	signature := eth.Bytes65(data[:65])
+	payloadBytes := data[65:]
+	timestamp := s.cfg.TimestampForBlock(number)
+	var envelope eth.ExecutionPayloadEnvelope
+	if s.cfg.IsEcotone(timestamp) {
+		blockVersion := eth.BlockV3
+		if s.cfg.IsIsthmus(timestamp) {
+			blockVersion = eth.BlockV4
+		}
+		if err := envelope.UnmarshalSSZ(blockVersion, uint32(len(payloadBytes)), bytes.NewReader(payloadBytes)); err != nil {
+			return nil, fmt.Errorf("failed to decode execution payload envelope: %w", err)
+		}
+	} else {
+		blockVersion := eth.BlockV1
+		if s.cfg.IsCanyon(timestamp) {
+			blockVersion = eth.BlockV2
+		}
+		var payload eth.ExecutionPayload
+		if err := payload.UnmarshalSSZ(blockVersion, uint32(len(payloadBytes)), bytes.NewReader(payloadBytes)); err != nil {
+			return nil, fmt.Errorf("failed to decode execution payload: %w", err)
+		}
+		envelope.ExecutionPayload = &payload
+	}
+	auth := &opsigner.OPStackP2PBlockAuthV1{
+		Signers:   s.signers(),
+		Timestamp: timestamp,
+		Chain:     eth.ChainIDFromBig(s.cfg.L2ChainID),
+	}
+	if len(auth.Signers) == 0 {
+		return nil, errors.New("no unsafe block signer configured")
+	}
+	raw := opsigner.SignedP2PBlock{Raw: payloadBytes, Signature: signature}
+	if err := raw.VerifySignature(auth); err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	return &opsigner.SignedExecutionPayloadEnvelope{Envelope: &envelope, Signature: signature}, nil
+}
+
+func (s *DiskPayloadsStore) Put(signed *opsigner.SignedExecutionPayloadEnvelope) error {
+	id := signed.ID()
+	if _, ok := s.entries[id.Hash]; ok {
+		return nil
+	}
+	data, err := encodeSignedPayload(signed)
+	if err != nil {
+		return err
+	}
+	size := uint64(len(data))
+	if s.size+size > s.maxSize {
+		s.log.Warn("Payloads store is full, not persisting payload", "id", id, "size", s.size, "max_size", s.maxSize)
+		return nil
+	}
+	path := filepath.Join(s.dir, payloadFileName(id))
+	// write to a temporary file first, to not leave a partial payload behind
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write payload %s: %w", id, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to move payload %s into place: %w", id, err)
+	}
+	s.entries[id.Hash] = storedPayload{number: id.Number, path: path, size: size}
+	s.size += size
+	return nil
+}
+
+func (s *DiskPayloadsStore) Delete(hash common.Hash) error {
+	entry, ok := s.entries[hash]
+	if !ok {
+		return nil
+	}
+	delete(s.entries, hash)
+	s.size -= entry.size
+	if err := os.Remove(entry.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove payload %s: %w", hash, err)
+	}
+	return nil
+}
+
+func (s *DiskPayloadsStore) Hashes() []common.Hash {
+	out := make([]common.Hash, 0, len(s.entries))
+	for hash := range s.entries {
+		out = append(out, hash)
+	}
+	return out
+}
+
+func (s *DiskPayloadsStore) Load(finalized eth.L2BlockRef) ([]*opsigner.SignedExecutionPayloadEnvelope, error) {
+	var out []*opsigner.SignedExecutionPayloadEnvelope
+	for hash, entry := range s.entries {
+		signed, err := s.load(hash, entry, finalized)
+		if err != nil {
+			s.log.Warn("Removing invalid persisted payload", "path", entry.path, "err", err)
+			if err := s.Delete(hash); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		out = append(out, signed)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Envelope.ExecutionPayload.BlockNumber < out[j].Envelope.ExecutionPayload.BlockNumber
+	})
+	return out, nil
+}
+
+func (s *DiskPayloadsStore) load(hash common.Hash, entry storedPayload, finalized eth.L2BlockRef) (*opsigner.SignedExecutionPayloadEnvelope, error) {
+	if entry.number <= finalized.Number {
+		return nil, fmt.Errorf("payload is not newer than finalized block %s", finalized)
+	}
+	data, err := os.ReadFile(entry.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payload: %w", err)
+	}
+	signed, err := s.decodeSignedPayload(entry.number, data)
+	if err != nil {
+		return nil, err
+	}
+	payload := signed.Envelope.ExecutionPayload
+	if uint64(payload.BlockNumber) != entry.number || payload.BlockHash != hash {
+		return nil, fmt.Errorf("payload %s does not match file name", payload.ID())
+	}
+	if actual, ok := signed.Envelope.CheckBlockHash(); !ok {
+		return nil, fmt.Errorf("payload has bad block hash, actual block hash is %s", actual)
+	}
+	return signed, nil
+}
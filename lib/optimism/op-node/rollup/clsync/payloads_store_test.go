@@ -0,0 +1,211 @@
+package clsync
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type payloadsStoreTest struct {
+	cfg    *rollup.Config
+	signer *opsigner.LocalSigner
+	addr   common.Address
+}
+
+func newPayloadsStoreTest(t *testing.T) *payloadsStoreTest {
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &payloadsStoreTest{
+		cfg: &rollup.Config{
+			Genesis:   rollup.Genesis{L2Time: 1000},
+			BlockTime: 2,
+			L2ChainID: big.NewInt(901),
+		},
+		signer: opsigner.NewLocalSigner(priv),
+		addr:   crypto.PubkeyToAddress(priv.PublicKey),
+	}
+}
+
+// payload creates a signed pre-Canyon payload with a valid block hash.
+func (st *payloadsStoreTest) payload(t *testing.T, number uint64, parent common.Hash) *opsigner.SignedExecutionPayloadEnvelope {
+	payload := &eth.ExecutionPayload{
+		ParentHash:    parent,
+		BlockNumber:   eth.Uint64Quantity(number),
+		GasLimit:      30_000_000,
+		Timestamp:     eth.Uint64Quantity(st.cfg.TimestampForBlock(number)),
+		BaseFeePerGas: eth.Uint256Quantity(*uint256.NewInt(7)),
+	}
+	envelope := &eth.ExecutionPayloadEnvelope{ExecutionPayload: payload}
+	payload.BlockHash, _ = envelope.CheckBlockHash()
+
+	var buf bytes.Buffer
+	_, err := payload.MarshalSSZ(&buf)
+	require.NoError(t, err)
+	sig, err := st.signer.SignBlockV1(context.Background(), eth.ChainIDFromBig(st.cfg.L2ChainID), opsigner.PayloadHash(buf.Bytes()))
+	require.NoError(t, err)
+	return &opsigner.SignedExecutionPayloadEnvelope{Envelope: envelope, Signature: sig}
+}
+
+func (st *payloadsStoreTest) open(t *testing.T, dir string, maxSize uint64) *DiskPayloadsStore {
+	s, err := NewDiskPayloadsStore(testlog.Logger(t, log.LevelError), st.cfg, dir, maxSize,
+		func() []opsigner.AuthorizedSigner { return []opsigner.AuthorizedSigner{{Address: st.addr}} })
+	require.NoError(t, err)
+	return s
+}
+
+func TestDiskPayloadsStore(t *testing.T) {
+	st := newPayloadsStoreTest(t)
+	a := st.payload(t, 10, common.Hash{0xaa})
+	b := st.payload(t, 11, a.Envelope.ExecutionPayload.BlockHash)
+	c := st.payload(t, 12, b.Envelope.ExecutionPayload.BlockHash)
+
+	t.Run("reload", func(t *testing.T) {
+		dir := t.TempDir()
+		s := st.open(t, dir, 1<<20)
+		require.NoError(t, s.Put(c))
+		require.NoError(t, s.Put(a))
+		require.NoError(t, s.Put(b))
+		require.NoError(t, s.Put(b), "duplicates are ignored")
+		require.NoError(t, s.Delete(c.Envelope.ExecutionPayload.BlockHash))
+
+		s = st.open(t, dir, 1<<20)
+		require.ElementsMatch(t, []common.Hash{a.ID().Hash, b.ID().Hash}, s.Hashes())
+		loaded, err := s.Load(eth.L2BlockRef{Number: 9})
+		require.NoError(t, err)
+		require.Len(t, loaded, 2)
+		require.Equal(t, a.ID(), loaded[0].ID())
+		require.Equal(t, a.Signature, loaded[0].Signature)
+		require.Equal(t, b.ID(), loaded[1].ID())
+	})
+
+	t.Run("finalized", func(t *testing.T) {
+		dir := t.TempDir()
+		s := st.open(t, dir, 1<<20)
+		require.NoError(t, s.Put(a))
+		require.NoError(t, s.Put(b))
+		loaded, err := s.Load(eth.L2BlockRef{Number: 10})
+		require.NoError(t, err)
+		require.Len(t, loaded, 1)
+		require.Equal(t, b.ID(), loaded[0].ID())
+		require.NoFileExists(t, filepath.Join(dir, payloadFileName(a.ID())), "finalized payload is removed")
+	})
+
+	t.Run("signer change", func(t *testing.T) {
+		dir := t.TempDir()
+		s := st.open(t, dir, 1<<20)
+		require.NoError(t, s.Put(a))
+		s.signers = func() []opsigner.AuthorizedSigner {
+			return []opsigner.AuthorizedSigner{{Address: common.Address{0x42}}}
+		}
+		loaded, err := s.Load(eth.L2BlockRef{})
+		require.NoError(t, err)
+		require.Empty(t, loaded)
+		require.Empty(t, s.Hashes())
+	})
+
+	t.Run("corrupt", func(t *testing.T) {
+		dir := t.TempDir()
+		s := st.open(t, dir, 1<<20)
+		require.NoError(t, s.Put(a))
+		require.NoError(t, s.Put(b))
+		path := filepath.Join(dir, payloadFileName(a.ID()))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		data[len(data)-1] ^= 0xff
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "junk"+payloadFileExt), []byte("junk"), 0o644))
+
+		s = st.open(t, dir, 1<<20)
+		loaded, err := s.Load(eth.L2BlockRef{})
+		require.NoError(t, err)
+		require.Len(t, loaded, 1)
+		require.Equal(t, b.ID(), loaded[0].ID())
+		require.NoFileExists(t, path)
+		require.NoFileExists(t, filepath.Join(dir, "junk"+payloadFileExt))
+	})
+
+	t.Run("max size", func(t *testing.T) {
+		data, err := encodeSignedPayload(a)
+		require.NoError(t, err)
+		s := st.open(t, t.TempDir(), uint64(len(data))+1)
+		require.NoError(t, s.Put(a))
+		require.NoError(t, s.Put(b))
+		require.Equal(t, []common.Hash{a.ID().Hash}, s.Hashes(), "payload beyond the max size is not persisted")
+		require.NoError(t, s.Delete(a.ID().Hash))
+		require.NoError(t, s.Put(b))
+		require.Equal(t, []common.Hash{b.ID().Hash}, s.Hashes())
+	})
+}
+
+// TestCLSyncPayloadsStore tests that the signed payloads of the CL sync queue are persisted,
+// and loaded into the queue again after a restart.
+func TestCLSyncPayloadsStore(t *testing.T) {
+	st := newPayloadsStoreTest(t)
+	a := st.payload(t, 10, common.Hash{0xaa})
+	b := st.payload(t, 11, a.Envelope.ExecutionPayload.BlockHash)
+	unsigned := st.payload(t, 12, b.Envelope.ExecutionPayload.BlockHash)
+	logger := testlog.Logger(t, log.LevelError)
+	metrics := &testutils.TestDerivationMetrics{}
+	dir := t.TempDir()
+
+	store := st.open(t, dir, 1<<20)
+	cl := NewCLSync(logger, st.cfg, metrics)
+	cl.AttachStore(store)
+	emitter := &testutils.MockEmitter{}
+	cl.AttachEmitter(emitter)
+
+	for _, ev := range []ReceivedUnsafePayloadEvent{
+		{Envelope: a.Envelope, Signature: &a.Signature},
+		{Envelope: b.Envelope, Signature: &b.Signature},
+		{Envelope: unsigned.Envelope},
+	} {
+		emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
+		cl.OnEvent(ev)
+	}
+	emitter.AssertExpectations(t)
+	require.ElementsMatch(t, []common.Hash{a.ID().Hash, b.ID().Hash}, store.Hashes(), "only signed payloads are persisted")
+
+	// Restart, with the engine at the parent of a
+	store = st.open(t, dir, 1<<20)
+	cl = NewCLSync(logger, st.cfg, metrics)
+	cl.AttachStore(store)
+	emitter = &testutils.MockEmitter{}
+	cl.AttachEmitter(emitter)
+	parent := eth.L2BlockRef{Hash: a.Envelope.ExecutionPayload.ParentHash, Number: 9}
+	expectProcess := func(expected *opsigner.SignedExecutionPayloadEnvelope) {
+		emitter.ExpectOnceRun(func(ev event.Event) {
+			x, ok := ev.(engine.ProcessUnsafePayloadEvent)
+			require.True(t, ok)
+			require.Equal(t, expected.ID(), x.Envelope.ExecutionPayload.ID())
+		})
+	}
+	expectProcess(a)
+	cl.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: parent, SafeL2Head: parent, FinalizedL2Head: parent})
+	emitter.AssertExpectations(t)
+	require.Equal(t, 2, cl.unsafePayloads.Len())
+
+	// Once a is processed, it is removed from the store
+	refA := eth.L2BlockRef{Hash: a.ID().Hash, Number: a.ID().Number, ParentHash: parent.Hash}
+	expectProcess(b)
+	cl.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: refA, SafeL2Head: parent, FinalizedL2Head: parent})
+	emitter.AssertExpectations(t)
+	require.Equal(t, []common.Hash{b.ID().Hash}, store.Hashes())
+}
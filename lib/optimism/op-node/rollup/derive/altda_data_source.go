@@ -33,6 +33,15 @@ func NewAltDADataSource(log log.Logger, src DataIter, l1 L1Fetcher, fetcher AltD
 	}
 }
 
+// LastDataRef returns the batcher transaction of the commitment, or of the L1 data,
+// that was last read from the underlying L1 source.
+func (s *AltDADataSource) LastDataRef() DataRef {
+	if r, ok := s.src.(DataRefProvider); ok {
+		return r.LastDataRef()
+	}
+	return DataRef{}
+}
+
 func (s *AltDADataSource) Next(ctx context.Context) (eth.Data, error) {
 	// Process origin syncs the challenge contract events and updates the local challenge states
 	// before we can proceed to fetch the input data. This function can be called multiple times
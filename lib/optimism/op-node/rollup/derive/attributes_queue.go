@@ -57,6 +57,8 @@ type AttributesQueue struct {
 	batch       *SingularBatch
 	concluding  bool
 	lastAttribs *AttributesWithParent
+
+	provenance *provenanceTracker
 }
 
 type SingularBatchProvider interface {
@@ -102,6 +104,7 @@ func (aq *AttributesQueue) NextAttributes(ctx context.Context, parent eth.L2Bloc
 			DerivedFrom: aq.Origin(),
 		}
 		aq.lastAttribs = &attr
+		aq.provenance.batchAccepted(parent, aq.batch, attr.DerivedFrom)
 		aq.batch = nil
 		aq.concluding = false
 		return &attr, nil
@@ -10,6 +10,7 @@ import (
 	"github.com/andybalholm/brotli"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/klauspost/compress/zstd"
 )
 
 const (
@@ -171,7 +172,7 @@ func (ch *Channel) Reader() io.Reader {
 // The L1Inclusion block is also provided at creation time.
 // Warning: the batch reader can read every batch-type.
 // The caller of the batch-reader should filter the results.
-func BatchReader(r io.Reader, maxRLPBytesPerChannel uint64, isFjord bool) (func() (*BatchData, error), error) {
+func BatchReader(r io.Reader, maxRLPBytesPerChannel uint64, isFjord bool, isZstd bool) (func() (*BatchData, error), error) {
 	// use buffered reader so can peek the first byte
 	bufReader := bufio.NewReader(r)
 	compressionType, err := bufReader.Peek(1)
@@ -202,6 +203,25 @@ func BatchReader(r io.Reader, maxRLPBytesPerChannel uint64, isFjord bool) (func(
 		}
 		zr = brotli.NewReader(bufReader)
 		comprAlgo = Brotli
+	} else if compressionType[0] == ChannelVersionZstd {
+		// If zstd channels are not active yet, we cannot accept zstd compressed batch
+		if !isZstd {
+			return nil, fmt.Errorf("cannot accept zstd compressed batch before zstd channel activation")
+		}
+		// discard the first byte
+		_, err := bufReader.Discard(1)
+		if err != nil {
+			return nil, err
+		}
+		// Limit the window size to bound the decoder's memory usage. The decompressed
+		// data is further limited by the RLP reader below.
+		zr, err = zstd.NewReader(bufReader,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxWindow(maxRLPBytesPerChannel))
+		if err != nil {
+			return nil, err
+		}
+		comprAlgo = Zstd
 	} else {
 		return nil, fmt.Errorf("cannot distinguish the compression algo used given type byte %v", compressionType[0])
 	}
@@ -18,6 +18,8 @@ type ChannelAssembler struct {
 	channel *Channel
 
 	prev NextFrameProvider
+
+	provenance *provenanceTracker
 }
 
 var _ RawChannelProvider = (*ChannelAssembler)(nil)
@@ -133,6 +135,7 @@ func (ca *ChannelAssembler) NextRawChannel(ctx context.Context) ([]byte, error)
 	}
 
 	ca.resetChannel()
+	ca.provenance.channelRead(ch.ID())
 	r := ch.Reader()
 	return io.ReadAll(r)
 }
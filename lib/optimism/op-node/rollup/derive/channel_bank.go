@@ -38,6 +38,8 @@ type ChannelBank struct {
 	channelQueue []ChannelID            // channels in FIFO order
 
 	prev NextFrameProvider
+
+	provenance *provenanceTracker
 }
 
 var _ RawChannelProvider = (*ChannelBank)(nil)
@@ -164,6 +166,7 @@ func (cb *ChannelBank) tryReadChannelAtIndex(i int) (data []byte, err error) {
 	delete(cb.channels, chanID)
 	cb.channelQueue = slices.Delete(cb.channelQueue, i, i+1)
 	cb.metrics.RecordHeadChannelOpened()
+	cb.provenance.channelRead(chanID)
 	r := ch.Reader()
 	// Suppress error here. io.ReadAll does return nil instead of io.EOF though.
 	data, _ = io.ReadAll(r)
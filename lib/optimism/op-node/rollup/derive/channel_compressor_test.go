@@ -38,7 +38,12 @@ func TestChannelCompressor_NewReset(t *testing.T) {
 		},
 		{
 			name:              "zstd",
-			algo:              CompressionAlgo("zstd"),
+			algo:              Zstd,
+			expectedResetSize: 1,
+		},
+		{
+			name:              "lz4",
+			algo:              CompressionAlgo("lz4"),
 			expectedResetSize: 0,
 			expectErr:         true,
 		},
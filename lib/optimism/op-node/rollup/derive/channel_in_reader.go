@@ -23,6 +23,8 @@ type ChannelInReader struct {
 	nextBatchFn func() (*BatchData, error)
 	prev        RawChannelProvider
 	metrics     Metrics
+
+	provenance *provenanceTracker
 }
 
 var (
@@ -54,7 +56,7 @@ func (cr *ChannelInReader) Origin() eth.L1BlockRef {
 
 // TODO: Take full channel for better logging
 func (cr *ChannelInReader) WriteChannel(data []byte) error {
-	if f, err := BatchReader(bytes.NewBuffer(data), cr.spec.MaxRLPBytesPerChannel(cr.prev.Origin().Time), cr.cfg.IsFjord(cr.prev.Origin().Time)); err == nil {
+	if f, err := BatchReader(bytes.NewBuffer(data), cr.spec.MaxRLPBytesPerChannel(cr.prev.Origin().Time), cr.cfg.IsFjord(cr.prev.Origin().Time), cr.cfg.IsZstdChannel(cr.prev.Origin().Time)); err == nil {
 		cr.nextBatchFn = f
 		cr.metrics.RecordChannelInputBytes(len(data))
 		return nil
@@ -106,6 +108,7 @@ func (cr *ChannelInReader) NextBatch(ctx context.Context) (Batch, error) {
 			return nil, err
 		}
 		batch.LogContext(cr.log).Info("decoded singular batch from channel", "stage_origin", cr.Origin())
+		cr.provenance.batchRead(batch.Batch)
 		cr.metrics.RecordDerivedBatches("singular")
 		return batch, nil
 	case SpanBatchType:
@@ -120,6 +123,7 @@ func (cr *ChannelInReader) NextBatch(ctx context.Context) (Batch, error) {
 			return nil, err
 		}
 		batch.LogContext(cr.log).Info("decoded span batch from channel", "stage_origin", cr.Origin())
+		cr.provenance.batchRead(batch.Batch)
 		cr.metrics.RecordDerivedBatches("span")
 		return batch, nil
 	default:
@@ -18,6 +18,8 @@ type ChannelMux struct {
 	prev NextFrameProvider
 	m    Metrics
 
+	provenance *provenanceTracker
+
 	// embedded active stage
 	RawChannelProvider
 }
@@ -41,12 +43,12 @@ func (c *ChannelMux) Reset(ctx context.Context, base eth.L1BlockRef, sysCfg eth.
 	default:
 		if _, ok := c.RawChannelProvider.(*ChannelBank); !ok {
 			c.log.Info("ChannelMux: activating pre-Holocene stage during reset", "origin", base)
-			c.RawChannelProvider = NewChannelBank(c.log, c.spec, c.prev, c.m)
+			c.RawChannelProvider = c.newChannelBank()
 		}
 	case c.spec.IsHolocene(base.Time):
 		if _, ok := c.RawChannelProvider.(*ChannelAssembler); !ok {
 			c.log.Info("ChannelMux: activating Holocene stage during reset", "origin", base)
-			c.RawChannelProvider = NewChannelAssembler(c.log, c.spec, c.prev, c.m)
+			c.RawChannelProvider = c.newChannelAssembler()
 		}
 	}
 	return c.RawChannelProvider.Reset(ctx, base, sysCfg)
@@ -63,7 +65,7 @@ func (c *ChannelMux) TransformHolocene() {
 	switch cp := c.RawChannelProvider.(type) {
 	case *ChannelBank:
 		c.log.Info("ChannelMux: transforming to Holocene stage")
-		c.RawChannelProvider = NewChannelAssembler(c.log, c.spec, c.prev, c.m)
+		c.RawChannelProvider = c.newChannelAssembler()
 	case *ChannelAssembler:
 		// Even if the pipeline is Reset to the activation block, the previous origin will be the
 		// same, so transfromStages isn't called.
@@ -72,3 +74,15 @@ func (c *ChannelMux) TransformHolocene() {
 		panic(fmt.Sprintf("unknown channel stage type: %T", cp))
 	}
 }
+
+func (c *ChannelMux) newChannelBank() *ChannelBank {
+	cb := NewChannelBank(c.log, c.spec, c.prev, c.m)
+	cb.provenance = c.provenance
+	return cb
+}
+
+func (c *ChannelMux) newChannelAssembler() *ChannelAssembler {
+	ca := NewChannelAssembler(c.log, c.spec, c.prev, c.m)
+	ca.provenance = c.provenance
+	return ca
+}
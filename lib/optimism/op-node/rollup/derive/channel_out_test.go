@@ -454,7 +454,7 @@ func testSpanChannelOut_MaxBlocksPerSpanBatch(t *testing.T, tt maxBlocksTest) {
 	require.False(t, ch.IsReady())
 	require.NoError(t, ch.AddFrame(frame, l1Origin))
 	require.True(t, ch.IsReady())
-	br, err := BatchReader(ch.Reader(), spec.MaxRLPBytesPerChannel(0), true)
+	br, err := BatchReader(ch.Reader(), spec.MaxRLPBytesPerChannel(0), true, false)
 	require.NoError(t, err)
 
 	sbs := make([]*SingularBatch, 0, tt.numBatches-1)
@@ -160,7 +160,7 @@ func TestBatchReader(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	const Zstd CompressionAlgo = "zstd" // invalid algo
+	const Lz4 CompressionAlgo = "lz4" // invalid algo
 	compressor := func(ca CompressionAlgo) func(buf *bytes.Buffer, t *testing.T) {
 		switch {
 		case ca == Zlib:
@@ -179,9 +179,18 @@ func TestBatchReader(t *testing.T) {
 				require.NoError(t, err)
 				require.NoError(t, writer.Close())
 			}
-		case ca == Zstd: // invalid algo
+		case ca == Zstd:
 			return func(buf *bytes.Buffer, t *testing.T) {
-				buf.WriteByte(0x02) // invalid channel version byte
+				buf.WriteByte(ChannelVersionZstd)
+				writer, err := zstd.NewWriter(buf)
+				require.NoError(t, err)
+				_, err = writer.Write(encodedBatch.Bytes())
+				require.NoError(t, err)
+				require.NoError(t, writer.Close())
+			}
+		case ca == Lz4: // invalid algo
+			return func(buf *bytes.Buffer, t *testing.T) {
+				buf.WriteByte(0x03) // invalid channel version byte
 				writer, err := zstd.NewWriter(buf)
 				require.NoError(t, err)
 				_, err = writer.Write(encodedBatch.Bytes())
@@ -197,6 +206,7 @@ func TestBatchReader(t *testing.T) {
 		name      string
 		algo      CompressionAlgo
 		isFjord   bool
+		isZstd    bool
 		expectErr bool
 	}{
 		{
@@ -242,10 +252,23 @@ func TestBatchReader(t *testing.T) {
 			isFjord: true,
 		},
 		{
-			name:      "zstd-post-fjord",
+			name:    "zstd-active",
+			algo:    Zstd,
+			isFjord: true,
+			isZstd:  true,
+		},
+		{
+			name:      "zstd-inactive",
 			algo:      Zstd,
+			isFjord:   true,
+			expectErr: true, // expect an error because zstd is not active
+		},
+		{
+			name:      "lz4-post-fjord",
+			algo:      Lz4,
 			expectErr: true,
 			isFjord:   true,
+			isZstd:    true,
 		},
 	}
 
@@ -254,7 +277,7 @@ func TestBatchReader(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			compressor(tc.algo)(compressed, t)
-			reader, err := BatchReader(bytes.NewReader(compressed.Bytes()), 120000, tc.isFjord)
+			reader, err := BatchReader(bytes.NewReader(compressed.Bytes()), 120000, tc.isFjord, tc.isZstd)
 			if tc.expectErr {
 				require.Error(t, err)
 				return
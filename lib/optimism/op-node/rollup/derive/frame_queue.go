@@ -26,6 +26,8 @@ type FrameQueue struct {
 	frames []Frame
 	prev   NextDataProvider
 	cfg    *rollup.Config
+
+	provenance *provenanceTracker
 }
 
 func NewFrameQueue(log log.Logger, cfg *rollup.Config, prev NextDataProvider) *FrameQueue {
@@ -74,6 +76,7 @@ func (fq *FrameQueue) loadNextFrames(ctx context.Context) error {
 	}
 
 	if frames, err := ParseFrames(data); err == nil {
+		fq.provenance.framesParsed(frames)
 		fq.frames = append(fq.frames, frames...)
 	} else {
 		fq.log.Warn("Failed to parse frames", "origin", fq.prev.Origin(), "err", err)
@@ -27,6 +27,8 @@ type L1Retrieval struct {
 	prev    NextBlockProvider
 
 	datas DataIter
+
+	provenance *provenanceTracker
 }
 
 var _ ResettableStage = (*L1Retrieval)(nil)
@@ -68,6 +70,9 @@ func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
 		// CalldataSource appropriately wraps the error so avoid double wrapping errors here.
 		return nil, err
 	} else {
+		if l1r.provenance.active() {
+			l1r.provenance.dataRead(l1r.Origin(), l1r.datas)
+		}
 		return data, nil
 	}
 }
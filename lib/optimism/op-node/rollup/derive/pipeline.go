@@ -85,6 +85,8 @@ type DerivationPipeline struct {
 
 	attrib *AttributesQueue
 
+	provenance *provenanceTracker
+
 	// L1 block that the next returned attributes are derived from, i.e. at the L2-end of the pipeline.
 	origin         eth.L1BlockRef
 	resetL2Safe    eth.L2BlockRef
@@ -121,6 +123,14 @@ func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L
 	// Note: The ResetEngine is the only reset that can fail.
 	stages := []ResettableStage{l1Traversal, l1Src, altDA, frameQueue, channelMux, chInReader, batchMux, attributesQueue}
 
+	// Provenance tracking is shared by the stages, but only active once enabled with EnableDerivationInfo.
+	provenance := newProvenanceTracker(spec)
+	l1Src.provenance = provenance
+	frameQueue.provenance = provenance
+	channelMux.provenance = provenance
+	chInReader.provenance = provenance
+	attributesQueue.provenance = provenance
+
 	return &DerivationPipeline{
 		log:       log,
 		rollupCfg: rollupCfg,
@@ -132,6 +142,8 @@ func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L
 		traversal: l1Traversal,
 		attrib:    attributesQueue,
 		l2:        l2Source,
+
+		provenance: provenance,
 	}
 }
 
@@ -146,6 +158,22 @@ func (dp *DerivationPipeline) Reset() {
 	dp.resetSysConfig = eth.SystemConfig{}
 	dp.resetL2Safe = eth.L2BlockRef{}
 	dp.engineIsReset = false
+	if dp.provenance.active() {
+		dp.provenance.reset()
+	}
+}
+
+// EnableDerivationInfo enables recording which L1 data each derived L2 block came from,
+// to be retrieved with DerivationInfo once the block becomes safe.
+func (dp *DerivationPipeline) EnableDerivationInfo() {
+	dp.provenance.enable()
+}
+
+// DerivationInfo returns the L1 data that the given new safe block was derived from,
+// if derivation info is enabled and the block was derived from a batch.
+// Derivation info of older blocks is discarded.
+func (dp *DerivationPipeline) DerivationInfo(safe eth.L2BlockRef) (eth.DerivationInfo, bool) {
+	return dp.provenance.safeBlock(safe)
 }
 
 func (dp *DerivationPipeline) DepositsOnlyAttributes(parent eth.BlockID, derivedFrom eth.L1BlockRef) (*AttributesWithParent, error) {
@@ -0,0 +1,229 @@
+package derive
+
+import (
+	"encoding/binary"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// DataRef identifies the batcher transaction, and blob if any, that a piece of batcher data was read from.
+type DataRef struct {
+	TxHash    common.Hash
+	BlobIndex *uint64
+}
+
+// DataRefProvider is implemented by data sources that can identify where the data
+// last returned from Next was read from.
+type DataRefProvider interface {
+	LastDataRef() DataRef
+}
+
+// batchCandidate is a decoded block-batch, together with the channel it was read from.
+type batchCandidate struct {
+	timestamp uint64
+	channelID ChannelID
+	kind      string
+	frames    []eth.DerivationFrame
+}
+
+// provenanceTracker follows batcher data through the pipeline stages, to record which L1 data
+// each derived L2 block came from. Frames are attributed to the batcher transaction they were
+// parsed from, channels collect the frames of their ID, and each block-batch decoded from a
+// channel is a candidate until the attributes queue accepts it for the next L2 block.
+//
+// A disabled tracker is a no-op, so that the fault proof program does not pay for tracking.
+type provenanceTracker struct {
+	spec    *rollup.ChainSpec
+	enabled bool
+
+	origin eth.L1BlockRef
+	data   DataRef
+
+	// frames of channels that have not been read yet
+	frames map[ChannelID][]eth.DerivationFrame
+	// the channel that is currently being read from
+	channelID     ChannelID
+	channelFrames []eth.DerivationFrame
+
+	// candidates by block-batch fingerprint
+	candidates map[common.Hash]batchCandidate
+	// accepted derivation info, by ascending L2 block number
+	accepted []eth.DerivationInfo
+}
+
+func newProvenanceTracker(spec *rollup.ChainSpec) *provenanceTracker {
+	return &provenanceTracker{spec: spec}
+}
+
+func (p *provenanceTracker) enable() {
+	p.enabled = true
+	p.reset()
+}
+
+// active reports whether tracking is enabled. Stages used outside of a pipeline have a nil tracker.
+func (p *provenanceTracker) active() bool {
+	return p != nil && p.enabled
+}
+
+func (p *provenanceTracker) reset() {
+	p.origin = eth.L1BlockRef{}
+	p.data = DataRef{}
+	p.frames = make(map[ChannelID][]eth.DerivationFrame)
+	p.channelID = ChannelID{}
+	p.channelFrames = nil
+	p.candidates = make(map[common.Hash]batchCandidate)
+	p.accepted = nil
+}
+
+// dataRead records the L1 data that the next parsed frames come from.
+func (p *provenanceTracker) dataRead(origin eth.L1BlockRef, src DataIter) {
+	if !p.active() {
+		return
+	}
+	if origin != p.origin {
+		p.origin = origin
+		p.pruneFrames()
+	}
+	p.data = DataRef{}
+	if r, ok := src.(DataRefProvider); ok {
+		p.data = r.LastDataRef()
+	}
+}
+
+// pruneFrames drops the frames of channels that timed out without being read.
+func (p *provenanceTracker) pruneFrames() {
+	timeout := p.spec.ChannelTimeout(p.origin.Time)
+	for id, frames := range p.frames {
+		if frames[0].InclusionBlock.Number+timeout < p.origin.Number {
+			delete(p.frames, id)
+		}
+	}
+}
+
+// framesParsed attributes the frames to the last read data.
+func (p *provenanceTracker) framesParsed(frames []Frame) {
+	if !p.active() {
+		return
+	}
+	for _, f := range frames {
+		known := p.frames[f.ID]
+		// the channel stages ignore duplicate frames, so the first one seen is the one that is used
+		if slices.ContainsFunc(known, func(df eth.DerivationFrame) bool { return df.FrameNumber == f.FrameNumber }) {
+			continue
+		}
+		p.frames[f.ID] = append(known, eth.DerivationFrame{
+			FrameNumber:    f.FrameNumber,
+			InclusionBlock: p.origin.ID(),
+			TxHash:         p.data.TxHash,
+			BlobIndex:      p.data.BlobIndex,
+		})
+	}
+}
+
+// channelRead marks the channel as the one that the next batches are decoded from.
+func (p *provenanceTracker) channelRead(id ChannelID) {
+	if !p.active() {
+		return
+	}
+	p.channelID = id
+	p.channelFrames = p.frames[id]
+	delete(p.frames, id)
+	slices.SortFunc(p.channelFrames, func(a, b eth.DerivationFrame) int { return int(a.FrameNumber) - int(b.FrameNumber) })
+}
+
+// batchRead records the block-batches of a batch decoded from the current channel as candidates.
+func (p *provenanceTracker) batchRead(batch Batch) {
+	if !p.active() {
+		return
+	}
+	add := func(kind string, timestamp uint64, epoch rollup.Epoch, txs [][]byte) {
+		p.candidates[batchFingerprint(timestamp, epoch, txs)] = batchCandidate{
+			timestamp: timestamp,
+			channelID: p.channelID,
+			kind:      kind,
+			frames:    p.channelFrames,
+		}
+	}
+	switch b := batch.(type) {
+	case *SingularBatch:
+		add(eth.SingularBatchKind, b.Timestamp, b.EpochNum, hexBytesSlice(b.Transactions))
+	case *SpanBatch:
+		for _, el := range b.Batches {
+			add(eth.SpanBatchKind, el.Timestamp, el.EpochNum, hexBytesSlice(el.Transactions))
+		}
+	}
+}
+
+// batchAccepted records the provenance of the block-batch that the next L2 block after parent is derived from.
+func (p *provenanceTracker) batchAccepted(parent eth.L2BlockRef, batch *SingularBatch, derivedFrom eth.L1BlockRef) {
+	if !p.active() {
+		return
+	}
+	num := parent.Number + 1
+	// a re-derived block replaces any previously accepted info
+	p.accepted = slices.DeleteFunc(p.accepted, func(info eth.DerivationInfo) bool { return info.L2Block.Number >= num })
+	c, ok := p.candidates[batchFingerprint(batch.Timestamp, batch.EpochNum, hexBytesSlice(batch.Transactions))]
+	for fp, c := range p.candidates {
+		if c.timestamp <= batch.Timestamp {
+			delete(p.candidates, fp)
+		}
+	}
+	if !ok {
+		// e.g. an empty batch generated at the end of the sequencing window
+		return
+	}
+	p.accepted = append(p.accepted, eth.DerivationInfo{
+		L2Block:   eth.BlockID{Number: num},
+		L1Block:   derivedFrom.ID(),
+		ChannelID: slices.Clone(c.channelID[:]),
+		BatchKind: c.kind,
+		Frames:    c.frames,
+	})
+}
+
+// safeBlock returns the derivation info of the new safe block, if known,
+// and drops the info of it and all older blocks.
+func (p *provenanceTracker) safeBlock(safe eth.L2BlockRef) (info eth.DerivationInfo, ok bool) {
+	if !p.active() {
+		return
+	}
+	i := 0
+	for ; i < len(p.accepted) && p.accepted[i].L2Block.Number <= safe.Number; i++ {
+		if p.accepted[i].L2Block.Number == safe.Number {
+			info, ok = p.accepted[i], true
+			info.L2Block = safe.ID()
+		}
+	}
+	p.accepted = p.accepted[i:]
+	return
+}
+
+func batchFingerprint(timestamp uint64, epoch rollup.Epoch, txs [][]byte) common.Hash {
+	h := crypto.NewKeccakState()
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], timestamp)
+	h.Write(buf[:])
+	binary.BigEndian.PutUint64(buf[:], uint64(epoch))
+	h.Write(buf[:])
+	for _, tx := range txs {
+		binary.BigEndian.PutUint64(buf[:], uint64(len(tx)))
+		h.Write(buf[:])
+		h.Write(tx)
+	}
+	var out common.Hash
+	h.Read(out[:])
+	return out
+}
+
+func hexBytesSlice[T ~[]byte](s []T) [][]byte {
+	out := make([][]byte, len(s))
+	for i, b := range s {
+		out[i] = b
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package derive
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type refDataIter struct {
+	ref DataRef
+}
+
+func (r *refDataIter) Next(context.Context) (eth.Data, error) { return nil, nil }
+
+func (r *refDataIter) LastDataRef() DataRef { return r.ref }
+
+func TestProvenanceTracker(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chainID := big.NewInt(901)
+	spec := rollup.NewChainSpec(&rollup.Config{ChannelTimeoutBedrock: 50})
+
+	p := newProvenanceTracker(spec)
+	// disabled tracker, and nil tracker of stages outside a pipeline, ignore all calls
+	var nilTracker *provenanceTracker
+	nilTracker.dataRead(eth.L1BlockRef{}, nil)
+	p.framesParsed([]Frame{{ID: ChannelID{0x01}}})
+	_, ok := p.safeBlock(eth.L2BlockRef{})
+	require.False(t, ok)
+
+	p.enable()
+	l1A := testutils.RandomBlockRef(rng)
+	l1B := testutils.NextRandomRef(rng, l1A)
+	blobIndex := uint64(4)
+	txA := &refDataIter{ref: DataRef{TxHash: common.Hash{0xaa}}}
+	txB := &refDataIter{ref: DataRef{TxHash: common.Hash{0xbb}, BlobIndex: &blobIndex}}
+	chA, chB := ChannelID{0x0a}, ChannelID{0x0b}
+
+	p.dataRead(l1A, txA)
+	p.framesParsed([]Frame{{ID: chA, FrameNumber: 1}, {ID: chB, FrameNumber: 0}})
+	p.dataRead(l1B, txB)
+	p.framesParsed([]Frame{{ID: chA, FrameNumber: 0}, {ID: chA, FrameNumber: 1}})
+
+	p.channelRead(chA)
+	singular := RandomSingularBatch(rng, 2, chainID)
+	singular.Timestamp = 1000
+	p.batchRead(singular)
+	span := NewSpanBatch(0, chainID)
+	var spanBatches []*SingularBatch
+	for i := 0; i < 2; i++ {
+		b := RandomSingularBatch(rng, 1, chainID)
+		b.Timestamp = 1002 + uint64(i)*2
+		b.EpochNum = singular.EpochNum
+		require.NoError(t, span.AppendSingularBatch(b, uint64(i)))
+		spanBatches = append(spanBatches, b)
+	}
+	p.batchRead(span)
+
+	parent := eth.L2BlockRef{Number: 10, Time: 998}
+	p.batchAccepted(parent, singular, l1B)
+	p.batchAccepted(eth.L2BlockRef{Number: 11}, spanBatches[0], l1B)
+	// an unknown batch, like a generated empty batch, has no provenance
+	p.batchAccepted(eth.L2BlockRef{Number: 12}, &SingularBatch{Timestamp: 1004}, l1B)
+
+	expectedFrames := []eth.DerivationFrame{
+		{FrameNumber: 0, InclusionBlock: l1B.ID(), TxHash: txB.ref.TxHash, BlobIndex: &blobIndex},
+		{FrameNumber: 1, InclusionBlock: l1A.ID(), TxHash: txA.ref.TxHash},
+	}
+	safe := eth.L2BlockRef{Hash: common.Hash{0x11}, Number: 11}
+	info, ok := p.safeBlock(safe)
+	require.True(t, ok)
+	require.Equal(t, eth.DerivationInfo{
+		L2Block:   safe.ID(),
+		L1Block:   l1B.ID(),
+		ChannelID: chA[:],
+		BatchKind: eth.SingularBatchKind,
+		Frames:    expectedFrames,
+	}, info)
+
+	info, ok = p.safeBlock(eth.L2BlockRef{Hash: common.Hash{0x12}, Number: 12})
+	require.True(t, ok)
+	require.Equal(t, eth.SpanBatchKind, info.BatchKind)
+	require.Equal(t, expectedFrames, info.Frames)
+	_, ok = p.safeBlock(eth.L2BlockRef{Hash: common.Hash{0x13}, Number: 13})
+	require.False(t, ok)
+	require.Empty(t, p.accepted)
+
+	// frames of channels that timed out are pruned
+	l1Late := eth.L1BlockRef{Number: l1A.Number + 51}
+	p.dataRead(l1Late, txA)
+	require.NotContains(t, p.frames, chB)
+}
@@ -14,6 +14,7 @@ const (
 	Brotli9  CompressionAlgo = "brotli-9"
 	Brotli10 CompressionAlgo = "brotli-10"
 	Brotli11 CompressionAlgo = "brotli-11"
+	Zstd     CompressionAlgo = "zstd"
 )
 
 var CompressionAlgos = []CompressionAlgo{
@@ -22,6 +23,7 @@ var CompressionAlgos = []CompressionAlgo{
 	Brotli9,
 	Brotli10,
 	Brotli11,
+	Zstd,
 }
 
 var brotliRegexp = regexp.MustCompile(`^brotli(|-(9|10|11))$`)
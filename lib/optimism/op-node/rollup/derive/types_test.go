@@ -48,6 +48,12 @@ func TestCompressionAlgo(t *testing.T) {
 			isBrotli:                   true,
 			brotliLevel:                11,
 		},
+		{
+			name:                       "zstd",
+			algo:                       Zstd,
+			isValidCompressionAlgoType: true,
+			isBrotli:                   false,
+		},
 		{
 			name:                       "invalid",
 			algo:                       CompressionAlgo("invalid"),
@@ -1,5 +1,7 @@
 package driver
 
+import "github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
+
 type Config struct {
 	// VerifierConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation.
 	VerifierConfDepth uint64 `json:"verifier_conf_depth"`
@@ -21,6 +23,10 @@ type Config struct {
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
 
+	// SequencerOriginPolicy decides when the sequencer adopts the next L1 origin,
+	// as long as adopting it is optional.
+	SequencerOriginPolicy sequencing.OriginPolicyConfig `json:"sequencer_origin_policy"`
+
 	// RecoverMode forces the sequencer to select the next L1 Origin exactly, and create an empty block,
 	// to be compatible with verifiers forcefully generating the same block while catching up the sequencing window timeout.
 	RecoverMode bool `json:"recover_mode"`
@@ -76,6 +76,7 @@ type DerivationPipeline interface {
 	Origin() eth.L1BlockRef
 	DerivationReady() bool
 	ConfirmEngineReset()
+	DerivationInfo(safe eth.L2BlockRef) (eth.DerivationInfo, bool)
 }
 
 type EngineController interface {
@@ -119,6 +120,7 @@ type SyncStatusTracker interface {
 	event.Deriver
 	SyncStatus() *eth.SyncStatus
 	L1Head() eth.L1BlockRef
+	SubscribeHeads(topic status.HeadTopic) (*status.HeadSubscription, error)
 }
 
 type Network interface {
@@ -172,6 +174,7 @@ func NewDriver(
 	syncCfg *sync.Config,
 	sequencerConductor conductor.SequencerConductor,
 	altDA AltDAIface,
+	payloadsStore clsync.PayloadsStore,
 	managedMode bool,
 ) *Driver {
 	driverCtx, driverCancel := context.WithCancel(context.Background())
@@ -179,7 +182,7 @@ func NewDriver(
 	opts := event.DefaultRegisterOpts()
 
 	statusTracker := status.NewStatusTracker(log, metrics)
-	sys.Register("status", statusTracker, opts)
+	sys.Register(status.TrackerName, statusTracker, opts)
 
 	l1Tracker := status.NewL1Tracker(l1)
 	sys.Register("l1-blocks", l1Tracker, opts)
@@ -194,6 +197,9 @@ func NewDriver(
 		engine.NewEngineResetDeriver(driverCtx, log, cfg, l1, l2, syncCfg), opts)
 
 	clSync := clsync.NewCLSync(log, cfg, metrics) // alt-sync still uses cl-sync state to determine what to sync to
+	if payloadsStore != nil {
+		clSync.AttachStore(payloadsStore)
+	}
 	sys.Register("cl-sync", clSync, opts)
 
 	var finalizer Finalizer
@@ -208,6 +214,9 @@ func NewDriver(
 		attributes.NewAttributesHandler(log, cfg, driverCtx, l2), opts)
 
 	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l1Blobs, altDA, l2, metrics, managedMode)
+	if _, ok := safeHeadListener.(rollup.DerivationInfoListener); ok && safeHeadListener.Enabled() {
+		derivationPipeline.EnableDerivationInfo()
+	}
 
 	sys.Register("pipeline",
 		derive.NewPipelineDeriver(driverCtx, derivationPipeline), opts)
@@ -238,7 +247,12 @@ func NewDriver(
 		asyncGossiper := async.NewAsyncGossiper(driverCtx, network, log, metrics)
 		attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
 		sequencerConfDepth := confdepth.NewConfDepth(driverCfg.SequencerConfDepth, statusTracker.L1Head, l1)
-		findL1Origin := sequencing.NewL1OriginSelector(driverCtx, log, cfg, sequencerConfDepth)
+		originPolicy := sequencing.NewOriginPolicy(log, cfg, driverCfg.SequencerOriginPolicy,
+			driverCfg.SequencerConfDepth, statusTracker.L1Head)
+		if deriver, ok := originPolicy.(event.Deriver); ok {
+			sys.Register("origin-policy", deriver, opts)
+		}
+		findL1Origin := sequencing.NewL1OriginSelector(driverCtx, log, cfg, sequencerConfDepth, originPolicy)
 		sys.Register("origin-selector", findL1Origin, opts)
 		sequencer = sequencing.NewSequencer(driverCtx, log, cfg, attrBuilder, findL1Origin,
 			sequencerStateListener, sequencerConductor, asyncGossiper, metrics)
@@ -266,7 +280,7 @@ func NewDriver(
 		l1HeadSig:        make(chan eth.L1BlockRef, 10),
 		l1SafeSig:        make(chan eth.L1BlockRef, 10),
 		l1FinalizedSig:   make(chan eth.L1BlockRef, 10),
-		unsafeL2Payloads: make(chan *eth.ExecutionPayloadEnvelope, 10),
+		unsafeL2Payloads: make(chan clsync.ReceivedUnsafePayloadEvent, 10),
 		altSync:          altSync,
 	}
 
@@ -20,6 +20,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 )
 
 // Deprecated: use eth.SyncStatus instead.
@@ -61,7 +62,7 @@ type Driver struct {
 
 	// L2 Signals:
 
-	unsafeL2Payloads chan *eth.ExecutionPayloadEnvelope
+	unsafeL2Payloads chan clsync.ReceivedUnsafePayloadEvent
 
 	sequencer sequencing.SequencerIface
 	network   Network // may be nil, network for is optional
@@ -138,10 +139,20 @@ func (s *Driver) OnL1Finalized(ctx context.Context, finalized eth.L1BlockRef) er
 }
 
 func (s *Driver) OnUnsafeL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
+	return s.onUnsafeL2Payload(ctx, clsync.ReceivedUnsafePayloadEvent{Envelope: envelope})
+}
+
+// OnSignedUnsafeL2Payload is like OnUnsafeL2Payload, for payloads received with a verified signature.
+// Signed payloads may be persisted, to recover the unsafe payloads queue after a restart.
+func (s *Driver) OnSignedUnsafeL2Payload(ctx context.Context, signed *opsigner.SignedExecutionPayloadEnvelope) error {
+	return s.onUnsafeL2Payload(ctx, clsync.ReceivedUnsafePayloadEvent{Envelope: signed.Envelope, Signature: &signed.Signature})
+}
+
+func (s *Driver) onUnsafeL2Payload(ctx context.Context, ev clsync.ReceivedUnsafePayloadEvent) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case s.unsafeL2Payloads <- envelope:
+	case s.unsafeL2Payloads <- ev:
 		return nil
 	}
 }
@@ -236,11 +247,12 @@ func (s *Driver) eventLoop() {
 			if err != nil {
 				s.log.Warn("failed to check for unsafe L2 blocks to sync", "err", err)
 			}
-		case envelope := <-s.unsafeL2Payloads:
+		case ev := <-s.unsafeL2Payloads:
+			envelope := ev.Envelope
 			// If we are doing CL sync or done with engine syncing, fallback to the unsafe payload queue & CL P2P sync.
 			if s.SyncCfg.SyncMode == sync.CLSync || !s.Engine.IsEngineSyncing() {
 				s.log.Info("Optimistically queueing unsafe L2 execution payload", "id", envelope.ExecutionPayload.ID())
-				s.Emitter.Emit(clsync.ReceivedUnsafePayloadEvent{Envelope: envelope})
+				s.Emitter.Emit(ev)
 				s.metrics.RecordReceivedUnsafePayload(envelope)
 				reqStep()
 			} else if s.SyncCfg.SyncMode == sync.ELSync {
@@ -362,6 +374,15 @@ func (s *SyncDeriver) onSafeDerivedBlock(x engine.SafeDerivedEvent) {
 			// in the execution client but failed to post process it. Reset the pipeline so the safe head rolls back
 			// a little (it always rolls back at least 1 block) and then it will retry storing the entry
 			s.Emitter.Emit(rollup.ResetEvent{Err: fmt.Errorf("safe head notifications failed: %w", err)})
+			return
+		}
+		if l, ok := s.SafeHeadNotifs.(rollup.DerivationInfoListener); ok {
+			if info, ok := s.Derivation.DerivationInfo(x.Safe); ok {
+				if err := l.DerivationInfoUpdated(info); err != nil {
+					// Like a failed safe head update, roll back the safe head a little to retry recording it.
+					s.Emitter.Emit(rollup.ResetEvent{Err: fmt.Errorf("derivation info notification failed: %w", err)})
+				}
+			}
 		}
 	}
 }
@@ -491,6 +512,18 @@ func (s *Driver) StopSequencer(ctx context.Context) (common.Hash, error) {
 	return s.sequencer.Stop(ctx)
 }
 
+func (s *Driver) StopSequencerAt(ctx context.Context, target eth.SequencerStopTarget) (common.Hash, error) {
+	return s.sequencer.StopAt(ctx, target)
+}
+
+func (s *Driver) StartSequencerAt(ctx context.Context, blockHash common.Hash) error {
+	return s.sequencer.StartAt(ctx, blockHash)
+}
+
+func (s *Driver) CancelSequencerHandoff(ctx context.Context) error {
+	return s.sequencer.CancelHandoff(ctx)
+}
+
 func (s *Driver) SequencerActive(ctx context.Context) (bool, error) {
 	return s.sequencer.Active(), nil
 }
@@ -513,6 +546,11 @@ func (s *Driver) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	return s.statusTracker.SyncStatus(), nil
 }
 
+// SubscribeHeads subscribes to the changes of the given L2 head topic.
+func (s *Driver) SubscribeHeads(topic status.HeadTopic) (*status.HeadSubscription, error) {
+	return s.statusTracker.SubscribeHeads(topic)
+}
+
 // BlockRefWithStatus blocks the driver event loop and captures the syncing status,
 // along with an L2 block reference by number consistent with that same status.
 // If the event loop is too busy and the context expires, a context error is returned.
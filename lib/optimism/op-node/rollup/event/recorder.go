@@ -0,0 +1,339 @@
+package event
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+type RecordKind string
+
+const (
+	// RecordEmit is the kind of a recorded event emission.
+	RecordEmit RecordKind = "emit"
+	// RecordCall is the kind of a recorded external RPC call, e.g. an L1 fetch or an engine API call.
+	RecordCall RecordKind = "call"
+)
+
+// RecordEntry is a single entry of a recording, stored as one JSON line.
+type RecordEntry struct {
+	Kind RecordKind `json:"kind"`
+
+	// Only present if Kind == RecordEmit
+	Emitter      string          `json:"emitter,omitempty"`
+	EmitContext  uint64          `json:"emitContext,omitempty"`
+	DerivContext uint64          `json:"derivContext,omitempty"`
+	EventType    string          `json:"eventType,omitempty"`
+	Event        json.RawMessage `json:"event,omitempty"`
+	EncodeError  string          `json:"encodeError,omitempty"`
+
+	// Only present if Kind == RecordCall
+	Source string               `json:"source,omitempty"`
+	Call   *client.RecordedCall `json:"call,omitempty"`
+}
+
+// Recorder is a Tracer that records the ordered stream of emitted events to a writer,
+// to replay them later with Replay.
+// It also implements client.CallRecorder, to interleave the external responses that
+// derivers depend on with the events.
+type Recorder struct {
+	l sync.Mutex
+
+	w   *bufio.Writer
+	enc *json.Encoder
+	err error
+
+	closed bool
+}
+
+var _ Tracer = (*Recorder)(nil)
+var _ client.CallRecorder = (*Recorder)(nil)
+
+func NewRecorder(w io.Writer) *Recorder {
+	bw := bufio.NewWriter(w)
+	return &Recorder{w: bw, enc: json.NewEncoder(bw)}
+}
+
+// EventType returns the name that identifies the Go type of the event in a recording.
+func EventType(ev Event) string {
+	return reflect.TypeOf(ev).String()
+}
+
+func (r *Recorder) write(entry *RecordEntry) {
+	r.l.Lock()
+	defer r.l.Unlock()
+	if r.err != nil || r.closed {
+		return
+	}
+	r.err = r.enc.Encode(entry)
+}
+
+func (r *Recorder) OnDeriveStart(name string, ev AnnotatedEvent, derivContext uint64, startTime time.Time) {
+}
+
+func (r *Recorder) OnDeriveEnd(name string, ev AnnotatedEvent, derivContext uint64, startTime time.Time, duration time.Duration, effect bool) {
+}
+
+func (r *Recorder) OnRateLimited(name string, derivContext uint64) {
+}
+
+func (r *Recorder) OnEmit(name string, ev AnnotatedEvent, derivContext uint64, emitTime time.Time) {
+	entry := &RecordEntry{
+		Kind:         RecordEmit,
+		Emitter:      name,
+		EmitContext:  ev.EmitContext,
+		DerivContext: derivContext,
+		EventType:    EventType(ev.Event),
+	}
+	if data, err := encodeEvent(ev.Event); err != nil {
+		// Still record the emission, so the recording does not diverge from the replay at this point.
+		entry.EncodeError = err.Error()
+	} else {
+		entry.Event = data
+	}
+	r.write(entry)
+}
+
+func (r *Recorder) RecordCall(source string, call client.RecordedCall) {
+	r.write(&RecordEntry{Kind: RecordCall, Source: source, Call: &call})
+}
+
+// Close flushes the recording. Entries after Close are ignored.
+// It returns the first error encountered while writing the recording.
+func (r *Recorder) Close() error {
+	r.l.Lock()
+	defer r.l.Unlock()
+	if r.closed {
+		return r.err
+	}
+	r.closed = true
+	if r.err != nil {
+		return r.err
+	}
+	r.err = r.w.Flush()
+	return r.err
+}
+
+// Recording is a recorded stream of events and external calls.
+type Recording []RecordEntry
+
+// ReadRecording reads a recording, as written by a Recorder.
+func ReadRecording(rd io.Reader) (Recording, error) {
+	dec := json.NewDecoder(rd)
+	var out Recording
+	for {
+		var entry RecordEntry
+		if err := dec.Decode(&entry); err == io.EOF {
+			return out, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode entry %d: %w", len(out), err)
+		}
+		out = append(out, entry)
+	}
+}
+
+// Calls returns the recorded external calls of the given source, in order,
+// to replay them with a client.ReplayClient.
+func (rec Recording) Calls(source string) []client.RecordedCall {
+	var out []client.RecordedCall
+	for _, entry := range rec {
+		if entry.Kind == RecordCall && entry.Source == source && entry.Call != nil {
+			out = append(out, *entry.Call)
+		}
+	}
+	return out
+}
+
+// Emits returns the recorded event emissions, in order.
+func (rec Recording) Emits() []RecordEntry {
+	var out []RecordEntry
+	for _, entry := range rec {
+		if entry.Kind == RecordEmit {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+// EventTypes maps the recorded type names of events to their Go types, to decode recorded events.
+type EventTypes map[string]reflect.Type
+
+// Register adds the types of the given example events.
+func (t EventTypes) Register(evs ...Event) {
+	for _, ev := range evs {
+		t[EventType(ev)] = reflect.TypeOf(ev)
+	}
+}
+
+// Decode decodes a recorded event.
+func (t EventTypes) Decode(entry RecordEntry) (Event, error) {
+	if entry.EncodeError != "" {
+		return nil, fmt.Errorf("event %s could not be recorded: %s", entry.EventType, entry.EncodeError)
+	}
+	typ, ok := t[entry.EventType]
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %s", entry.EventType)
+	}
+	return decodeEventAs(typ, entry.Event)
+}
+
+var (
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+	timeType  = reflect.TypeOf(time.Time{})
+)
+
+// encodeEvent encodes an event as JSON.
+// Events with error fields are encoded as an object of their fields, with the errors encoded as their
+// message, since the standard encoding loses the error and can't be decoded into an error field.
+func encodeEvent(ev Event) ([]byte, error) {
+	v := reflect.Indirect(reflect.ValueOf(ev))
+	if v.Kind() != reflect.Struct || !hasErrorFields(v.Type()) {
+		return json.Marshal(ev)
+	}
+	fields := make(map[string]any)
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		name, ok := jsonFieldName(f)
+		if !ok {
+			continue
+		}
+		fv := v.Field(i)
+		if f.Type == errorType {
+			if fv.IsNil() {
+				fields[name] = nil
+			} else {
+				fields[name] = fv.Interface().(error).Error()
+			}
+			continue
+		}
+		fields[name] = fv.Interface()
+	}
+	return json.Marshal(fields)
+}
+
+// decodeEventAs decodes an event, as encoded by encodeEvent, into the given type.
+// Decoded errors only retain their message.
+func decodeEventAs(typ reflect.Type, data []byte) (Event, error) {
+	elem := typ
+	if typ.Kind() == reflect.Pointer {
+		elem = typ.Elem()
+	}
+	v := reflect.New(elem)
+	if err := decodeEvent(data, v); err != nil {
+		return nil, fmt.Errorf("failed to decode event %s: %w", typ, err)
+	}
+	if typ.Kind() != reflect.Pointer {
+		v = v.Elem()
+	}
+	ev, ok := v.Interface().(Event)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not an event", typ)
+	}
+	return ev, nil
+}
+
+func decodeEvent(data []byte, dest reflect.Value) error {
+	v := dest.Elem()
+	if v.Kind() != reflect.Struct || !hasErrorFields(v.Type()) {
+		return json.Unmarshal(data, dest.Interface())
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		name, ok := jsonFieldName(f)
+		if !ok {
+			continue
+		}
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if f.Type == errorType {
+			var msg *string
+			if err := json.Unmarshal(raw, &msg); err != nil {
+				return fmt.Errorf("failed to decode error field %s: %w", f.Name, err)
+			}
+			if msg != nil {
+				v.Field(i).Set(reflect.ValueOf(errors.New(*msg)))
+			}
+			continue
+		}
+		if err := json.Unmarshal(raw, v.Field(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("failed to decode field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func hasErrorFields(typ reflect.Type) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		if f := typ.Field(i); f.IsExported() && f.Type == errorType {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonFieldName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() {
+		return "", false
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = f.Name
+	}
+	return name, true
+}
+
+// withoutTimes returns a copy of the event with all wall-clock time fields zeroed,
+// including those of nested structs. These times, e.g. when a block started building,
+// differ between a recording and its replay.
+func withoutTimes(ev Event) Event {
+	v := reflect.ValueOf(ev)
+	isPtr := v.Kind() == reflect.Pointer
+	if isPtr {
+		if v.IsNil() {
+			return ev
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ev
+	}
+	cp := reflect.New(v.Type())
+	cp.Elem().Set(v)
+	zeroTimes(cp.Elem())
+	if isPtr {
+		return cp.Interface().(Event)
+	}
+	return cp.Elem().Interface().(Event)
+}
+
+func zeroTimes(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if !f.CanSet() {
+			continue
+		}
+		switch {
+		case f.Type() == timeType:
+			f.Set(reflect.Zero(timeType))
+		case f.Kind() == reflect.Struct:
+			zeroTimes(f)
+		}
+	}
+}
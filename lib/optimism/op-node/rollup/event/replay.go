@@ -0,0 +1,150 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ReplayEmitterName is the name of the emitter that re-emits the external events of a recording.
+const ReplayEmitterName = "replay"
+
+var ErrReplayDiverged = errors.New("replay diverged from recording")
+
+// Replay re-drives the derivers registered with sys with a recording.
+//
+// The events that were emitted outside of event processing, e.g. by the driver loop or by
+// external signals, are decoded with types and re-emitted in their recorded order.
+// The drainer, which must be the synchronous executor of sys, processes the events that were
+// emitted before each of them first, to reproduce the recorded order of processing.
+// Events emitted by derivers are not re-emitted, but compared against the recording instead,
+// and the first divergence is returned as an error, wrapping ErrReplayDiverged.
+//
+// External calls that the derivers make should be served by client.ReplayClient instances,
+// created with Recording.Calls.
+func Replay(sys System, drainer Drainer, types EventTypes, rec Recording) error {
+	emits := rec.Emits()
+	checker := &replayChecker{expected: emits}
+	sys.AddTracer(checker)
+	defer sys.RemoveTracer(checker)
+	em := sys.Register(ReplayEmitterName, nil, DefaultRegisterOpts())
+	defer sys.Unregister(ReplayEmitterName)
+
+	for i, entry := range emits {
+		if entry.DerivContext != 0 {
+			continue
+		}
+		// process the events that were emitted before this external event
+		if err := drainer.DrainUntil(func(Event) bool { return checker.count() >= i }, true); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to process events before event %d: %w", i, err)
+		}
+		if err := checker.result(); err != nil {
+			return err
+		}
+		ev, err := types.Decode(entry)
+		if err != nil {
+			return fmt.Errorf("failed to decode event %d: %w", i, err)
+		}
+		em.Emit(ev)
+	}
+	if err := drainer.Drain(); err != nil {
+		return fmt.Errorf("failed to process events: %w", err)
+	}
+	if err := checker.result(); err != nil {
+		return err
+	}
+	if n := checker.count(); n != len(emits) {
+		return fmt.Errorf("%w: %d events were emitted, but %d were recorded", ErrReplayDiverged, n, len(emits))
+	}
+	return nil
+}
+
+// replayChecker is a Tracer that compares the emitted events with the recorded ones.
+type replayChecker struct {
+	l sync.Mutex
+
+	expected []RecordEntry
+	emitted  int
+	err      error
+}
+
+var _ Tracer = (*replayChecker)(nil)
+
+func (c *replayChecker) OnDeriveStart(name string, ev AnnotatedEvent, derivContext uint64, startTime time.Time) {
+}
+
+func (c *replayChecker) OnDeriveEnd(name string, ev AnnotatedEvent, derivContext uint64, startTime time.Time, duration time.Duration, effect bool) {
+}
+
+func (c *replayChecker) OnRateLimited(name string, derivContext uint64) {
+}
+
+func (c *replayChecker) OnEmit(name string, ev AnnotatedEvent, derivContext uint64, emitTime time.Time) {
+	c.l.Lock()
+	defer c.l.Unlock()
+	i := c.emitted
+	c.emitted++
+	if c.err != nil {
+		return
+	}
+	if i >= len(c.expected) {
+		c.err = fmt.Errorf("%w: unexpected event %d %s emitted by %s", ErrReplayDiverged, i, ev.Event, name)
+		return
+	}
+	expected := c.expected[i]
+	// Replayed external events are emitted by the replay emitter, so the emitter only matters within event processing.
+	if derivContext != 0 && name != expected.Emitter {
+		c.err = fmt.Errorf("%w: event %d %s was emitted by %s, but was recorded as emitted by %s",
+			ErrReplayDiverged, i, ev.Event, name, expected.Emitter)
+		return
+	}
+	if typ := EventType(ev.Event); typ != expected.EventType {
+		c.err = fmt.Errorf("%w: event %d has type %s, but was recorded with type %s",
+			ErrReplayDiverged, i, typ, expected.EventType)
+		return
+	}
+	if expected.EncodeError != "" {
+		return
+	}
+	if err := compareEvent(ev.Event, expected.Event); err != nil {
+		c.err = fmt.Errorf("%w: event %d %s: %w", ErrReplayDiverged, i, ev.Event, err)
+	}
+}
+
+// compareEvent compares an emitted event with its recording.
+// Wall-clock time fields are ignored, since they differ between a recording and its replay.
+func compareEvent(ev Event, recorded json.RawMessage) error {
+	expected, err := decodeEventAs(reflect.TypeOf(ev), recorded)
+	if err != nil {
+		return err
+	}
+	data, err := encodeEvent(withoutTimes(ev))
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	expectedData, err := encodeEvent(withoutTimes(expected))
+	if err != nil {
+		return fmt.Errorf("failed to encode recorded event: %w", err)
+	}
+	if !bytes.Equal(data, expectedData) {
+		return fmt.Errorf("%s differs from recorded event %s", data, expectedData)
+	}
+	return nil
+}
+
+func (c *replayChecker) count() int {
+	c.l.Lock()
+	defer c.l.Unlock()
+	return c.emitted
+}
+
+func (c *replayChecker) result() error {
+	c.l.Lock()
+	defer c.l.Unlock()
+	return c.err
+}
@@ -0,0 +1,173 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type countEvent struct {
+	Count uint64
+}
+
+func (ev countEvent) String() string {
+	return "count"
+}
+
+// countRPC answers each call with an incrementing count.
+type countRPC struct {
+	count uint64
+}
+
+func (c *countRPC) Close() {}
+
+func (c *countRPC) CallContext(_ context.Context, result any, method string, args ...any) error {
+	if method != "test_next" {
+		return errors.New("unknown method")
+	}
+	c.count += 1
+	*(result.(*uint64)) = c.count
+	return nil
+}
+
+func (c *countRPC) BatchCallContext(context.Context, []rpc.BatchElem) error {
+	return errors.New("not supported")
+}
+
+func (c *countRPC) Subscribe(context.Context, string, any, ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+// runCounter sets up a system with a deriver that fetches the next count for every count event,
+// until it reaches a count of 3.
+func runCounter(t *testing.T, cl client.RPC) (*Sys, *GlobalSyncExec, Emitter) {
+	logger := testlog.Logger(t, log.LevelError)
+	ex := NewGlobalSynchronous(context.Background())
+	sys := NewSystem(logger, ex)
+	t.Cleanup(sys.Stop)
+	var em Emitter
+	em = sys.Register("counter", DeriverFunc(func(ev Event) bool {
+		x, ok := ev.(countEvent)
+		if !ok || x.Count >= 3 {
+			return false
+		}
+		var next uint64
+		require.NoError(t, cl.CallContext(context.Background(), &next, "test_next", x.Count))
+		em.Emit(countEvent{Count: next})
+		return true
+	}), DefaultRegisterOpts())
+	return sys, ex, em
+}
+
+func TestRecordReplay(t *testing.T) {
+	var buf bytes.Buffer
+	recorder := NewRecorder(&buf)
+	sys, ex, em := runCounter(t, client.NewRecordingClient(&countRPC{}, "test", recorder))
+	sys.AddTracer(recorder)
+	em.Emit(countEvent{Count: 0})
+	require.NoError(t, ex.Drain())
+	em.Emit(TestEvent{})
+	require.NoError(t, ex.Drain())
+	require.NoError(t, recorder.Close())
+
+	rec, err := ReadRecording(&buf)
+	require.NoError(t, err)
+	require.Len(t, rec.Emits(), 5)
+	require.Len(t, rec.Calls("test"), 3)
+
+	types := make(EventTypes)
+	types.Register(countEvent{}, TestEvent{})
+
+	t.Run("identical", func(t *testing.T) {
+		replayClient := client.NewReplayClient(rec.Calls("test"))
+		sys, ex, _ := runCounter(t, replayClient)
+		require.NoError(t, Replay(sys, ex, types, rec))
+		require.Zero(t, replayClient.Remaining())
+	})
+	t.Run("diverged", func(t *testing.T) {
+		// the deriver fetches different counts than recorded
+		sys, ex, _ := runCounter(t, &countRPC{count: 1})
+		require.ErrorIs(t, Replay(sys, ex, types, rec), ErrReplayDiverged)
+	})
+	t.Run("unknown type", func(t *testing.T) {
+		replayClient := client.NewReplayClient(rec.Calls("test"))
+		sys, ex, _ := runCounter(t, replayClient)
+		require.ErrorContains(t, Replay(sys, ex, make(EventTypes), rec), "unknown event type")
+	})
+}
+
+type timedEvent struct {
+	Count   uint64
+	Started time.Time
+	Err     error
+}
+
+func (ev timedEvent) String() string {
+	return "timed"
+}
+
+// runTimed sets up a system with a deriver that answers a count event with a timed event,
+// that carries the wall-clock time and an error.
+func runTimed(t *testing.T, errMsg string) (*Sys, *GlobalSyncExec, Emitter) {
+	logger := testlog.Logger(t, log.LevelError)
+	ex := NewGlobalSynchronous(context.Background())
+	sys := NewSystem(logger, ex)
+	t.Cleanup(sys.Stop)
+	var em Emitter
+	em = sys.Register("timer", DeriverFunc(func(ev Event) bool {
+		x, ok := ev.(countEvent)
+		if !ok {
+			return false
+		}
+		em.Emit(timedEvent{Count: x.Count, Started: time.Now(), Err: fmt.Errorf("%s %d", errMsg, x.Count)})
+		return true
+	}), DefaultRegisterOpts())
+	return sys, ex, em
+}
+
+func TestReplayTimedEvents(t *testing.T) {
+	var buf bytes.Buffer
+	recorder := NewRecorder(&buf)
+	sys, ex, em := runTimed(t, "count")
+	sys.AddTracer(recorder)
+	em.Emit(countEvent{Count: 1})
+	require.NoError(t, ex.Drain())
+	em.Emit(timedEvent{Count: 2, Started: time.Now(), Err: errors.New("external")})
+	require.NoError(t, ex.Drain())
+	require.NoError(t, recorder.Close())
+
+	rec, err := ReadRecording(&buf)
+	require.NoError(t, err)
+	require.Len(t, rec.Emits(), 3)
+
+	types := make(EventTypes)
+	types.Register(countEvent{}, timedEvent{})
+	ev, err := types.Decode(rec.Emits()[2])
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), ev.(timedEvent).Count)
+	require.EqualError(t, ev.(timedEvent).Err, "external")
+	require.False(t, ev.(timedEvent).Started.IsZero())
+
+	t.Run("identical", func(t *testing.T) {
+		// the times differ from the recording, but are ignored
+		time.Sleep(time.Millisecond)
+		sys, ex, _ := runTimed(t, "count")
+		require.NoError(t, Replay(sys, ex, types, rec))
+	})
+	t.Run("diverged", func(t *testing.T) {
+		sys, ex, _ := runTimed(t, "other")
+		require.ErrorIs(t, Replay(sys, ex, types, rec), ErrReplayDiverged)
+	})
+}
@@ -0,0 +1,228 @@
+package event
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// ChromeTracer writes a trace in the Chrome Trace Event format, which can be inspected with
+// Perfetto (ui.perfetto.dev) or chrome://tracing.
+//
+// Each deriver gets its own track. Event processing that had an effect is recorded as a span on the
+// track of the deriver, and a flow arrow connects the emission of the event to the span.
+// Derivers that ignore an event are not recorded, to keep the trace readable.
+// The trace is bounded: once maxEvents trace events have been written, further trace events are dropped.
+type ChromeTracer struct {
+	l sync.Mutex
+
+	w   *bufio.Writer
+	err error
+
+	tracks map[string]uint64
+
+	maxEvents uint64
+	events    uint64
+	dropped   uint64
+	closed    bool
+}
+
+var _ Tracer = (*ChromeTracer)(nil)
+
+// chromeTraceEvent is a single entry of the Chrome Trace Event format.
+// See https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU
+type chromeTraceEvent struct {
+	Name  string         `json:"name"`
+	Cat   string         `json:"cat,omitempty"`
+	Phase string         `json:"ph"`
+	Ts    float64        `json:"ts"`
+	Dur   float64        `json:"dur,omitempty"`
+	Pid   uint64         `json:"pid"`
+	Tid   uint64         `json:"tid"`
+	ID    uint64         `json:"id,omitempty"`
+	Bp    string         `json:"bp,omitempty"`
+	Scope string         `json:"s,omitempty"`
+	Args  map[string]any `json:"args,omitempty"`
+}
+
+// NewChromeTracer creates a ChromeTracer that writes to w, until Close is called.
+// A maxEvents of 0 does not bound the trace.
+func NewChromeTracer(w io.Writer, maxEvents uint64) *ChromeTracer {
+	t := &ChromeTracer{
+		w:         bufio.NewWriter(w),
+		tracks:    make(map[string]uint64),
+		maxEvents: maxEvents,
+	}
+	_, t.err = t.w.WriteString("[\n")
+	return t
+}
+
+// microseconds returns the time in the microsecond unit used by trace events.
+func microseconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e3
+}
+
+func (ct *ChromeTracer) write(ev *chromeTraceEvent) {
+	if ct.err != nil || ct.closed {
+		return
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		ct.err = err
+		return
+	}
+	if ct.events > 0 {
+		if _, ct.err = ct.w.WriteString(",\n"); ct.err != nil {
+			return
+		}
+	}
+	_, ct.err = ct.w.Write(data)
+	ct.events++
+}
+
+// reserve returns whether n more trace events fit in the bound, and counts them as dropped if not.
+func (ct *ChromeTracer) reserve(n uint64) bool {
+	if ct.maxEvents > 0 && ct.events+n > ct.maxEvents {
+		ct.dropped += n
+		return false
+	}
+	return true
+}
+
+// track returns the track of the named deriver, writing the track name on first use.
+func (ct *ChromeTracer) track(name string) uint64 {
+	if tid, ok := ct.tracks[name]; ok {
+		return tid
+	}
+	tid := uint64(len(ct.tracks) + 1)
+	ct.tracks[name] = tid
+	ct.write(&chromeTraceEvent{
+		Name:  "thread_name",
+		Phase: "M",
+		Pid:   1,
+		Tid:   tid,
+		Args:  map[string]any{"name": name},
+	})
+	return tid
+}
+
+func (ct *ChromeTracer) OnDeriveStart(name string, ev AnnotatedEvent, derivContext uint64, startTime time.Time) {
+	// Spans are recorded when they end, once it is known whether processing had an effect.
+}
+
+func (ct *ChromeTracer) OnDeriveEnd(name string, ev AnnotatedEvent, derivContext uint64, startTime time.Time, duration time.Duration, effect bool) {
+	if !effect {
+		return
+	}
+	ct.l.Lock()
+	defer ct.l.Unlock()
+	if !ct.reserve(3) { // possibly a track name, the span and the flow end
+		return
+	}
+	tid := ct.track(name)
+	ts := microseconds(startTime)
+	eventName := ev.Event.String()
+	ct.write(&chromeTraceEvent{
+		Name:  eventName,
+		Cat:   "derive",
+		Phase: "X",
+		Ts:    ts,
+		Dur:   float64(duration.Nanoseconds()) / 1e3,
+		Pid:   1,
+		Tid:   tid,
+		Args: map[string]any{
+			"emit_context":  ev.EmitContext,
+			"deriv_context": derivContext,
+		},
+	})
+	ct.write(&chromeTraceEvent{
+		Name:  eventName,
+		Cat:   "emit",
+		Phase: "f",
+		Bp:    "e",
+		Ts:    ts,
+		Pid:   1,
+		Tid:   tid,
+		ID:    ev.EmitContext,
+	})
+}
+
+func (ct *ChromeTracer) OnRateLimited(name string, derivContext uint64) {
+	ct.l.Lock()
+	defer ct.l.Unlock()
+	if !ct.reserve(2) {
+		return
+	}
+	ct.write(&chromeTraceEvent{
+		Name:  "rate-limited",
+		Cat:   "emit",
+		Phase: "i",
+		Scope: "t",
+		Ts:    microseconds(time.Now()),
+		Pid:   1,
+		Tid:   ct.track(name),
+		Args:  map[string]any{"deriv_context": derivContext},
+	})
+}
+
+func (ct *ChromeTracer) OnEmit(name string, ev AnnotatedEvent, derivContext uint64, emitTime time.Time) {
+	ct.l.Lock()
+	defer ct.l.Unlock()
+	if !ct.reserve(3) { // possibly a track name, an instant event and the flow start
+		return
+	}
+	tid := ct.track(name)
+	ts := microseconds(emitTime)
+	eventName := ev.Event.String()
+	if derivContext == 0 {
+		// Emitted outside of event processing, so there is no span for the flow to start from.
+		ct.write(&chromeTraceEvent{
+			Name:  eventName,
+			Cat:   "emit",
+			Phase: "i",
+			Scope: "t",
+			Ts:    ts,
+			Pid:   1,
+			Tid:   tid,
+			Args:  map[string]any{"emit_context": ev.EmitContext},
+		})
+	}
+	ct.write(&chromeTraceEvent{
+		Name:  eventName,
+		Cat:   "emit",
+		Phase: "s",
+		Ts:    ts,
+		Pid:   1,
+		Tid:   tid,
+		ID:    ev.EmitContext,
+	})
+}
+
+// Stats returns the number of written and dropped trace events.
+func (ct *ChromeTracer) Stats() (events uint64, dropped uint64) {
+	ct.l.Lock()
+	defer ct.l.Unlock()
+	return ct.events, ct.dropped
+}
+
+// Close completes the trace. Trace events after Close are ignored.
+// It returns the first error encountered while writing the trace.
+func (ct *ChromeTracer) Close() error {
+	ct.l.Lock()
+	defer ct.l.Unlock()
+	if ct.closed {
+		return ct.err
+	}
+	ct.closed = true
+	if ct.err != nil {
+		return ct.err
+	}
+	if _, err := ct.w.WriteString("\n]\n"); err != nil {
+		ct.err = err
+		return err
+	}
+	ct.err = ct.w.Flush()
+	return ct.err
+}
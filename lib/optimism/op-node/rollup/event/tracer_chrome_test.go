@@ -0,0 +1,98 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestChromeTracer(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	ex := NewGlobalSynchronous(context.Background())
+	sys := NewSystem(logger, ex)
+	var fooEmitter Emitter
+	foo := DeriverFunc(func(ev Event) bool {
+		switch ev.(type) {
+		case TestEvent:
+			fooEmitter.Emit(FooEvent{})
+			return true
+		}
+		return false
+	})
+	bar := DeriverFunc(func(ev Event) bool {
+		_, ok := ev.(FooEvent)
+		return ok
+	})
+	var buf bytes.Buffer
+	tracer := NewChromeTracer(&buf, 0)
+	sys.AddTracer(tracer)
+	fooEmitter = sys.Register("foo", foo, DefaultRegisterOpts())
+	sys.Register("bar", bar, DefaultRegisterOpts())
+
+	fooEmitter.Emit(TestEvent{})
+	require.NoError(t, ex.Drain())
+	require.NoError(t, tracer.Close())
+
+	var trace []chromeTraceEvent
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &trace), "trace must be valid JSON")
+	events, dropped := tracer.Stats()
+	require.Equal(t, uint64(len(trace)), events)
+	require.Zero(t, dropped)
+
+	tracks := make(map[string]uint64)
+	phases := make(map[string][]chromeTraceEvent)
+	for _, ev := range trace {
+		if ev.Phase == "M" {
+			tracks[ev.Args["name"].(string)] = ev.Tid
+		}
+		phases[ev.Phase] = append(phases[ev.Phase], ev)
+	}
+	require.Len(t, tracks, 2, "one track per deriver")
+	require.NotEqual(t, tracks["foo"], tracks["bar"])
+
+	// each deriver processed one event with effect
+	require.Len(t, phases["X"], 2)
+	for _, span := range phases["X"] {
+		require.Contains(t, span.Args, "emit_context")
+		require.Contains(t, span.Args, "deriv_context")
+	}
+	// each emitted event has a flow from the emitter to the deriver that processed it
+	require.Len(t, phases["s"], 2)
+	require.Len(t, phases["f"], 2)
+	for i, start := range phases["s"] {
+		require.Equal(t, start.ID, phases["f"][i].ID)
+	}
+	require.Equal(t, tracks["foo"], phases["s"][1].Tid, "FooEvent is emitted by foo")
+	require.Equal(t, tracks["bar"], phases["f"][1].Tid, "FooEvent is processed by bar")
+	// the first event was emitted outside of event processing, and is anchored by an instant event
+	require.Len(t, phases["i"], 1)
+
+	// events after closing are ignored
+	fooEmitter.Emit(TestEvent{})
+	require.NoError(t, ex.Drain())
+	eventsAfterClose, _ := tracer.Stats()
+	require.Equal(t, events, eventsAfterClose)
+}
+
+func TestChromeTracerBounded(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := NewChromeTracer(&buf, 5)
+	for i := 0; i < 10; i++ {
+		tracer.OnEmit("foo", AnnotatedEvent{Event: TestEvent{}, EmitContext: uint64(i + 1)}, 0, time.Now())
+	}
+	require.NoError(t, tracer.Close())
+	var trace []chromeTraceEvent
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &trace))
+	events, dropped := tracer.Stats()
+	require.LessOrEqual(t, events, uint64(5))
+	require.Equal(t, uint64(len(trace)), events)
+	require.NotZero(t, dropped)
+}
@@ -20,3 +20,10 @@ type SafeHeadListener interface {
 	// The L1 block that made the new safe head safe is unknown.
 	SafeHeadReset(resetSafeHead eth.L2BlockRef) error
 }
+
+// DerivationInfoListener may additionally be implemented by a SafeHeadListener
+// to be notified of the L1 data that each new safe block was derived from.
+type DerivationInfoListener interface {
+	// DerivationInfoUpdated is called for a new safe block, after SafeHeadUpdated.
+	DerivationInfoUpdated(info eth.DerivationInfo) error
+}
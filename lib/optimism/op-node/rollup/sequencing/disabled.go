@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 var ErrSequencerNotEnabled = errors.New("sequencer is not enabled")
@@ -40,6 +41,18 @@ func (ds DisabledSequencer) Stop(ctx context.Context) (hash common.Hash, err err
 	return common.Hash{}, ErrSequencerNotEnabled
 }
 
+func (ds DisabledSequencer) StopAt(ctx context.Context, target eth.SequencerStopTarget) (hash common.Hash, err error) {
+	return common.Hash{}, ErrSequencerNotEnabled
+}
+
+func (ds DisabledSequencer) StartAt(ctx context.Context, head common.Hash) error {
+	return ErrSequencerNotEnabled
+}
+
+func (ds DisabledSequencer) CancelHandoff(ctx context.Context) error {
+	return ErrSequencerNotEnabled
+}
+
 func (ds DisabledSequencer) SetMaxSafeLag(ctx context.Context, v uint64) error {
 	return ErrSequencerNotEnabled
 }
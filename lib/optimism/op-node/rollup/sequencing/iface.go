@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 type SequencerIface interface {
@@ -17,6 +18,9 @@ type SequencerIface interface {
 	Init(ctx context.Context, active bool) error
 	Start(ctx context.Context, head common.Hash) error
 	Stop(ctx context.Context) (hash common.Hash, err error)
+	StopAt(ctx context.Context, target eth.SequencerStopTarget) (hash common.Hash, err error)
+	StartAt(ctx context.Context, head common.Hash) error
+	CancelHandoff(ctx context.Context) error
 	SetMaxSafeLag(ctx context.Context, v uint64) error
 	OverrideLeader(ctx context.Context) error
 	ConductorEnabled(ctx context.Context) bool
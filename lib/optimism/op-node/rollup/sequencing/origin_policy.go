@@ -0,0 +1,234 @@
+package sequencing
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// OriginPolicy decides whether the sequencer adopts the next L1 origin for the next L2 block,
+// once the next origin is known and the L2 block time allows it.
+// The policy is not consulted when adopting the next origin is not optional:
+// in recover mode, and when the current origin would exceed the max sequencer drift.
+type OriginPolicy interface {
+	AdoptNext(l2Head eth.L2BlockRef, current, next eth.L1BlockRef) bool
+}
+
+type OriginPolicyKind string
+
+const (
+	// OriginPolicyEager adopts the next origin as soon as possible.
+	OriginPolicyEager OriginPolicyKind = "eager"
+	// OriginPolicyAdaptive requires additional L1 confirmations of the next origin after L1 reorgs.
+	OriginPolicyAdaptive OriginPolicyKind = "adaptive"
+	// OriginPolicyDriftMargin is adaptive, but adopts the next origin regardless of L1 reorgs
+	// once the drift margin of the current origin becomes small.
+	OriginPolicyDriftMargin OriginPolicyKind = "drift-margin"
+)
+
+var OriginPolicyKinds = []OriginPolicyKind{OriginPolicyEager, OriginPolicyAdaptive, OriginPolicyDriftMargin}
+
+func (k OriginPolicyKind) String() string {
+	return string(k)
+}
+
+func (k *OriginPolicyKind) Set(value string) error {
+	if !slices.Contains(OriginPolicyKinds, OriginPolicyKind(value)) {
+		return fmt.Errorf("unknown origin policy: %q", value)
+	}
+	*k = OriginPolicyKind(value)
+	return nil
+}
+
+func (k *OriginPolicyKind) Clone() any {
+	cpy := *k
+	return &cpy
+}
+
+type OriginPolicyConfig struct {
+	// Kind of origin policy. Eager if empty.
+	Kind OriginPolicyKind `json:"kind"`
+	// MaxExtraConfs is the maximum number of L1 confirmations that the adaptive policies add after L1 reorgs.
+	MaxExtraConfs uint64 `json:"max_extra_confs"`
+	// MinDriftMargin is the drift margin in seconds, below which the drift-margin policy adopts the next origin.
+	MinDriftMargin uint64 `json:"min_drift_margin"`
+}
+
+func (c *OriginPolicyConfig) Check() error {
+	if c.Kind != "" && !slices.Contains(OriginPolicyKinds, c.Kind) {
+		return fmt.Errorf("unknown origin policy: %q", c.Kind)
+	}
+	return nil
+}
+
+// NewOriginPolicy creates the configured origin policy.
+// The baseDepth is the confirmation depth that the L1 source of the origin selector already applies.
+// Policies that track L1 reorgs also implement event.Deriver, and must be registered to the event system.
+func NewOriginPolicy(log log.Logger, rollupCfg *rollup.Config, cfg OriginPolicyConfig,
+	baseDepth uint64, l1Head func() eth.L1BlockRef) OriginPolicy {
+	switch cfg.Kind {
+	case OriginPolicyAdaptive:
+		return NewAdaptiveConfDepthPolicy(log, baseDepth, cfg.MaxExtraConfs, l1Head)
+	case OriginPolicyDriftMargin:
+		adaptive := NewAdaptiveConfDepthPolicy(log, baseDepth, cfg.MaxExtraConfs, l1Head)
+		return NewDriftMarginPolicy(rollupCfg, cfg.MinDriftMargin, adaptive)
+	default:
+		return EagerPolicy{}
+	}
+}
+
+// EagerPolicy always adopts the next origin as soon as possible.
+type EagerPolicy struct{}
+
+var _ OriginPolicy = EagerPolicy{}
+
+func (EagerPolicy) AdoptNext(l2Head eth.L2BlockRef, current, next eth.L1BlockRef) bool {
+	return true
+}
+
+// adaptiveDecayBlocks is the number of L1 blocks without reorg after which
+// the adaptive policy drops one of its extra confirmations.
+const adaptiveDecayBlocks = 10
+
+// AdaptiveConfDepthPolicy adopts the next origin only once it has extra L1 confirmations,
+// beyond the base confirmation depth, after the L1 chain reorged.
+// Every reorg adds its depth to the extra confirmations, up to a maximum,
+// and the extra confirmations decay again while the L1 chain progresses without reorgs.
+// This trades sequencing on slightly older L1 origins for fewer unsafe L2 reorgs during L1 reorg storms.
+//
+// Only L1 head changes to a block at or below the previous head are counted as reorgs:
+// a jump to a higher block on another fork is indistinguishable from missed head updates.
+type AdaptiveConfDepthPolicy struct {
+	log       log.Logger
+	baseDepth uint64
+	maxExtra  uint64
+	l1Head    func() eth.L1BlockRef
+
+	mu sync.Mutex
+	// prevHead is the last seen L1 head
+	prevHead eth.L1BlockRef
+	// extra is the number of extra confirmations, as of the L1 head at lastReorg
+	extra     uint64
+	lastReorg uint64
+}
+
+var _ OriginPolicy = (*AdaptiveConfDepthPolicy)(nil)
+var _ event.Deriver = (*AdaptiveConfDepthPolicy)(nil)
+
+func NewAdaptiveConfDepthPolicy(log log.Logger, baseDepth uint64, maxExtra uint64, l1Head func() eth.L1BlockRef) *AdaptiveConfDepthPolicy {
+	return &AdaptiveConfDepthPolicy{
+		log:       log,
+		baseDepth: baseDepth,
+		maxExtra:  maxExtra,
+		l1Head:    l1Head,
+	}
+}
+
+func (p *AdaptiveConfDepthPolicy) OnEvent(ev event.Event) bool {
+	x, ok := ev.(status.L1UnsafeEvent)
+	if !ok {
+		return false
+	}
+	p.onL1Head(x.L1Unsafe)
+	return true
+}
+
+func (p *AdaptiveConfDepthPolicy) onL1Head(head eth.L1BlockRef) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	prev := p.prevHead
+	p.prevHead = head
+	if prev == (eth.L1BlockRef{}) || prev.Hash == head.Hash || prev.Hash == head.ParentHash {
+		return
+	}
+	if head.Number > prev.Number {
+		return
+	}
+	depth := prev.Number - head.Number + 1
+	p.extra = min(p.extraAt(head)+depth, p.maxExtra)
+	p.lastReorg = head.Number
+	p.log.Warn("L1 reorg, sequencer requires extra L1 origin confirmations",
+		"depth", depth, "extra_confs", p.extra, "l1_head", head)
+}
+
+// extraAt returns the extra confirmations, after decay up to the given L1 head.
+func (p *AdaptiveConfDepthPolicy) extraAt(head eth.L1BlockRef) uint64 {
+	if head.Number <= p.lastReorg {
+		return p.extra
+	}
+	decay := (head.Number - p.lastReorg) / adaptiveDecayBlocks
+	if decay >= p.extra {
+		return 0
+	}
+	return p.extra - decay
+}
+
+// ExtraConfs returns the current number of extra confirmations required of the next origin.
+func (p *AdaptiveConfDepthPolicy) ExtraConfs() uint64 {
+	head := p.l1Head()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.extraAt(head)
+}
+
+func (p *AdaptiveConfDepthPolicy) AdoptNext(l2Head eth.L2BlockRef, current, next eth.L1BlockRef) bool {
+	extra := p.ExtraConfs()
+	if extra == 0 {
+		return true
+	}
+	head := p.l1Head()
+	if next.Number+p.baseDepth+extra <= head.Number {
+		return true
+	}
+	p.log.Debug("Holding on to current L1 origin, next origin lacks extra confirmations",
+		"current", current, "next", next, "l1_head", head, "extra_confs", extra)
+	return false
+}
+
+// DriftMarginPolicy adopts the next origin when the inner policy does,
+// and also when the drift margin of the current origin falls below the minimum margin,
+// to prefer origins that leave the sequencer room to keep including transactions
+// if L1 stalls, over holding on to a more confirmed origin.
+// The drift margin is the time left before the next L2 block would exceed the max sequencer drift.
+type DriftMarginPolicy struct {
+	spec      *rollup.ChainSpec
+	blockTime uint64
+	minMargin uint64
+	inner     OriginPolicy
+}
+
+var _ OriginPolicy = (*DriftMarginPolicy)(nil)
+var _ event.Deriver = (*DriftMarginPolicy)(nil)
+
+func NewDriftMarginPolicy(rollupCfg *rollup.Config, minMargin uint64, inner OriginPolicy) *DriftMarginPolicy {
+	return &DriftMarginPolicy{
+		spec:      rollup.NewChainSpec(rollupCfg),
+		blockTime: rollupCfg.BlockTime,
+		minMargin: minMargin,
+		inner:     inner,
+	}
+}
+
+// OnEvent forwards events to the inner policy, if it tracks events.
+func (p *DriftMarginPolicy) OnEvent(ev event.Event) bool {
+	if d, ok := p.inner.(event.Deriver); ok {
+		return d.OnEvent(ev)
+	}
+	return false
+}
+
+func (p *DriftMarginPolicy) AdoptNext(l2Head eth.L2BlockRef, current, next eth.L1BlockRef) bool {
+	if p.inner.AdoptNext(l2Head, current, next) {
+		return true
+	}
+	msd := p.spec.MaxSequencerDrift(current.Time)
+	drift := l2Head.Time + p.blockTime - current.Time
+	return drift+p.minMargin > msd
+}
@@ -0,0 +1,144 @@
+package sequencing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func l1Ref(number uint64, fork byte, parent common.Hash) eth.L1BlockRef {
+	return eth.L1BlockRef{
+		Hash:       common.Hash{byte(number), fork},
+		Number:     number,
+		ParentHash: parent,
+		Time:       number * 12,
+	}
+}
+
+func TestAdaptiveConfDepthPolicy(t *testing.T) {
+	var head eth.L1BlockRef
+	p := NewAdaptiveConfDepthPolicy(testlog.Logger(t, log.LevelError), 4, 6, func() eth.L1BlockRef { return head })
+	setHead := func(ref eth.L1BlockRef) {
+		head = ref
+		require.True(t, p.OnEvent(status.L1UnsafeEvent{L1Unsafe: ref}))
+	}
+
+	a := l1Ref(100, 0, common.Hash{})
+	b := l1Ref(101, 0, a.Hash)
+	setHead(a)
+	setHead(b)
+	require.Zero(t, p.ExtraConfs(), "no reorgs, no extra confirmations")
+	require.True(t, p.AdoptNext(eth.L2BlockRef{}, a, b))
+
+	// A jump ahead is not counted as reorg
+	c := l1Ref(105, 0, common.Hash{0xff})
+	setHead(c)
+	require.Zero(t, p.ExtraConfs())
+
+	// Reorg of depth 2
+	c2 := l1Ref(104, 1, common.Hash{0xee})
+	setHead(c2)
+	require.Equal(t, uint64(2), p.ExtraConfs())
+	next := l1Ref(99, 0, common.Hash{})
+	require.False(t, p.AdoptNext(eth.L2BlockRef{}, a, next), "99+4+2 > 104")
+	next = l1Ref(98, 0, common.Hash{})
+	require.True(t, p.AdoptNext(eth.L2BlockRef{}, a, next), "98+4+2 <= 104")
+
+	// Reorgs add up, to the maximum
+	setHead(l1Ref(104, 2, common.Hash{0xdd}))
+	require.Equal(t, uint64(3), p.ExtraConfs())
+	setHead(l1Ref(100, 3, common.Hash{0xcc}))
+	require.Equal(t, uint64(6), p.ExtraConfs())
+
+	// The extra confirmations decay while L1 progresses without reorgs
+	head = l1Ref(100+adaptiveDecayBlocks, 3, common.Hash{})
+	require.Equal(t, uint64(5), p.ExtraConfs())
+	head = l1Ref(100+6*adaptiveDecayBlocks, 3, common.Hash{})
+	require.Zero(t, p.ExtraConfs())
+}
+
+func TestDriftMarginPolicy(t *testing.T) {
+	cfg := &rollup.Config{
+		MaxSequencerDrift: 600,
+		BlockTime:         2,
+	}
+	holding := originPolicyFn(func(l2Head eth.L2BlockRef, current, next eth.L1BlockRef) bool { return false })
+	p := NewDriftMarginPolicy(cfg, 100, holding)
+	current := eth.L1BlockRef{Number: 10, Time: 1000}
+	next := eth.L1BlockRef{Number: 11, Time: 1012}
+	require.False(t, p.AdoptNext(eth.L2BlockRef{Time: 1400}, current, next), "wide margin")
+	require.False(t, p.AdoptNext(eth.L2BlockRef{Time: 1498}, current, next), "margin of exactly 100")
+	require.True(t, p.AdoptNext(eth.L2BlockRef{Time: 1500}, current, next), "margin below 100")
+
+	adopting := originPolicyFn(func(l2Head eth.L2BlockRef, current, next eth.L1BlockRef) bool { return true })
+	p = NewDriftMarginPolicy(cfg, 100, adopting)
+	require.True(t, p.AdoptNext(eth.L2BlockRef{Time: 1400}, current, next), "inner policy adopts")
+}
+
+type originPolicyFn func(l2Head eth.L2BlockRef, current, next eth.L1BlockRef) bool
+
+func (fn originPolicyFn) AdoptNext(l2Head eth.L2BlockRef, current, next eth.L1BlockRef) bool {
+	return fn(l2Head, current, next)
+}
+
+// TestOriginSelectorPolicy ensures that the origin selector consults the policy
+// while adopting the next origin is optional, and ignores it when the sequencer drift is exceeded.
+func TestOriginSelectorPolicy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := testlog.Logger(t, log.LevelCrit)
+	cfg := &rollup.Config{
+		MaxSequencerDrift: 8,
+		BlockTime:         2,
+	}
+	l1 := &testutils.MockL1Source{}
+	defer l1.AssertExpectations(t)
+	a := eth.L1BlockRef{
+		Hash:   common.Hash{'a'},
+		Number: 10,
+		Time:   20,
+	}
+	b := eth.L1BlockRef{
+		Hash:       common.Hash{'b'},
+		Number:     11,
+		Time:       22,
+		ParentHash: a.Hash,
+	}
+	l2Head := eth.L2BlockRef{
+		L1Origin: a.ID(),
+		Time:     24,
+	}
+
+	s := NewL1OriginSelector(ctx, log, cfg, l1, originPolicyFn(func(eth.L2BlockRef, eth.L1BlockRef, eth.L1BlockRef) bool {
+		return false
+	}))
+	s.currentOrigin = a
+	s.nextOrigin = b
+
+	next, err := s.FindL1Origin(ctx, l2Head)
+	require.NoError(t, err)
+	require.Equal(t, a, next, "policy holds on to the current origin")
+
+	s.SetRecoverMode(true)
+	l1.ExpectL1BlockRefByHash(a.Hash, a, nil)
+	l1.ExpectL1BlockRefByNumber(b.Number, b, nil)
+	next, err = s.FindL1Origin(ctx, l2Head)
+	require.NoError(t, err)
+	require.Equal(t, b, next, "recover mode ignores the policy")
+	s.SetRecoverMode(false)
+
+	l2Head.Time = 28
+	next, err = s.FindL1Origin(ctx, l2Head)
+	require.NoError(t, err)
+	require.Equal(t, b, next, "past the sequencer drift the policy is ignored")
+}
@@ -33,6 +33,8 @@ type L1OriginSelector struct {
 
 	l1 L1Blocks
 
+	policy OriginPolicy
+
 	// Internal cache of L1 origins for faster access.
 	currentOrigin eth.L1BlockRef
 	nextOrigin    eth.L1BlockRef
@@ -40,13 +42,14 @@ type L1OriginSelector struct {
 	mu sync.Mutex
 }
 
-func NewL1OriginSelector(ctx context.Context, log log.Logger, cfg *rollup.Config, l1 L1Blocks) *L1OriginSelector {
+func NewL1OriginSelector(ctx context.Context, log log.Logger, cfg *rollup.Config, l1 L1Blocks, policy OriginPolicy) *L1OriginSelector {
 	return &L1OriginSelector{
-		ctx:  ctx,
-		log:  log,
-		cfg:  cfg,
-		spec: rollup.NewChainSpec(cfg),
-		l1:   l1,
+		ctx:    ctx,
+		log:    log,
+		cfg:    cfg,
+		spec:   rollup.NewChainSpec(cfg),
+		l1:     l1,
+		policy: policy,
 	}
 }
 
@@ -78,10 +81,12 @@ func (los *L1OriginSelector) FindL1Origin(ctx context.Context, l2Head eth.L2Bloc
 	// If the next L2 block time is greater than the next origin block's time, we can choose to
 	// start building on top of the next origin. Sequencer implementation has some leeway here and
 	// could decide to continue to build on top of the previous origin until the Sequencer runs out
-	// of slack. For simplicity, we implement our Sequencer to always start building on the latest
-	// L1 block when we can.
+	// of slack. The origin policy makes this choice; the default policy always starts building on
+	// the latest L1 block when we can. Recover mode always adopts the next origin.
 	if nextOrigin != (eth.L1BlockRef{}) && l2Head.Time+los.cfg.BlockTime >= nextOrigin.Time {
-		return nextOrigin, nil
+		if los.recoverMode.Load() || los.policy.AdoptNext(l2Head, currentOrigin, nextOrigin) {
+			return nextOrigin, nil
+		}
 	}
 
 	msd := los.spec.MaxSequencerDrift(currentOrigin.Time)
@@ -26,6 +26,15 @@ const sealingDuration = time.Millisecond * 50
 var (
 	ErrSequencerAlreadyStarted = errors.New("sequencer already running")
 	ErrSequencerAlreadyStopped = errors.New("sequencer not running")
+	ErrStopAlreadyScheduled    = errors.New("a different sequencer stop is already scheduled")
+	ErrStopTargetReached       = errors.New("sequencer stop target already reached")
+	ErrScheduledStopCancelled  = errors.New("scheduled sequencer stop was cancelled")
+)
+
+// Kinds of scheduled sequencer handoffs, as reported in metrics.
+const (
+	HandoffStop  = "stop"
+	HandoffStart = "start"
 )
 
 type L1OriginSelectorIface interface {
@@ -38,6 +47,8 @@ type Metrics interface {
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
 	RecordSequencingError()
+	SetSequencerHandoffScheduled(kind string, scheduled bool)
+	RecordSequencerHandoff(kind string)
 }
 
 type SequencerStateListener interface {
@@ -120,10 +131,26 @@ type Sequencer struct {
 
 	latestHeadSet chan struct{}
 
+	// stopTarget is the target of the scheduled stop, if any.
+	// The sequencer stops once the unsafe head reaches it, and then closes stopDone.
+	stopTarget *eth.SequencerStopTarget
+	stopDone   chan struct{}
+	// lastStop is the result of the last scheduled stop,
+	// so the caller can retrieve the head to hand off, even if it stopped waiting for it.
+	lastStop scheduledStop
+
+	// startAt is the hash of the unsafe head to start sequencing on, if a start is scheduled.
+	startAt common.Hash
+
 	// toBlockRef converts a payload to a block-ref, and is only configurable for test-purposes
 	toBlockRef func(rollupCfg *rollup.Config, payload *eth.ExecutionPayload) (eth.L2BlockRef, error)
 }
 
+type scheduledStop struct {
+	target eth.SequencerStopTarget
+	head   eth.L2BlockRef
+}
+
 var _ SequencerIface = (*Sequencer)(nil)
 
 func NewSequencer(driverCtx context.Context, log log.Logger, rollupCfg *rollup.Config,
@@ -435,6 +462,14 @@ func (d *Sequencer) onForkchoiceUpdate(x engine.ForkchoiceUpdateEvent) {
 
 	if !d.active.Load() {
 		d.setLatestHead(x.UnsafeL2Head)
+		if d.startAt != (common.Hash{}) && x.UnsafeL2Head.Hash == d.startAt {
+			d.onScheduledStart()
+		}
+		return
+	}
+	if d.stopTarget != nil && d.stopTarget.Reached(x.UnsafeL2Head) {
+		d.setLatestHead(x.UnsafeL2Head)
+		d.onScheduledStop()
 		return
 	}
 	// If the safe head has fallen behind by a significant number of blocks, delay creating new blocks
@@ -674,6 +709,10 @@ func (d *Sequencer) forceStart() error {
 	if err := d.listener.SequencerStarted(); err != nil {
 		return fmt.Errorf("failed to notify sequencer-state listener of start: %w", err)
 	}
+	if d.startAt != (common.Hash{}) {
+		d.startAt = common.Hash{}
+		d.metrics.SetSequencerHandoffScheduled(HandoffStart, false)
+	}
 	// clear the building state; interrupting any existing sequencing job (there should never be one)
 	d.latest = BuildingState{}
 	d.nextActionOK = true
@@ -736,10 +775,150 @@ func (d *Sequencer) Stop(ctx context.Context) (common.Hash, error) {
 	d.nextActionOK = false
 	d.active.Store(false)
 	d.metrics.SetSequencerState(false)
+	d.clearScheduledStop()
 	d.log.Info("Sequencer has been stopped")
 	return d.latestHead.Hash, nil
 }
 
+// StopAt schedules the sequencer to stop right after the unsafe head reaches the given target,
+// and then waits for the stop, returning the last sequenced block hash, like Stop.
+// If ctx is done before the stop happens, the stop remains scheduled,
+// and StopAt can be called again with the same target to resume waiting.
+func (d *Sequencer) StopAt(ctx context.Context, target eth.SequencerStopTarget) (common.Hash, error) {
+	if err := target.Check(); err != nil {
+		return common.Hash{}, err
+	}
+	if err := d.l.LockCtx(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	if d.stopTarget == nil {
+		if d.lastStop.target == target && d.lastStop.head != (eth.L2BlockRef{}) && !d.active.Load() {
+			d.l.Unlock()
+			return d.lastStop.head.Hash, nil
+		}
+		if !d.active.Load() {
+			d.l.Unlock()
+			return common.Hash{}, ErrSequencerAlreadyStopped
+		}
+		if target.Reached(d.latestHead) {
+			d.l.Unlock()
+			return common.Hash{}, fmt.Errorf("%w: head %s, target %s", ErrStopTargetReached, d.latestHead, target)
+		}
+		d.stopTarget = &target
+		d.stopDone = make(chan struct{})
+		d.metrics.SetSequencerHandoffScheduled(HandoffStop, true)
+		d.log.Info("Scheduled sequencer stop", "target", target, "head", d.latestHead)
+	} else if *d.stopTarget != target {
+		d.l.Unlock()
+		return common.Hash{}, fmt.Errorf("%w: %s", ErrStopAlreadyScheduled, d.stopTarget)
+	}
+	done := d.stopDone
+	d.l.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return common.Hash{}, ctx.Err()
+	case <-done:
+	}
+
+	if err := d.l.LockCtx(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	defer d.l.Unlock()
+	if d.lastStop.target != target || d.lastStop.head == (eth.L2BlockRef{}) {
+		return common.Hash{}, ErrScheduledStopCancelled
+	}
+	return d.lastStop.head.Hash, nil
+}
+
+// onScheduledStop stops the sequencer, now that the unsafe head reached the scheduled stop target.
+func (d *Sequencer) onScheduledStop() {
+	// The stop happens regardless of the listener:
+	// sequencing past the target would conflict with the sequencer that takes over.
+	if err := d.listener.SequencerStopped(); err != nil {
+		d.log.Error("Failed to notify sequencer-state listener of scheduled stop", "err", err)
+	}
+	d.latest = BuildingState{}
+	d.nextActionOK = false
+	d.active.Store(false)
+	d.metrics.SetSequencerState(false)
+	d.lastStop = scheduledStop{target: *d.stopTarget, head: d.latestHead}
+	d.clearScheduledStop()
+	d.metrics.RecordSequencerHandoff(HandoffStop)
+	d.log.Info("Sequencer has been stopped at scheduled target", "head", d.latestHead, "target", d.lastStop.target)
+}
+
+// clearScheduledStop removes the scheduled stop, if any, and releases the StopAt callers that wait for it.
+func (d *Sequencer) clearScheduledStop() {
+	if d.stopTarget == nil {
+		return
+	}
+	d.stopTarget = nil
+	close(d.stopDone)
+	d.stopDone = nil
+	d.metrics.SetSequencerHandoffScheduled(HandoffStop, false)
+}
+
+// StartAt starts the sequencer on top of the given unsafe head, like Start,
+// but waits for the unsafe head to reach the given block if it is not the head yet,
+// e.g. when the block was just handed off by another sequencer and is still being gossiped.
+// StartAt returns once the start is scheduled; Active reports when the sequencer started.
+func (d *Sequencer) StartAt(ctx context.Context, head common.Hash) error {
+	if head == (common.Hash{}) {
+		return errors.New("no head to start sequencing on")
+	}
+	// must be leading to activate, the leader check is not repeated when the scheduled start happens
+	if isLeader, err := d.conductor.Leader(ctx); err != nil {
+		return fmt.Errorf("sequencer leader check failed: %w", err)
+	} else if !isLeader {
+		return errors.New("sequencer is not the leader, aborting")
+	}
+
+	if err := d.l.LockCtx(ctx); err != nil {
+		return err
+	}
+	defer d.l.Unlock()
+
+	if d.active.Load() {
+		return ErrSequencerAlreadyStarted
+	}
+	if d.latestHead.Hash == head {
+		return d.forceStart()
+	}
+	d.startAt = head
+	d.metrics.SetSequencerHandoffScheduled(HandoffStart, true)
+	d.log.Info("Scheduled sequencer start", "start_at", head, "head", d.latestHead)
+	return nil
+}
+
+// onScheduledStart starts the sequencer, now that the unsafe head reached the scheduled start block.
+func (d *Sequencer) onScheduledStart() {
+	if err := d.forceStart(); err != nil {
+		d.log.Error("Failed to start sequencer at scheduled head", "head", d.latestHead, "err", err)
+		return
+	}
+	d.metrics.RecordSequencerHandoff(HandoffStart)
+}
+
+// CancelHandoff removes any scheduled stop or start of the sequencer.
+func (d *Sequencer) CancelHandoff(ctx context.Context) error {
+	if err := d.l.LockCtx(ctx); err != nil {
+		return err
+	}
+	defer d.l.Unlock()
+
+	if d.stopTarget != nil {
+		d.log.Info("Cancelled scheduled sequencer stop", "target", d.stopTarget)
+		d.clearScheduledStop()
+	}
+	if d.startAt != (common.Hash{}) {
+		d.log.Info("Cancelled scheduled sequencer start", "start_at", d.startAt)
+		d.startAt = common.Hash{}
+		d.metrics.SetSequencerHandoffScheduled(HandoffStart, false)
+	}
+	return nil
+}
+
 func (d *Sequencer) SetMaxSafeLag(ctx context.Context, v uint64) error {
 	d.maxSafeLag.Store(v)
 	return nil
@@ -246,6 +246,94 @@ func TestSequencer_StartStop(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestSequencer_ScheduledHandoff schedules a stop of the sequencer at a target block,
+// and a start of the sequencer once the unsafe head reaches the block it was handed off at.
+func TestSequencer_ScheduledHandoff(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	seq, deps := createSequencer(logger)
+	emitter := &testutils.MockEmitter{}
+	seq.AttachEmitter(emitter)
+	deps.conductor.leader = true
+
+	emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
+	require.NoError(t, seq.Init(context.Background(), false))
+	emitter.AssertExpectations(t)
+
+	_, err := seq.StopAt(context.Background(), eth.SequencerStopTarget{Number: 12})
+	require.ErrorIs(t, err, ErrSequencerAlreadyStopped)
+
+	head := eth.L2BlockRef{Hash: common.Hash{0xaa}, Number: 10, Time: 1000}
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: head})
+	require.NoError(t, seq.Start(context.Background(), head.Hash))
+
+	_, err = seq.StopAt(context.Background(), eth.SequencerStopTarget{})
+	require.ErrorContains(t, err, "exactly one")
+	_, err = seq.StopAt(context.Background(), eth.SequencerStopTarget{Number: 10})
+	require.ErrorIs(t, err, ErrStopTargetReached)
+
+	// The stop remains scheduled when the caller stops waiting for it.
+	target := eth.SequencerStopTarget{Number: 12}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	_, err = seq.StopAt(ctx, target)
+	cancel()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = seq.StopAt(context.Background(), eth.SequencerStopTarget{Number: 13})
+	require.ErrorIs(t, err, ErrStopAlreadyScheduled)
+
+	result := make(chan common.Hash, 1)
+	go func() {
+		h, err := seq.StopAt(context.Background(), target)
+		require.NoError(t, err)
+		result <- h
+	}()
+
+	head = eth.L2BlockRef{Hash: common.Hash{0xbb}, Number: 11, ParentHash: head.Hash, Time: 1002}
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: head})
+	require.True(t, seq.Active(), "target not reached yet")
+
+	head = eth.L2BlockRef{Hash: common.Hash{0xcc}, Number: 12, ParentHash: head.Hash, Time: 1004}
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: head})
+	require.False(t, seq.Active(), "stopped right after the target block")
+	require.False(t, deps.seqState.active, "sequencer signaled it is no longer active")
+	_, ok := seq.NextAction()
+	require.False(t, ok, "no more blocks are built after the target")
+
+	select {
+	case h := <-result:
+		require.Equal(t, head.Hash, h)
+	case <-time.After(time.Second * 10):
+		t.Fatal("scheduled stop did not complete")
+	}
+	// The stop result can be retrieved again, e.g. after a timed-out call.
+	h, err := seq.StopAt(context.Background(), target)
+	require.NoError(t, err)
+	require.Equal(t, head.Hash, h)
+
+	// Schedule the start at a block that has not been received yet.
+	handoff := eth.L2BlockRef{Hash: common.Hash{0xdd}, Number: 13, ParentHash: head.Hash, Time: 1006}
+	require.NoError(t, seq.StartAt(context.Background(), handoff.Hash))
+	require.False(t, seq.Active())
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: handoff})
+	require.True(t, seq.Active(), "started once the unsafe head reached the handoff block")
+	require.True(t, deps.seqState.active, "sequencer signaled it is active")
+	require.ErrorIs(t, seq.StartAt(context.Background(), handoff.Hash), ErrSequencerAlreadyStarted)
+
+	// Waiting callers are released when the scheduled stop is cancelled.
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond*10)
+	_, err = seq.StopAt(ctx, eth.SequencerStopTarget{Timestamp: 2000})
+	cancel()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	done := seq.stopDone
+	require.NotNil(t, done)
+	require.NoError(t, seq.CancelHandoff(context.Background()))
+	select {
+	case <-done:
+	default:
+		t.Fatal("cancelled stop did not release the waiting callers")
+	}
+	require.True(t, seq.Active(), "cancelled stop does not stop the sequencer")
+}
+
 // TestSequencer_StaleBuild stops the sequencer after block-building,
 // but before processing the block locally,
 // and then continues it again, to check if the async-gossip gets cleared,
@@ -0,0 +1,136 @@
+package status
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Snapshot is a record of the snapshot log: the L1 and L2 heads of the sync status,
+// after an event changed any of them.
+type Snapshot struct {
+	Time  time.Time `json:"t"`
+	Event string    `json:"event"`
+
+	L1Head      eth.L1BlockRef `json:"l1Head"`
+	L1Current   eth.L1BlockRef `json:"l1Current"`
+	L2Unsafe    eth.L2BlockRef `json:"l2Unsafe"`
+	L2Safe      eth.L2BlockRef `json:"l2Safe"`
+	L2Finalized eth.L2BlockRef `json:"l2Finalized"`
+}
+
+func snapshotOf(t time.Time, ev string, status *eth.SyncStatus) Snapshot {
+	return Snapshot{
+		Time:        t,
+		Event:       ev,
+		L1Head:      status.HeadL1,
+		L1Current:   status.CurrentL1,
+		L2Unsafe:    status.UnsafeL2,
+		L2Safe:      status.SafeL2,
+		L2Finalized: status.FinalizedL2,
+	}
+}
+
+// sameHeads returns whether both snapshots have the same heads, ignoring when and why they were taken.
+func (s *Snapshot) sameHeads(o *Snapshot) bool {
+	return s.L1Head == o.L1Head && s.L1Current == o.L1Current &&
+		s.L2Unsafe == o.L2Unsafe && s.L2Safe == o.L2Safe && s.L2Finalized == o.L2Finalized
+}
+
+// SnapshotLog is a Tracer that writes a Snapshot to a writer, one JSON object per line,
+// every time the status tracker processes an event that changes the tracked heads.
+// Each snapshot is flushed to the writer as a single write.
+// The log can be analyzed after the fact with the op-node snapshot-analyze command.
+type SnapshotLog struct {
+	l sync.Mutex
+
+	status func() *eth.SyncStatus
+
+	w    *bufio.Writer
+	enc  *json.Encoder
+	last Snapshot
+	err  error
+
+	closed bool
+}
+
+var _ event.Tracer = (*SnapshotLog)(nil)
+
+// NewSnapshotLog creates a SnapshotLog, that reads the sync status of the status tracker with the given function.
+func NewSnapshotLog(w io.Writer, status func() *eth.SyncStatus) *SnapshotLog {
+	bw := bufio.NewWriter(w)
+	return &SnapshotLog{status: status, w: bw, enc: json.NewEncoder(bw)}
+}
+
+func (sl *SnapshotLog) OnDeriveStart(name string, ev event.AnnotatedEvent, derivContext uint64, startTime time.Time) {
+}
+
+func (sl *SnapshotLog) OnDeriveEnd(name string, ev event.AnnotatedEvent, derivContext uint64, startTime time.Time, duration time.Duration, effect bool) {
+	if name != TrackerName || !effect {
+		return
+	}
+	snap := snapshotOf(startTime.Add(duration), ev.Event.String(), sl.status())
+	sl.l.Lock()
+	defer sl.l.Unlock()
+	if sl.err != nil || sl.closed || snap.sameHeads(&sl.last) {
+		return
+	}
+	sl.last = snap
+	if err := sl.enc.Encode(&snap); err != nil {
+		sl.err = err
+		return
+	}
+	// Flush every snapshot, so the log is complete up to the last head change if the node crashes.
+	sl.err = sl.w.Flush()
+}
+
+func (sl *SnapshotLog) OnRateLimited(name string, derivContext uint64) {
+}
+
+func (sl *SnapshotLog) OnEmit(name string, ev event.AnnotatedEvent, derivContext uint64, emitTime time.Time) {
+}
+
+// Err returns the first error encountered while writing the snapshot log.
+// Snapshots after an error are not written.
+func (sl *SnapshotLog) Err() error {
+	sl.l.Lock()
+	defer sl.l.Unlock()
+	return sl.err
+}
+
+// Close stops the snapshot log. Snapshots after Close are not written.
+// It returns the first error encountered while writing the snapshot log.
+func (sl *SnapshotLog) Close() error {
+	sl.l.Lock()
+	defer sl.l.Unlock()
+	if sl.closed {
+		return sl.err
+	}
+	sl.closed = true
+	if sl.err != nil {
+		return sl.err
+	}
+	sl.err = sl.w.Flush()
+	return sl.err
+}
+
+// ReadSnapshots reads all snapshots of a snapshot log, as written by SnapshotLog.
+func ReadSnapshots(r io.Reader) ([]Snapshot, error) {
+	dec := json.NewDecoder(r)
+	var out []Snapshot
+	for {
+		var snap Snapshot
+		if err := dec.Decode(&snap); err == io.EOF {
+			return out, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot %d: %w", len(out), err)
+		}
+		out = append(out, snap)
+	}
+}
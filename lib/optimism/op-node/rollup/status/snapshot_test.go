@@ -0,0 +1,55 @@
+package status
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestSnapshotLog(t *testing.T) {
+	st := NewStatusTracker(testlog.Logger(t, log.LevelError), noopMetrics{})
+	var buf bytes.Buffer
+	sl := NewSnapshotLog(&buf, st.SyncStatus)
+
+	start := time.Unix(1000, 0)
+	process := func(name string, ev event.Event) {
+		effect := false
+		if name == TrackerName {
+			effect = st.OnEvent(ev)
+		}
+		sl.OnDeriveEnd(name, event.AnnotatedEvent{Event: ev}, 0, start, time.Second, effect)
+		start = start.Add(time.Second)
+	}
+	a := eth.L2BlockRef{Hash: common.Hash{0xa}, Number: 10}
+	b := eth.L2BlockRef{Hash: common.Hash{0xb}, Number: 11, ParentHash: a.Hash}
+	process(TrackerName, engine.ForkchoiceUpdateEvent{UnsafeL2Head: a, SafeL2Head: a, FinalizedL2Head: a})
+	process(TrackerName, engine.ForkchoiceUpdateEvent{UnsafeL2Head: a, SafeL2Head: a, FinalizedL2Head: a})
+	process("engine-controller", engine.ForkchoiceUpdateEvent{UnsafeL2Head: b, SafeL2Head: a, FinalizedL2Head: a})
+	process(TrackerName, engine.ForkchoiceUpdateEvent{UnsafeL2Head: b, SafeL2Head: a, FinalizedL2Head: a})
+	require.NoError(t, sl.Err())
+	written, err := ReadSnapshots(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Len(t, written, 2, "snapshots are flushed as they are logged")
+	require.NoError(t, sl.Close())
+	// snapshots after close are not written
+	process(TrackerName, engine.ForkchoiceUpdateEvent{UnsafeL2Head: a, SafeL2Head: a, FinalizedL2Head: a})
+
+	snapshots, err := ReadSnapshots(&buf)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2, "unchanged heads and other derivers are not logged")
+	require.Equal(t, a, snapshots[0].L2Unsafe)
+	require.True(t, time.Unix(1001, 0).Equal(snapshots[0].Time))
+	require.Equal(t, "forkchoice-update", snapshots[0].Event)
+	require.Equal(t, b, snapshots[1].L2Unsafe)
+	require.Equal(t, a, snapshots[1].L2Safe)
+	require.Equal(t, a, snapshots[1].L2Finalized)
+}
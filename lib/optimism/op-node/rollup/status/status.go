@@ -1,6 +1,8 @@
 package status
 
 import (
+	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 
@@ -14,6 +16,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
+// TrackerName is the name of the status tracker in the event system.
+const TrackerName = "status"
+
 type L1UnsafeEvent struct {
 	L1Unsafe eth.L1BlockRef
 }
@@ -30,6 +35,71 @@ func (ev L1SafeEvent) String() string {
 	return "l1-safe"
 }
 
+// HeadTopic identifies a kind of L2 head change that can be subscribed to.
+type HeadTopic string
+
+const (
+	// UnsafeHeadTopic signals changes of the unsafe head.
+	UnsafeHeadTopic HeadTopic = "unsafe"
+	// SafeHeadTopic signals changes of the local-safe head, derived from L1 but not yet cross-verified.
+	SafeHeadTopic HeadTopic = "safe"
+	// CrossSafeHeadTopic signals changes of the cross-safe head, the safe head of the sync status.
+	CrossSafeHeadTopic HeadTopic = "crossSafe"
+	// FinalizedHeadTopic signals changes of the finalized head.
+	FinalizedHeadTopic HeadTopic = "finalized"
+	// PipelineResetTopic signals derivation pipeline resets, with the local-safe head that derivation restarts from.
+	PipelineResetTopic HeadTopic = "reset"
+	// L1OriginTopic signals unsafe heads with a different L1 origin than the previous unsafe head.
+	L1OriginTopic HeadTopic = "l1Origin"
+)
+
+var HeadTopics = []HeadTopic{UnsafeHeadTopic, SafeHeadTopic, CrossSafeHeadTopic, FinalizedHeadTopic, PipelineResetTopic, L1OriginTopic}
+
+var (
+	ErrUnknownHeadTopic = errors.New("unknown head topic")
+	// ErrHeadSubscriberLagging is the error that a head subscription is dropped with, if it lags too far behind.
+	ErrHeadSubscriberLagging = errors.New("head subscriber is lagging behind")
+)
+
+// headSubscriptionQueue is the number of head changes that are queued for a head subscription.
+// Subscriptions that lag further behind are dropped.
+const headSubscriptionQueue = 100
+
+// HeadSubscription is a subscription to the changes of a head topic.
+// The changes are queued in a bounded queue, that the subscriber must keep up with.
+// If the queue is full, the subscription is dropped, and Err receives ErrHeadSubscriberLagging,
+// so a lagging subscriber never blocks the status tracker.
+type HeadSubscription struct {
+	st    *StatusTracker
+	topic HeadTopic
+
+	heads chan eth.L2BlockRef
+	err   chan error
+
+	once sync.Once
+}
+
+// Values returns the queue of head changes.
+func (s *HeadSubscription) Values() <-chan eth.L2BlockRef {
+	return s.heads
+}
+
+// Err returns a channel that receives ErrHeadSubscriberLagging if the subscription is dropped for lagging behind.
+// The channel is closed when the subscription ends.
+func (s *HeadSubscription) Err() <-chan error {
+	return s.err
+}
+
+// Unsubscribe ends the subscription.
+func (s *HeadSubscription) Unsubscribe() {
+	s.st.unsubscribe(s, nil)
+}
+
+type headChange struct {
+	topic HeadTopic
+	ref   eth.L2BlockRef
+}
+
 type Metrics interface {
 	RecordL1ReorgDepth(d uint64)
 	RecordL1Ref(name string, ref eth.L1BlockRef)
@@ -45,6 +115,9 @@ type StatusTracker struct {
 	metrics Metrics
 
 	mu sync.RWMutex
+
+	subsMu sync.Mutex
+	subs   map[HeadTopic]map[*HeadSubscription]struct{}
 }
 
 func NewStatusTracker(log log.Logger, metrics Metrics) *StatusTracker {
@@ -54,13 +127,47 @@ func NewStatusTracker(log log.Logger, metrics Metrics) *StatusTracker {
 	}
 	st.data = eth.SyncStatus{}
 	st.published.Store(&eth.SyncStatus{})
+	st.subs = make(map[HeadTopic]map[*HeadSubscription]struct{})
+	for _, topic := range HeadTopics {
+		st.subs[topic] = make(map[*HeadSubscription]struct{})
+	}
 	return st
 }
 
 func (st *StatusTracker) OnEvent(ev event.Event) bool {
+	changes, ok := st.update(ev)
+	// Notify subscribers outside of the lock, so the sync status can be read by subscribers.
+	for _, c := range changes {
+		st.notify(c)
+	}
+	return ok
+}
+
+// notify queues the head change for all subscribers of its topic, without blocking.
+// Subscribers with a full queue are dropped.
+func (st *StatusTracker) notify(c headChange) {
+	st.subsMu.Lock()
+	var lagging []*HeadSubscription
+	for sub := range st.subs[c.topic] {
+		select {
+		case sub.heads <- c.ref:
+		default:
+			lagging = append(lagging, sub)
+		}
+	}
+	st.subsMu.Unlock()
+	for _, sub := range lagging {
+		st.log.Warn("Dropping lagging head subscriber", "topic", c.topic, "queue", headSubscriptionQueue)
+		st.unsubscribe(sub, ErrHeadSubscriberLagging)
+	}
+}
+
+func (st *StatusTracker) update(ev event.Event) ([]headChange, bool) {
 	st.mu.Lock()
 	defer st.mu.Unlock()
 
+	prev := st.data
+	var changes []headChange
 	switch x := ev.(type) {
 	case engine.ForkchoiceUpdateEvent:
 		st.log.Debug("Forkchoice update", "unsafe", x.UnsafeL2Head, "safe", x.SafeL2Head, "finalized", x.FinalizedL2Head)
@@ -123,10 +230,13 @@ func (st *StatusTracker) OnEvent(ev event.Event) bool {
 		st.data.LocalSafeL2 = x.LocalSafe
 		st.data.SafeL2 = x.CrossSafe
 		st.data.FinalizedL2 = x.Finalized
+		changes = append(changes, headChange{PipelineResetTopic, x.LocalSafe})
 	default: // other events do not affect the sync status
-		return false
+		return nil, false
 	}
 
+	changes = append(changes, headChanges(&prev, &st.data)...)
+
 	// If anything changes, then copy the state to the published SyncStatus
 	// @dev: If this becomes a performance bottleneck during sync (because mem copies onto heap, and 1KB comparisons),
 	// we can rate-limit updates of the published data.
@@ -135,7 +245,63 @@ func (st *StatusTracker) OnEvent(ev event.Event) bool {
 		published = st.data
 		st.published.Store(&published)
 	}
-	return true
+	return changes, true
+}
+
+// headChanges returns the changes of the L2 heads between the two sync statuses.
+// Heads that are cleared, e.g. on reset, are not signaled.
+func headChanges(prev, next *eth.SyncStatus) []headChange {
+	var changes []headChange
+	changed := func(a, b eth.L2BlockRef) bool {
+		return a != b && b != (eth.L2BlockRef{})
+	}
+	if changed(prev.UnsafeL2, next.UnsafeL2) {
+		changes = append(changes, headChange{UnsafeHeadTopic, next.UnsafeL2})
+		if prev.UnsafeL2.L1Origin != next.UnsafeL2.L1Origin {
+			changes = append(changes, headChange{L1OriginTopic, next.UnsafeL2})
+		}
+	}
+	if changed(prev.LocalSafeL2, next.LocalSafeL2) {
+		changes = append(changes, headChange{SafeHeadTopic, next.LocalSafeL2})
+	}
+	if changed(prev.SafeL2, next.SafeL2) {
+		changes = append(changes, headChange{CrossSafeHeadTopic, next.SafeL2})
+	}
+	if changed(prev.FinalizedL2, next.FinalizedL2) {
+		changes = append(changes, headChange{FinalizedHeadTopic, next.FinalizedL2})
+	}
+	return changes
+}
+
+// SubscribeHeads subscribes to the changes of the given head topic.
+func (st *StatusTracker) SubscribeHeads(topic HeadTopic) (*HeadSubscription, error) {
+	st.subsMu.Lock()
+	defer st.subsMu.Unlock()
+	subs, ok := st.subs[topic]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownHeadTopic, topic)
+	}
+	sub := &HeadSubscription{
+		st:    st,
+		topic: topic,
+		heads: make(chan eth.L2BlockRef, headSubscriptionQueue),
+		err:   make(chan error, 1),
+	}
+	subs[sub] = struct{}{}
+	return sub, nil
+}
+
+// unsubscribe removes the subscription, and ends it with the given error, if not nil.
+func (st *StatusTracker) unsubscribe(sub *HeadSubscription, err error) {
+	sub.once.Do(func() {
+		st.subsMu.Lock()
+		delete(st.subs[sub.topic], sub)
+		st.subsMu.Unlock()
+		if err != nil {
+			sub.err <- err
+		}
+		close(sub.err)
+	})
 }
 
 // SyncStatus is thread safe, and reads the latest view of L1 and L2 block labels
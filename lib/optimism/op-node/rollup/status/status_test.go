@@ -0,0 +1,118 @@
+package status
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type noopMetrics struct{}
+
+func (noopMetrics) RecordL1ReorgDepth(d uint64) {}
+
+func (noopMetrics) RecordL1Ref(name string, ref eth.L1BlockRef) {}
+
+func TestStatusTrackerHeadSubscriptions(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	st := NewStatusTracker(testlog.Logger(t, log.LevelError), noopMetrics{})
+	chans := make(map[HeadTopic]<-chan eth.L2BlockRef)
+	for _, topic := range HeadTopics {
+		sub, err := st.SubscribeHeads(topic)
+		require.NoError(t, err)
+		t.Cleanup(sub.Unsubscribe)
+		chans[topic] = sub.Values()
+	}
+	_, err := st.SubscribeHeads("foo")
+	require.ErrorIs(t, err, ErrUnknownHeadTopic)
+
+	requireNotified := func(topic HeadTopic, expected ...eth.L2BlockRef) {
+		t.Helper()
+		for _, ref := range expected {
+			select {
+			case got := <-chans[topic]:
+				require.Equal(t, ref, got, "topic %s", topic)
+			default:
+				t.Fatalf("no notification of topic %s", topic)
+			}
+		}
+		require.Empty(t, chans[topic], "unexpected notifications of topic %s", topic)
+	}
+
+	unsafe := testutils.RandomL2BlockRef(rng)
+	st.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: unsafe})
+	requireNotified(UnsafeHeadTopic, unsafe)
+	requireNotified(L1OriginTopic, unsafe)
+
+	// same L1 origin
+	next := testutils.NextRandomL2Ref(rng, 2, unsafe, unsafe.L1Origin)
+	next.L1Origin = unsafe.L1Origin
+	st.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: next})
+	requireNotified(UnsafeHeadTopic, next)
+	requireNotified(L1OriginTopic)
+
+	// unchanged heads are not signaled
+	st.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: next})
+	requireNotified(UnsafeHeadTopic)
+
+	st.OnEvent(engine.LocalSafeUpdateEvent{Ref: unsafe})
+	requireNotified(SafeHeadTopic, unsafe)
+	st.OnEvent(engine.CrossSafeUpdateEvent{CrossSafe: unsafe, LocalSafe: unsafe})
+	requireNotified(CrossSafeHeadTopic, unsafe)
+	requireNotified(SafeHeadTopic)
+	st.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: next, SafeL2Head: unsafe, FinalizedL2Head: unsafe})
+	requireNotified(FinalizedHeadTopic, unsafe)
+
+	reset := engine.EngineResetConfirmedEvent{LocalUnsafe: next, CrossUnsafe: next, LocalSafe: unsafe, CrossSafe: unsafe, Finalized: unsafe}
+	st.OnEvent(reset)
+	requireNotified(PipelineResetTopic, unsafe)
+	for _, topic := range HeadTopics {
+		requireNotified(topic)
+	}
+}
+
+func TestStatusTrackerLaggingHeadSubscriber(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	st := NewStatusTracker(testlog.Logger(t, log.LevelCrit), noopMetrics{})
+	lagging, err := st.SubscribeHeads(UnsafeHeadTopic)
+	require.NoError(t, err)
+	reading, err := st.SubscribeHeads(UnsafeHeadTopic)
+	require.NoError(t, err)
+	defer reading.Unsubscribe()
+
+	// the lagging subscriber never reads, which must not block the status tracker
+	ref := testutils.RandomL2BlockRef(rng)
+	for i := 0; i < headSubscriptionQueue+10; i++ {
+		ref = testutils.NextRandomL2Ref(rng, 2, ref, ref.L1Origin)
+		st.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: ref})
+		select {
+		case got := <-reading.Values():
+			require.Equal(t, ref, got)
+		default:
+			t.Fatal("no notification of reading subscriber")
+		}
+	}
+
+	select {
+	case err := <-lagging.Err():
+		require.ErrorIs(t, err, ErrHeadSubscriberLagging)
+	default:
+		t.Fatal("lagging subscriber was not dropped")
+	}
+	_, ok := <-lagging.Err()
+	require.False(t, ok, "err channel must be closed")
+	require.Len(t, lagging.Values(), headSubscriptionQueue)
+	require.NotPanics(t, lagging.Unsubscribe)
+
+	select {
+	case <-reading.Err():
+		t.Fatal("reading subscriber was dropped")
+	default:
+	}
+}
@@ -36,6 +36,7 @@ var (
 	ErrChainIDsSame                  = errors.New("L1 and L2 chain IDs must be different")
 	ErrL1ChainIDNotPositive          = errors.New("L1 chain ID must be non-zero and positive")
 	ErrL2ChainIDNotPositive          = errors.New("L2 chain ID must be non-zero and positive")
+	ErrZstdChannelBeforeFjord        = errors.New("zstd channel compression must not activate before Fjord")
 )
 
 type Genesis struct {
@@ -162,6 +163,12 @@ type Config struct {
 	// This feature (de)activates by L1 origin timestamp, to keep a consistent L1 block info per L2
 	// epoch.
 	PectraBlobScheduleTime *uint64 `json:"pectra_blob_schedule_time,omitempty"`
+
+	// ZstdChannelTime sets the time from which on channels compressed with zstd, marked by the
+	// ChannelVersionZstd version byte, are accepted by derivation.
+	// This feature is optional and off by default. Like brotli compression since Fjord,
+	// it activates by L1 origin timestamp.
+	ZstdChannelTime *uint64 `json:"zstd_channel_time,omitempty"`
 }
 
 // ValidateL1Config checks L1 config variables for errors.
@@ -332,6 +339,9 @@ func (cfg *Config) Check() error {
 	if err := validateAltDAConfig(cfg); err != nil {
 		return err
 	}
+	if cfg.ZstdChannelTime != nil && (cfg.FjordTime == nil || *cfg.ZstdChannelTime < *cfg.FjordTime) {
+		return ErrZstdChannelBeforeFjord
+	}
 
 	if err := checkFork(cfg.RegolithTime, cfg.CanyonTime, Regolith, Canyon); err != nil {
 		return err
@@ -475,6 +485,11 @@ func (c *Config) IsInterop(timestamp uint64) bool {
 	return c.InteropTime != nil && timestamp >= *c.InteropTime
 }
 
+// IsZstdChannel returns true if zstd channel compression is active at or past the given timestamp.
+func (c *Config) IsZstdChannel(timestamp uint64) bool {
+	return c.ZstdChannelTime != nil && timestamp >= *c.ZstdChannelTime
+}
+
 func (c *Config) IsRegolithActivationBlock(l2BlockTime uint64) bool {
 	return c.IsRegolith(l2BlockTime) &&
 		l2BlockTime >= c.BlockTime &&
@@ -773,6 +788,10 @@ func (c *Config) forEachFork(callback func(name string, logName string, time *ui
 	callback("Isthmus", "isthmus_time", c.IsthmusTime)
 	callback("Jovian", "jovian_time", c.JovianTime)
 	callback("Interop", "interop_time", c.InteropTime)
+	if c.ZstdChannelTime != nil {
+		// only report if config is set
+		callback("Zstd Channels", "zstd_channel_time", c.ZstdChannelTime)
+	}
 }
 
 func (c *Config) ParseRollupConfig(in io.Reader) error {
@@ -531,6 +531,20 @@ func TestConfig_Check(t *testing.T) {
 			modifier:    func(cfg *Config) { cfg.L2ChainID = big.NewInt(0) },
 			expectedErr: ErrL2ChainIDNotPositive,
 		},
+		{
+			name:        "ZstdChannelWithoutFjord",
+			modifier:    func(cfg *Config) { cfg.ZstdChannelTime = new(uint64) },
+			expectedErr: ErrZstdChannelBeforeFjord,
+		},
+		{
+			name: "ZstdChannelBeforeFjord",
+			modifier: func(cfg *Config) {
+				fjordTime, zstdTime := uint64(10), uint64(5)
+				cfg.FjordTime = &fjordTime
+				cfg.ZstdChannelTime = &zstdTime
+			},
+			expectedErr: ErrZstdChannelBeforeFjord,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
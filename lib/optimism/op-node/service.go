@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"strings"
 
@@ -16,12 +17,15 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/node"
+	"github.com/ethereum-optimism/optimism/op-node/node/outputcheck"
 	p2pcli "github.com/ethereum-optimism/optimism/op-node/p2p/cli"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/interop"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
@@ -70,6 +74,11 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		return nil, fmt.Errorf("failed to create the sync config: %w", err)
 	}
 
+	outputCheckConfig, err := NewOutputCheckConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load output check config: %w", err)
+	}
+
 	haltOption := ctx.String(flags.RollupHalt.Name)
 	if haltOption == "none" {
 		haltOption = ""
@@ -103,8 +112,17 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		P2PSigner:                   p2pSignerSetup,
 		L1EpochPollInterval:         ctx.Duration(flags.L1EpochPollIntervalFlag.Name),
 		RuntimeConfigReloadInterval: ctx.Duration(flags.RuntimeConfigReloadIntervalFlag.Name),
+		P2PSignersFile:              ctx.String(flags.P2PSignersFile.Name),
+		NetworksDir:                 ctx.String(opflags.NetworksDirFlagName),
+		OutputCheck:                 outputCheckConfig,
 		ConfigPersistence:           configPersistence,
 		SafeDBPath:                  ctx.String(flags.SafeDBPath.Name),
+		SafeDBRetention:             ctx.Uint64(flags.SafeDBRetention.Name),
+		EventRecordPath:             ctx.String(flags.EventRecordPath.Name),
+		EventTraceDir:               ctx.String(flags.EventTraceDir.Name),
+		SnapshotLogPath:             ctx.String(flags.SnapshotLogPath.Name),
+		UnsafePayloadsPath:          ctx.String(flags.UnsafePayloadsPath.Name),
+		UnsafePayloadsMaxSize:       ctx.Uint64(flags.UnsafePayloadsMaxSize.Name),
 		Sync:                        *syncConfig,
 		RollupHalt:                  haltOption,
 
@@ -134,6 +152,32 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 	return cfg, nil
 }
 
+func NewOutputCheckConfig(ctx *cli.Context) (outputcheck.Config, error) {
+	cfg := outputcheck.Config{
+		PollInterval: ctx.Duration(flags.OutputCheckPollInterval.Name),
+		Backfill:     ctx.Uint64(flags.OutputCheckBackfill.Name),
+	}
+	if addr := ctx.String(flags.OutputCheckDisputeGameFactory.Name); addr != "" {
+		if !common.IsHexAddress(addr) {
+			return outputcheck.Config{}, fmt.Errorf("invalid DisputeGameFactory address: %q", addr)
+		}
+		cfg.DisputeGameFactory = common.HexToAddress(addr)
+	}
+	if addr := ctx.String(flags.OutputCheckL2OutputOracle.Name); addr != "" {
+		if !common.IsHexAddress(addr) {
+			return outputcheck.Config{}, fmt.Errorf("invalid L2OutputOracle address: %q", addr)
+		}
+		cfg.L2OutputOracle = common.HexToAddress(addr)
+	}
+	for _, gameType := range ctx.UintSlice(flags.OutputCheckGameTypes.Name) {
+		if gameType > math.MaxUint32 {
+			return outputcheck.Config{}, fmt.Errorf("invalid dispute game type: %d", gameType)
+		}
+		cfg.GameTypes = append(cfg.GameTypes, uint32(gameType))
+	}
+	return cfg, nil
+}
+
 func NewSupervisorEndpointConfig(ctx *cli.Context) *interop.Config {
 	return &interop.Config{
 		SupervisorAddr:   ctx.String(flags.InteropSupervisor.Name),
@@ -156,6 +200,9 @@ func NewBeaconEndpointConfig(ctx *cli.Context) node.L1BeaconEndpointSetup {
 func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
 	return &node.L1EndpointConfig{
 		L1NodeAddr:       ctx.String(flags.L1NodeAddr.Name),
+		L1ExtraAddrs:     ctx.StringSlice(flags.L1ExtraAddrs.Name),
+		L1MultiMode:      client.MultiMode(strings.ToLower(ctx.String(flags.L1MultiMode.Name))),
+		L1Quorum:         ctx.Int(flags.L1Quorum.Name),
 		L1TrustRPC:       ctx.Bool(flags.L1TrustRPC.Name),
 		L1RPCKind:        sources.RPCProviderKind(strings.ToLower(ctx.String(flags.L1RPCProviderKind.Name))),
 		RateLimit:        ctx.Float64(flags.L1RPCRateLimit.Name),
@@ -196,7 +243,12 @@ func NewDriverConfig(ctx *cli.Context) *driver.Config {
 		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
 		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
 		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
-		RecoverMode:         ctx.Bool(flags.SequencerRecoverMode.Name),
+		SequencerOriginPolicy: sequencing.OriginPolicyConfig{
+			Kind:           sequencing.OriginPolicyKind(strings.ToLower(ctx.String(flags.SequencerOriginPolicy.Name))),
+			MaxExtraConfs:  ctx.Uint64(flags.SequencerOriginMaxExtraConfs.Name),
+			MinDriftMargin: ctx.Uint64(flags.SequencerOriginMinDriftMargin.Name),
+		},
+		RecoverMode: ctx.Bool(flags.SequencerRecoverMode.Name),
 	}
 }
 
@@ -206,6 +258,13 @@ func NewRollupConfigFromCLI(log log.Logger, ctx *cli.Context) (*rollup.Config, e
 	if ctx.Bool(flags.BetaExtraNetworks.Name) {
 		log.Warn("The beta.extra-networks flag is deprecated and can be omitted safely.")
 	}
+	if networksDir := ctx.String(opflags.NetworksDirFlagName); networksDir != "" {
+		chains, err := chaincfg.LoadCustomChains(networksDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load custom networks: %w", err)
+		}
+		chaincfg.SetCustomChains(chains)
+	}
 	rollupConfig, err := NewRollupConfig(log, network, rollupConfigPath)
 	if err != nil {
 		return nil, err
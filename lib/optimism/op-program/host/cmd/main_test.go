@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -228,6 +229,20 @@ func TestL2ChainID(t *testing.T) {
 		require.Equal(t, boot.CustomChainIDIndicator, cfg.L2ChainID)
 	})
 
+	t.Run("CustomNetworkImpliesCustomIndicator", func(t *testing.T) {
+		networksDir := writeCustomNetwork(t, "op-custom", 424242)
+		cfg := configForArgs(t, addRequiredArgsExcept("--network",
+			"--network", "op-custom",
+			"--networks.dir", networksDir))
+		require.Equal(t, boot.CustomChainIDIndicator, cfg.L2ChainID)
+
+		// registry networks are still identified by their chain ID
+		cfg = configForArgs(t, addRequiredArgsExcept("--network",
+			"--network", "op-mainnet",
+			"--networks.dir", networksDir))
+		require.Equal(t, eth.ChainIDFromUInt64(10), cfg.L2ChainID)
+	})
+
 	t.Run("ZeroWhenMultipleL2ChainsSpecified", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgsExcept("--network", "--network", "op-sepolia,op-mainnet"))
 		require.Zero(t, cfg.L2ChainID)
@@ -595,6 +610,17 @@ func writeRollupConfig(t *testing.T, rollupCfg *rollup.Config) string {
 	return cfgFile
 }
 
+// writeCustomNetwork writes a networks directory with a custom network of the given name and chain ID.
+func writeCustomNetwork(t *testing.T, name string, chainID uint64) string {
+	dir := t.TempDir()
+	rollupFile, _ := writeRollupConfigWithChainID(t, chainID)
+	genesisFile, _ := writeGenesisFileWithChainID(t, chainID)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, name), 0o755))
+	require.NoError(t, os.Rename(rollupFile, filepath.Join(dir, name, chaincfg.CustomRollupFileName)))
+	require.NoError(t, os.Rename(genesisFile, filepath.Join(dir, name, chaincfg.CustomGenesisFileName)))
+	return dir
+}
+
 func toArgList(req map[string]string) []string {
 	var combined []string
 	for name, value := range req {
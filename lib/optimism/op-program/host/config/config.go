@@ -276,8 +276,29 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	var rollupCfgs []*rollup.Config
 	var l2ChainConfigs []*params.ChainConfig
 	var l2ChainID eth.ChainID
+	var customChains *chaincfg.CustomChains
+	// custom networks are not known to the client program, so their config must be loaded via the preimage oracle
+	customNetwork := false
+	if networksDir := ctx.String(flags.NetworksDir.Name); networksDir != "" {
+		customChains, err = chaincfg.LoadCustomChains(networksDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load custom networks: %w", err)
+		}
+	}
 	networkNames := ctx.StringSlice(flags.Network.Name)
 	for _, networkName := range networkNames {
+		if customChains != nil {
+			if chain := customChains.ByName(networkName); chain != nil {
+				if chain.ChainConfig == nil {
+					return nil, fmt.Errorf("custom network %q has no genesis or chain config", networkName)
+				}
+				l2ChainConfigs = append(l2ChainConfigs, chain.ChainConfig)
+				rollupCfgs = append(rollupCfgs, chain.Rollup)
+				l2ChainID = chain.ChainID()
+				customNetwork = true
+				continue
+			}
+		}
 		var chainID eth.ChainID
 		if chainID, err = eth.ParseDecimalChainID(networkName); err != nil {
 			ch := chaincfg.ChainByName(networkName)
@@ -319,7 +340,7 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 		rollupCfgs = append(rollupCfgs, rollupCfg)
 
 	}
-	if ctx.Bool(flags.L2Custom.Name) {
+	if ctx.Bool(flags.L2Custom.Name) || customNetwork {
 		log.Warn("Using custom chain configuration via preimage oracle. This is not compatible with on-chain execution.")
 		l2ChainID = boot.CustomChainIDIndicator
 	} else if len(rollupCfgs) > 1 {
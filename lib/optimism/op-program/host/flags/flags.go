@@ -24,6 +24,7 @@ var (
 	L2Custom = &cli.BoolFlag{
 		Name: "l2.custom",
 		Usage: "Override the L2 chain ID to the custom chain indicator for custom chain configuration not present in the client program. " +
+			"Implied for custom networks of the networks directory. " +
 			"WARNING: This is not compatible with on-chain execution and must only be used for testing.",
 		EnvVars: prefixEnvVars("L2_CHAINID"),
 		Value:   false,
@@ -39,6 +40,13 @@ var (
 		Usage:   fmt.Sprintf("Predefined network selection. Available networks: %s", strings.Join(chaincfg.AvailableNetworks(), ", ")),
 		EnvVars: prefixEnvVars("NETWORK"),
 	}
+	NetworksDir = &cli.StringFlag{
+		Name: "networks.dir",
+		Usage: "Directory of custom networks, with a subdirectory per network, named after the network or its chain ID. " +
+			"Each network directory has a rollup.json, and a genesis.json or chain-config.json. " +
+			"Custom networks are not embedded in the client program, and require --l2.custom.",
+		EnvVars: prefixEnvVars("NETWORKS_DIR"),
+	}
 	DataDir = &cli.StringFlag{
 		Name:    "datadir",
 		Usage:   "Directory to use for preimage data storage. Default uses in-memory storage",
@@ -156,6 +164,7 @@ var programFlags = []cli.Flag{
 	L2Custom,
 	RollupConfig,
 	Network,
+	NetworksDir,
 	DataDir,
 	DataFormat,
 	L2NodeAddr,
@@ -177,8 +186,8 @@ func init() {
 }
 
 func CheckRequired(ctx *cli.Context) error {
-	if ctx.Bool(L2Custom.Name) && ctx.IsSet(Network.Name) {
-		return fmt.Errorf("flag %s cannot be used with named networks", L2Custom.Name)
+	if ctx.Bool(L2Custom.Name) && ctx.IsSet(Network.Name) && !ctx.IsSet(NetworksDir.Name) {
+		return fmt.Errorf("flag %s cannot be used with named networks, unless they are custom networks of %s", L2Custom.Name, NetworksDir.Name)
 	}
 	for _, flag := range requiredFlags {
 		if !ctx.IsSet(flag.Names()[0]) {
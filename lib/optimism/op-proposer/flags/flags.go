@@ -7,6 +7,8 @@ import (
 	"github.com/urfave/cli/v2"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -84,6 +86,29 @@ var (
 		Value:   false,
 		EnvVars: prefixEnvVars("WAIT_NODE_SYNC"),
 	}
+	L1ExtraEthRpcsFlag = &cli.StringSliceFlag{
+		Name:    txmgr.L1ExtraRPCsFlagName,
+		Usage:   "HTTP provider URLs of additional L1 endpoints of the same chain, used for failover or quorum with l1-eth-rpc, also by the tx manager.",
+		EnvVars: prefixEnvVars("L1_ETH_EXTRA_RPCS"),
+	}
+	L1MultiModeFlag = &cli.GenericFlag{
+		Name: txmgr.L1MultiModeFlagName,
+		Usage: "How to use multiple L1 endpoints, if l1-eth-extra-rpcs is set. 'failover' rotates away from failing or lagging endpoints, " +
+			"'quorum' additionally requires L1 block headers to match across l1-quorum endpoints. Receipts and transactions are not checked against the headers. Valid options: " +
+			openum.EnumString(client.MultiModes),
+		EnvVars: prefixEnvVars("L1_MULTI_MODE"),
+		Value: func() *client.MultiMode {
+			out := client.MultiFailover
+			return &out
+		}(),
+	}
+	L1QuorumFlag = &cli.IntFlag{
+		Name: txmgr.L1QuorumFlagName,
+		Usage: "Number of L1 endpoints that must agree on block headers in quorum mode. Defaults to a majority of the endpoints if 0. " +
+			"A higher quorum needs more colluding endpoints to accept a wrong header, but fewer faulty endpoints to stall. " +
+			"Must be a majority and at most all but one of the endpoints, so quorum mode requires at least 3 endpoints.",
+		EnvVars: prefixEnvVars("L1_QUORUM"),
+	}
 	// Legacy Flags
 	L2OutputHDPathFlag = txmgr.L2OutputHDPathFlag
 )
@@ -93,6 +118,9 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	L1ExtraEthRpcsFlag,
+	L1MultiModeFlag,
+	L1QuorumFlag,
 	RollupRpcFlag,
 	SupervisorRpcsFlag,
 	L2OOAddressFlag,
@@ -2,12 +2,14 @@ package proposer
 
 import (
 	"errors"
+	"fmt"
 	"slices"
 	"time"
 
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-proposer/flags"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -40,6 +42,12 @@ type CLIConfig struct {
 	// L1EthRpc is the HTTP provider URL for L1.
 	L1EthRpc string
 
+	// L1ExtraEthRpcs are the HTTP provider URLs of additional L1 endpoints of the same chain, used together with L1EthRpc.
+	// See client.MultiClient for how L1MultiMode and L1Quorum are used.
+	L1ExtraEthRpcs []string
+	L1MultiMode    client.MultiMode
+	L1Quorum       int
+
 	// RollupRpc is the HTTP provider URL for the rollup node. A comma-separated list enables the active rollup provider.
 	RollupRpc string
 
@@ -84,6 +92,11 @@ type CLIConfig struct {
 }
 
 func (c *CLIConfig) Check() error {
+	if len(c.L1ExtraEthRpcs) > 0 {
+		if err := c.L1MultiConfig().Check(1 + len(c.L1ExtraEthRpcs)); err != nil {
+			return fmt.Errorf("invalid L1 RPC endpoints: %w", err)
+		}
+	}
 	if err := c.RPCConfig.Check(); err != nil {
 		return err
 	}
@@ -128,10 +141,18 @@ func (c *CLIConfig) Check() error {
 	return nil
 }
 
+// L1MultiConfig returns the configuration of the L1 MultiClient, used if there are extra L1 endpoints.
+func (c *CLIConfig) L1MultiConfig() client.MultiConfig {
+	return client.NewMultiConfig(c.L1MultiMode, c.L1Quorum, 1+len(c.L1ExtraEthRpcs))
+}
+
 // NewConfig parses the Config from the provided flags or environment variables.
 func NewConfig(ctx *cli.Context) *CLIConfig {
 	return &CLIConfig{
 		L1EthRpc:                     ctx.String(flags.L1EthRpcFlag.Name),
+		L1ExtraEthRpcs:               ctx.StringSlice(flags.L1ExtraEthRpcsFlag.Name),
+		L1MultiMode:                  client.MultiMode(ctx.String(flags.L1MultiModeFlag.Name)),
+		L1Quorum:                     ctx.Int(flags.L1QuorumFlag.Name),
 		RollupRpc:                    ctx.String(flags.RollupRpcFlag.Name),
 		SupervisorRpcs:               ctx.StringSlice(flags.SupervisorRpcsFlag.Name),
 		L2OOAddress:                  ctx.String(flags.L2OOAddressFlag.Name),
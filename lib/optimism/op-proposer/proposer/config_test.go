@@ -3,6 +3,7 @@ package proposer
 import (
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -94,6 +95,19 @@ func TestDisallowRollupAndSupervisorRPC(t *testing.T) {
 	require.ErrorIs(t, cfg.Check(), ErrConflictingSource)
 }
 
+func TestL1ExtraEthRpcs(t *testing.T) {
+	cfg := validConfig()
+	cfg.L1ExtraEthRpcs = []string{"http://localhost:8889/l1"}
+	cfg.L1MultiMode = client.MultiFailover
+	require.NoError(t, cfg.Check())
+
+	cfg.L1MultiMode = client.MultiQuorum
+	require.ErrorContains(t, cfg.Check(), "at least 3 endpoints")
+
+	cfg.L1ExtraEthRpcs = append(cfg.L1ExtraEthRpcs, "http://localhost:8890/l1")
+	require.NoError(t, cfg.Check())
+}
+
 func validConfig() *CLIConfig {
 	return &CLIConfig{
 		L1EthRpc:                     "http://localhost:8888/l1",
@@ -58,8 +58,10 @@ type ProposerService struct {
 
 	ProposerConfig
 
-	TxManager      txmgr.TxManager
-	L1Client       *ethclient.Client
+	TxManager txmgr.TxManager
+	L1Client  *ethclient.Client
+	// l1Multi spreads the L1Client requests over multiple L1 endpoints, if configured.
+	l1Multi        *client.MultiClient
 	ProposalSource source.ProposalSource
 
 	driver *L2OutputSubmitter
@@ -126,11 +128,19 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 }
 
 func (ps *ProposerService) initRPCClients(ctx context.Context, cfg *CLIConfig) error {
-	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, ps.Log, cfg.L1EthRpc)
-	if err != nil {
-		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	var err error
+	if len(cfg.L1ExtraEthRpcs) > 0 {
+		urls := append([]string{cfg.L1EthRpc}, cfg.L1ExtraEthRpcs...)
+		ps.L1Client, ps.l1Multi, err = dial.DialMultiEthClientWithTimeout(ctx, dial.DefaultDialTimeout, ps.Log, urls, cfg.L1MultiConfig())
+		if err != nil {
+			return fmt.Errorf("failed to dial L1 RPCs: %w", err)
+		}
+	} else {
+		ps.L1Client, err = dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, ps.Log, cfg.L1EthRpc)
+		if err != nil {
+			return fmt.Errorf("failed to dial L1 RPC: %w", err)
+		}
 	}
-	ps.L1Client = l1Client
 
 	if cfg.RollupRpc != "" {
 		var rollupProvider dial.RollupProvider
@@ -344,6 +354,9 @@ func (ps *ProposerService) Stop(ctx context.Context) error {
 	if ps.L1Client != nil {
 		ps.L1Client.Close()
 	}
+	if ps.l1Multi != nil {
+		ps.l1Multi.Close()
+	}
 
 	if ps.ProposalSource != nil {
 		ps.ProposalSource.Close()
@@ -1,18 +1,165 @@
 package apis
 
-import "context"
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
 
 type BatcherActivity interface {
 	StartBatcher(ctx context.Context) error
 	StopBatcher(ctx context.Context) error
 }
 
+type BatcherState interface {
+	ChannelManagerState(ctx context.Context) (ChannelManagerState, error)
+	ChannelCosts(ctx context.Context) ([]ChannelCost, error)
+	AltDAState(ctx context.Context) (AltDAState, error)
+}
+
+type BatcherChannelConfig interface {
+	UpdateChannelConfig(ctx context.Context, update ChannelConfigUpdate) error
+	ChannelConfigOverrides(ctx context.Context) (ChannelConfigUpdate, error)
+	// PinDAType pins the data availability type, "blobs", "calldata" or "auto", for durationSecs seconds.
+	// It is only supported if the batcher runs with data availability type auto, and returns an error otherwise.
+	PinDAType(ctx context.Context, daType string, durationSecs uint64) error
+}
+
 type BatcherAdminServer interface {
 	CommonAdminServer
 	BatcherActivity
+	BatcherState
+	BatcherChannelConfig
 }
 
 type BatcherAdminClient interface {
 	CommonAdminClient
 	BatcherActivity
+	BatcherState
+	BatcherChannelConfig
+}
+
+// ChannelManagerState is a read-only snapshot of the batcher's channel manager.
+type ChannelManagerState struct {
+	// Blocks describes the queue of L2 blocks loaded from the sequencer but not yet pruned as safe.
+	Blocks BlockQueueState `json:"blocks"`
+	// L1OriginLastSubmittedChannel is the latest L1 origin of the most recently submitted channel.
+	L1OriginLastSubmittedChannel eth.BlockID `json:"l1OriginLastSubmittedChannel"`
+	// Channels lists all channels in the channel queue, oldest first.
+	Channels []ChannelState `json:"channels"`
+	// PendingDABytes is the number of bytes pending to be written to the DA layer.
+	PendingDABytes int64 `json:"pendingDABytes"`
+}
+
+// BlockQueueState describes the range of L2 blocks held by the channel manager.
+type BlockQueueState struct {
+	// Oldest is the first block in the queue. Zero if the queue is empty.
+	Oldest eth.BlockID `json:"oldest"`
+	// Latest is the last block in the queue. Zero if the queue is empty.
+	Latest eth.BlockID `json:"latest"`
+	// Total is the number of blocks in the queue.
+	Total int `json:"total"`
+	// Pending is the number of blocks in the queue that have not been added to a channel yet.
+	Pending int `json:"pending"`
+}
+
+// ChannelState is a read-only snapshot of a single channel.
+type ChannelState struct {
+	ID derive.ChannelID `json:"id"`
+	// IsCurrent is true if this is the channel new blocks are currently written to.
+	IsCurrent bool `json:"isCurrent"`
+	// IsFull is true if the channel is closed for new blocks.
+	IsFull bool `json:"isFull"`
+	// FullReason is the reason the channel got closed, empty if it is still open.
+	FullReason string `json:"fullReason,omitempty"`
+	UseBlobs   bool   `json:"useBlobs"`
+
+	TotalFrames   int `json:"totalFrames"`
+	PendingFrames int `json:"pendingFrames"`
+	InputBytes    int `json:"inputBytes"`
+	OutputBytes   int `json:"outputBytes"`
+
+	// PendingTxs lists the IDs of transactions sent but not yet confirmed.
+	PendingTxs []string `json:"pendingTxs"`
+	// ConfirmedTxs lists the IDs of confirmed transactions with their L1 inclusion block.
+	ConfirmedTxs []ConfirmedTx `json:"confirmedTxs"`
+
+	OldestL1Origin eth.BlockID `json:"oldestL1Origin"`
+	LatestL1Origin eth.BlockID `json:"latestL1Origin"`
+	OldestL2       eth.BlockID `json:"oldestL2"`
+	LatestL2       eth.BlockID `json:"latestL2"`
+
+	// Timeout is the L1 block number at which the channel times out, 0 if no timeout is set yet.
+	Timeout uint64 `json:"timeout"`
+}
+
+// ConfirmedTx is a batcher transaction that got included on L1.
+type ConfirmedTx struct {
+	ID             string      `json:"id"`
+	InclusionBlock eth.BlockID `json:"inclusionBlock"`
+}
+
+// ChannelCost is the L1 cost of a channel whose transactions are all confirmed, or that timed out on L1.
+type ChannelCost struct {
+	ID derive.ChannelID `json:"id"`
+	// TimedOut is true if the channel timed out on L1, so it got resubmitted in a new channel.
+	TimedOut bool `json:"timedOut"`
+	// Incomplete is true if the cost of some of the channel's txs is unknown, because they
+	// got confirmed before the batcher restarted.
+	Incomplete bool `json:"incomplete"`
+
+	MinInclusionBlock uint64      `json:"minInclusionBlock"`
+	MaxInclusionBlock uint64      `json:"maxInclusionBlock"`
+	OldestL2          eth.BlockID `json:"oldestL2"`
+	LatestL2          eth.BlockID `json:"latestL2"`
+
+	// NumL2Blocks and NumL2Txs are the number of L2 blocks and non-deposit txs covered by the channel.
+	NumL2Blocks int `json:"numL2Blocks"`
+	NumL2Txs    int `json:"numL2Txs"`
+	// InputBytes is the number of uncompressed L2 batch bytes of the channel.
+	InputBytes  int `json:"inputBytes"`
+	OutputBytes int `json:"outputBytes"`
+
+	// NumL1Txs is the number of confirmed L1 txs the cost is accounted for.
+	NumL1Txs    int    `json:"numL1Txs"`
+	GasUsed     uint64 `json:"gasUsed"`
+	BlobGasUsed uint64 `json:"blobGasUsed"`
+	// ExecutionCost is the sum of gas used times effective gas price of all txs, in wei.
+	ExecutionCost *hexutil.Big `json:"executionCost"`
+	// BlobCost is the sum of blob gas used times blob gas price of all txs, in wei.
+	BlobCost  *hexutil.Big `json:"blobCost"`
+	TotalCost *hexutil.Big `json:"totalCost"`
+	// CostPerL2Byte is the total cost divided by the input bytes, in wei.
+	CostPerL2Byte float64 `json:"costPerL2Byte"`
+}
+
+// AltDAModeAltDA is the Alt-DA mode in which the batcher posts inputs to the DA server.
+// While failed over to Ethereum DA, the mode is the used data availability type instead.
+const AltDAModeAltDA = "altda"
+
+// AltDAState describes where the batcher currently posts its data with Alt-DA enabled.
+type AltDAState struct {
+	// Mode is "altda" if posting to the DA server, or "blobs" or "calldata" while failed over to Ethereum DA.
+	Mode string `json:"mode"`
+	// FailedOverSince is the unix timestamp at which the failover started, 0 if not failed over.
+	FailedOverSince uint64 `json:"failedOverSince"`
+	// ConsecutiveFailures is the number of consecutive failed requests to the DA server.
+	ConsecutiveFailures uint64 `json:"consecutiveFailures"`
+}
+
+// ChannelConfigUpdate holds channel configuration values to change at runtime.
+// Nil fields are left unchanged. Updates apply from the next channel onward.
+type ChannelConfigUpdate struct {
+	CompressorKind     *string                 `json:"compressorKind,omitempty"`
+	CompressionAlgo    *derive.CompressionAlgo `json:"compressionAlgo,omitempty"`
+	ApproxComprRatio   *float64                `json:"approxComprRatio,omitempty"`
+	TargetNumFrames    *int                    `json:"targetNumFrames,omitempty"`
+	MaxChannelDuration *uint64                 `json:"maxChannelDuration,omitempty"`
+	SubSafetyMargin    *uint64                 `json:"subSafetyMargin,omitempty"`
+	// DAType switches the data availability type to "blobs" or "calldata", if the batcher has a channel config for it.
+	// "auto" returns to choosing the type by L1 fees, if the batcher runs with data availability type auto.
+	DAType *string `json:"daType,omitempty"`
 }
@@ -1,8 +1,18 @@
 package apis
 
-import "context"
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type EventTracing interface {
+	StartEventTrace(ctx context.Context, name string, maxEvents uint64) error
+	StopEventTrace(ctx context.Context) (*eth.EventTraceResult, error)
+}
 
 type OpnodeAdminServer interface {
 	RollupAdminServer
+	EventTracing
 	ResetDerivationPipeline(ctx context.Context) error
 }
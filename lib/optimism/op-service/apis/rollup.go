@@ -34,12 +34,37 @@ type RollupSafeAtServer interface {
 	SafeHeadAtL1Block(ctx context.Context, blockNum hexutil.Uint64) (*eth.SafeHeadResponse, error)
 }
 
+type RollupSafeHeadsClient interface {
+	SafeHeadsInRange(ctx context.Context, start uint64, end uint64) ([]eth.SafeHeadResponse, error)
+	L1BlockAtSafeHead(ctx context.Context, l2BlockNum uint64) (*eth.SafeHeadResponse, error)
+}
+
+type RollupSafeHeadsServer interface {
+	SafeHeadsInRange(ctx context.Context, start hexutil.Uint64, end hexutil.Uint64) ([]eth.SafeHeadResponse, error)
+	L1BlockAtSafeHead(ctx context.Context, l2BlockNum hexutil.Uint64) (*eth.SafeHeadResponse, error)
+}
+
+type RollupDerivationInfoClient interface {
+	DerivationInfoAtBlock(ctx context.Context, blockNum uint64) (*eth.DerivationInfo, error)
+}
+
+type RollupDerivationInfoServer interface {
+	DerivationInfoAtBlock(ctx context.Context, blockNum hexutil.Uint64) (*eth.DerivationInfo, error)
+}
+
 type SequencerActivity interface {
 	StartSequencer(ctx context.Context, unsafeHead common.Hash) error
 	StopSequencer(ctx context.Context) (common.Hash, error)
 	SequencerActive(ctx context.Context) (bool, error)
 }
 
+// SequencerHandoff schedules a sequencer stop at a target block, and the start of the next sequencer after it.
+type SequencerHandoff interface {
+	StopSequencerAt(ctx context.Context, target eth.SequencerStopTarget) (common.Hash, error)
+	StartSequencerAt(ctx context.Context, unsafeHead common.Hash) error
+	CancelSequencerHandoff(ctx context.Context) error
+}
+
 type UnsignedPayloadPoster interface {
 	PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 }
@@ -56,6 +81,7 @@ type RecoverMode interface {
 type RollupAdminClient interface {
 	CommonAdminClient
 	SequencerActivity
+	SequencerHandoff
 	UnsignedPayloadPoster
 	RollupConductor
 	RecoverMode
@@ -64,6 +90,7 @@ type RollupAdminClient interface {
 type RollupAdminServer interface {
 	CommonAdminServer
 	SequencerActivity
+	SequencerHandoff
 	UnsignedPayloadPoster
 	RollupConductor
 	RecoverMode
@@ -75,6 +102,8 @@ type RollupNodeClient interface {
 	RollupSyncStatus
 	RollupOutputClient
 	RollupSafeAtClient
+	RollupSafeHeadsClient
+	RollupDerivationInfoClient
 }
 
 type RollupNodeServer interface {
@@ -83,6 +112,8 @@ type RollupNodeServer interface {
 	RollupSyncStatus
 	RollupOutputServer
 	RollupSafeAtServer
+	RollupSafeHeadsServer
+	RollupDerivationInfoServer
 }
 
 type RollupClient interface {
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// NewGethRPCClient returns a go-ethereum rpc.Client that sends its requests to the given RPC,
+// so that go-ethereum clients, like ethclient.Client, can be built on any RPC, e.g. a MultiClient.
+// The returned client doesn't support subscriptions, and closing it doesn't close the RPC.
+func NewGethRPCClient(ctx context.Context, cl RPC) (*rpc.Client, error) {
+	// The requests never leave the process, the URL only selects the HTTP transport of the client.
+	return rpc.DialOptions(ctx, "http://rpc.invalid", rpc.WithHTTPClient(&http.Client{Transport: &rpcTransport{rpc: cl}}))
+}
+
+// DialMultiEthClient dials all addresses, and returns an ethclient.Client that spreads its requests
+// over them with a MultiClient, see NewMultiRPC. Closing the ethclient.Client doesn't close the
+// MultiClient, which is returned to be closed by the caller.
+func DialMultiEthClient(ctx context.Context, lgr log.Logger, addrs []string, cfg MultiConfig, opts ...RPCOption) (*ethclient.Client, *MultiClient, error) {
+	multi, err := NewMultiRPC(ctx, lgr, addrs, cfg, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	gethClient, err := NewGethRPCClient(ctx, multi)
+	if err != nil {
+		multi.Close()
+		return nil, nil, err
+	}
+	return ethclient.NewClient(gethClient), multi, nil
+}
+
+// rpcTransport is an http.RoundTripper that answers the JSON-RPC requests of a go-ethereum
+// HTTP rpc.Client with an RPC, without sending them over the network.
+type rpcTransport struct {
+	rpc RPC
+}
+
+type jsonrpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type jsonrpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+type jsonrpcResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *jsonrpcError   `json:"error,omitempty"`
+}
+
+func (t *rpcTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+	var out any
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var msgs []jsonrpcRequest
+		if err := json.Unmarshal(body, &msgs); err != nil {
+			return nil, fmt.Errorf("invalid JSON-RPC batch: %w", err)
+		}
+		batch := make([]rpc.BatchElem, len(msgs))
+		results := make([]json.RawMessage, len(msgs))
+		for i, msg := range msgs {
+			batch[i] = rpc.BatchElem{Method: msg.Method, Args: callArgs(msg.Params), Result: &results[i]}
+		}
+		if err := t.rpc.BatchCallContext(req.Context(), batch); err != nil {
+			return nil, err
+		}
+		resps := make([]*jsonrpcResponse, len(msgs))
+		for i, msg := range msgs {
+			resps[i] = newJSONRPCResponse(msg.ID, results[i], batch[i].Error)
+		}
+		out = resps
+	} else {
+		var msg jsonrpcRequest
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return nil, fmt.Errorf("invalid JSON-RPC request: %w", err)
+		}
+		var result json.RawMessage
+		err := t.rpc.CallContext(req.Context(), &result, msg.Method, callArgs(msg.Params)...)
+		var rpcErr rpc.Error
+		if err != nil && !errors.As(err, &rpcErr) && !errors.Is(err, ethereum.NotFound) {
+			// not a response of the endpoint, e.g. a transport failure or no quorum
+			return nil, err
+		}
+		out = newJSONRPCResponse(msg.ID, result, err)
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode response: %w", err)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
+
+// callArgs converts JSON-RPC params to call arguments. Strings are decoded, so that labels like
+// "latest" can be told apart by the RPC, other params are forwarded as-is.
+func callArgs(params []json.RawMessage) []any {
+	args := make([]any, len(params))
+	for i, p := range params {
+		var s string
+		if len(p) > 0 && p[0] == '"' && json.Unmarshal(p, &s) == nil {
+			args[i] = s
+		} else {
+			args[i] = p
+		}
+	}
+	return args
+}
+
+func newJSONRPCResponse(id json.RawMessage, result json.RawMessage, err error) *jsonrpcResponse {
+	resp := &jsonrpcResponse{Version: "2.0", ID: id}
+	if err == nil || errors.Is(err, ethereum.NotFound) {
+		if len(result) == 0 {
+			result = json.RawMessage("null")
+		}
+		resp.Result = result
+		return resp
+	}
+	resp.Error = &jsonrpcError{Code: -32000, Message: err.Error()}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		resp.Error.Code = rpcErr.ErrorCode()
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		resp.Error.Data = dataErr.ErrorData()
+	}
+	return resp
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestGethRPCClient(t *testing.T) {
+	ctx := context.Background()
+	m := newTestMultiClient(t, MultiConfig{Mode: MultiQuorum, Quorum: 2},
+		&chainRPC{head: 12, salt: 1}, &chainRPC{head: 100, salt: 2}, &chainRPC{head: 12, salt: 1})
+	cl, err := NewGethRPCClient(ctx, m)
+	require.NoError(t, err)
+	defer cl.Close()
+
+	var header *quorumHeader
+	require.NoError(t, cl.CallContext(ctx, &header, "eth_getBlockByNumber", "latest", false))
+	require.Equal(t, hexutil.Uint64(12), header.Number, "labels are resolved by quorum")
+	require.Equal(t, common.Hash{1, 12}, header.Hash)
+
+	require.NoError(t, cl.CallContext(ctx, &header, "eth_getBlockByNumber", hexutil.Uint64(50), false))
+	require.Nil(t, header, "a quorum agrees the block doesn't exist")
+
+	err = cl.CallContext(ctx, nil, "foo_bar")
+	var rpcErr rpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, -32601, rpcErr.ErrorCode())
+	require.Equal(t, "method not found", rpcErr.Error())
+
+	var head hexutil.Uint64
+	batch := []rpc.BatchElem{
+		{Method: "eth_getBlockByNumber", Args: []any{hexutil.Uint64(5), false}, Result: &header},
+		{Method: "eth_blockNumber", Result: &head},
+		{Method: "foo_bar"},
+	}
+	require.NoError(t, cl.BatchCallContext(ctx, batch))
+	require.NoError(t, batch[0].Error)
+	require.Equal(t, common.Hash{1, 5}, header.Hash)
+	require.NoError(t, batch[1].Error)
+	require.Equal(t, hexutil.Uint64(12), head)
+	require.ErrorAs(t, batch[2].Error, &rpcErr)
+}
+
+func TestGethRPCClientNoQuorum(t *testing.T) {
+	ctx := context.Background()
+	m := newTestMultiClient(t, MultiConfig{Mode: MultiQuorum, Quorum: 2},
+		&chainRPC{head: 10, salt: 1}, &chainRPC{head: 10, salt: 2}, &chainRPC{head: 10, salt: 3})
+	cl, err := NewGethRPCClient(ctx, m)
+	require.NoError(t, err)
+	defer cl.Close()
+
+	var header *quorumHeader
+	require.ErrorContains(t, cl.CallContext(ctx, &header, "eth_getBlockByNumber", hexutil.Uint64(5), false), ErrNoQuorum.Error())
+}
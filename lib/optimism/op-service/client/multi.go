@@ -0,0 +1,483 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type MultiMode string
+
+const (
+	// MultiFailover sends requests to the first healthy endpoint, and rotates away from failing endpoints.
+	MultiFailover MultiMode = "failover"
+	// MultiQuorum additionally requires block headers to match across a quorum of endpoints.
+	MultiQuorum MultiMode = "quorum"
+)
+
+var MultiModes = []MultiMode{MultiFailover, MultiQuorum}
+
+func (m MultiMode) String() string {
+	return string(m)
+}
+
+func (m *MultiMode) Set(value string) error {
+	if !slices.Contains(MultiModes, MultiMode(value)) {
+		return fmt.Errorf("unknown multi-endpoint mode: %q", value)
+	}
+	*m = MultiMode(value)
+	return nil
+}
+
+func (m *MultiMode) Clone() any {
+	cpy := *m
+	return &cpy
+}
+
+var ErrNoQuorum = errors.New("no quorum")
+
+type MultiConfig struct {
+	Mode MultiMode
+	// Quorum is the number of endpoints that must agree on a block header, in quorum mode.
+	//
+	// The quorum trades liveness for safety: a higher quorum requires more endpoints to collude to
+	// serve a wrong header, but fewer faulty or lagging endpoints stall header requests. The quorum
+	// must be a majority of the endpoints, so conflicting headers can't both reach it, and at most
+	// all but one of the endpoints, so a single faulty endpoint can't stall header requests.
+	// Quorum mode thus requires at least 3 endpoints. See DefaultQuorum.
+	Quorum int
+	// HealthCheckInterval is the interval between health checks of all endpoints. Disabled if 0.
+	HealthCheckInterval time.Duration
+	// MaxLag is the number of blocks an endpoint may lag behind the highest endpoint before it is considered unhealthy.
+	MaxLag uint64
+}
+
+func (c MultiConfig) Check(endpoints int) error {
+	if !slices.Contains(MultiModes, c.Mode) {
+		return fmt.Errorf("unknown multi-endpoint mode: %q", c.Mode)
+	}
+	if c.Mode != MultiQuorum {
+		return nil
+	}
+	if endpoints < 3 {
+		return fmt.Errorf("quorum mode requires at least 3 endpoints, so a single faulty endpoint can't stall header requests, got %d", endpoints)
+	}
+	if c.Quorum <= endpoints/2 {
+		return fmt.Errorf("quorum of %d is not a majority of %d endpoints", c.Quorum, endpoints)
+	}
+	if c.Quorum > endpoints-1 {
+		return fmt.Errorf("quorum of %d with %d endpoints can be stalled by a single faulty endpoint, must be at most %d", c.Quorum, endpoints, endpoints-1)
+	}
+	return nil
+}
+
+const (
+	// DefaultMultiHealthCheckInterval is the default interval between health checks of the endpoints of a MultiClient.
+	DefaultMultiHealthCheckInterval = 10 * time.Second
+	// DefaultMultiMaxLag is the default number of blocks an endpoint may lag behind before rotating away from it.
+	DefaultMultiMaxLag = 3
+)
+
+// NewMultiConfig returns a MultiConfig of the given mode for the given number of endpoints,
+// with the default health check interval and maximum lag. The quorum defaults to DefaultQuorum if 0.
+func NewMultiConfig(mode MultiMode, quorum int, endpoints int) MultiConfig {
+	if quorum == 0 {
+		quorum = DefaultQuorum(endpoints)
+	}
+	return MultiConfig{
+		Mode:                mode,
+		Quorum:              quorum,
+		HealthCheckInterval: DefaultMultiHealthCheckInterval,
+		MaxLag:              DefaultMultiMaxLag,
+	}
+}
+
+// DefaultQuorum returns the smallest majority of the given number of endpoints.
+// It tolerates the most faulty endpoints without stalling header requests.
+func DefaultQuorum(endpoints int) int {
+	return endpoints/2 + 1
+}
+
+type multiEndpoint struct {
+	name string
+	rpc  RPC
+
+	healthy bool
+}
+
+// MultiClient is an RPC that spreads requests over multiple endpoints of the same chain.
+//
+// Requests are sent to the first healthy endpoint, in order of configuration. Endpoints that fail
+// with a transport error, fail a health check, or lag behind the other endpoints are marked
+// unhealthy until they pass a health check again.
+//
+// In quorum mode, block header requests (eth_getBlockByHash and eth_getBlockByNumber) are sent to all
+// endpoints, and only a response that matches across the quorum of endpoints, by block hash, parent hash
+// and receipts root, is returned. Requests by label, like "latest", resolve to the highest block number
+// that a quorum of endpoints has reached. Other responses, like receipts and transactions, must be
+// verified by the caller against the block headers, i.e. the RPC must not be trusted.
+//
+// The op-node L1 source uses a MultiClient, see its l1.extra-rpcs flag, and verifies all other responses
+// against the headers. The op-batcher and op-proposer, including their txmgr, use it through a go-ethereum
+// ethclient.Client, see DialMultiEthClient, which doesn't verify receipts and transactions. For them,
+// quorum mode only protects the L1 block headers.
+type MultiClient struct {
+	log log.Logger
+	cfg MultiConfig
+
+	mu        sync.Mutex
+	endpoints []*multiEndpoint
+
+	closeOnce sync.Once
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+var _ RPC = (*MultiClient)(nil)
+
+// NewMultiRPC dials all addresses, and returns a MultiClient over them.
+func NewMultiRPC(ctx context.Context, lgr log.Logger, addrs []string, cfg MultiConfig, opts ...RPCOption) (*MultiClient, error) {
+	if err := cfg.Check(len(addrs)); err != nil {
+		return nil, err
+	}
+	rpcs := make([]RPC, 0, len(addrs))
+	for _, addr := range addrs {
+		cl, err := NewRPC(ctx, lgr, addr, opts...)
+		if err != nil {
+			for _, cl := range rpcs {
+				cl.Close()
+			}
+			return nil, fmt.Errorf("failed to dial %s: %w", addr, err)
+		}
+		rpcs = append(rpcs, cl)
+	}
+	return NewMultiClient(lgr, cfg, addrs, rpcs), nil
+}
+
+// NewMultiClient returns a MultiClient over the given RPCs, named for logging.
+func NewMultiClient(lgr log.Logger, cfg MultiConfig, names []string, rpcs []RPC) *MultiClient {
+	m := &MultiClient{
+		log:  lgr,
+		cfg:  cfg,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	for i, cl := range rpcs {
+		m.endpoints = append(m.endpoints, &multiEndpoint{name: names[i], rpc: cl, healthy: true})
+	}
+	if cfg.HealthCheckInterval > 0 {
+		go m.healthCheckLoop()
+	} else {
+		close(m.done)
+	}
+	return m
+}
+
+func (m *MultiClient) healthCheckLoop() {
+	defer close(m.done)
+	ticker := time.NewTicker(m.cfg.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.CheckHealth()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// CheckHealth checks all endpoints for their latest block number,
+// and marks failing and lagging endpoints as unhealthy.
+func (m *MultiClient) CheckHealth() {
+	ctx, cancel := context.WithTimeout(context.Background(), m.healthCheckTimeout())
+	defer cancel()
+	heads := make([]uint64, len(m.endpoints))
+	errs := make([]error, len(m.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range m.endpoints {
+		wg.Add(1)
+		go func(i int, e *multiEndpoint) {
+			defer wg.Done()
+			var head hexutil.Uint64
+			errs[i] = e.rpc.CallContext(ctx, &head, "eth_blockNumber")
+			heads[i] = uint64(head)
+		}(i, e)
+	}
+	wg.Wait()
+
+	var highest uint64
+	for i := range m.endpoints {
+		if errs[i] == nil {
+			highest = max(highest, heads[i])
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, e := range m.endpoints {
+		healthy := errs[i] == nil && heads[i]+m.cfg.MaxLag >= highest
+		if healthy != e.healthy {
+			if healthy {
+				m.log.Info("RPC endpoint is healthy again", "endpoint", e.name, "head", heads[i])
+			} else {
+				m.log.Warn("RPC endpoint is unhealthy", "endpoint", e.name, "head", heads[i], "highest", highest, "err", errs[i])
+			}
+		}
+		e.healthy = healthy
+	}
+}
+
+func (m *MultiClient) healthCheckTimeout() time.Duration {
+	if m.cfg.HealthCheckInterval > 0 && m.cfg.HealthCheckInterval < 10*time.Second {
+		return m.cfg.HealthCheckInterval
+	}
+	return 10 * time.Second
+}
+
+// candidates returns the endpoints in order of preference: healthy ones first, in order of configuration.
+func (m *MultiClient) candidates() []*multiEndpoint {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]*multiEndpoint, 0, len(m.endpoints))
+	for _, e := range m.endpoints {
+		if e.healthy {
+			out = append(out, e)
+		}
+	}
+	for _, e := range m.endpoints {
+		if !e.healthy {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (m *MultiClient) markUnhealthy(e *multiEndpoint, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e.healthy {
+		m.log.Warn("RPC endpoint failed, rotating to next endpoint", "endpoint", e.name, "err", err)
+	}
+	e.healthy = false
+}
+
+// markHealthy marks an endpoint healthy again after a successful request.
+// Lagging endpoints are marked unhealthy again by the next health check.
+func (m *MultiClient) markHealthy(e *multiEndpoint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !e.healthy {
+		m.log.Info("RPC endpoint recovered", "endpoint", e.name)
+	}
+	e.healthy = true
+}
+
+// isEndpointFailure returns whether the error is a failure of the endpoint,
+// rather than a valid response, like a JSON-RPC error or a not-found result.
+func isEndpointFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// failover calls fn with the endpoints in order of preference, until one does not fail.
+func (m *MultiClient) failover(ctx context.Context, fn func(e *multiEndpoint) error) error {
+	var err error
+	for _, e := range m.candidates() {
+		err = fn(e)
+		if !isEndpointFailure(ctx, err) {
+			if err == nil {
+				m.markHealthy(e)
+			}
+			return err
+		}
+		m.markUnhealthy(e, err)
+	}
+	return err
+}
+
+func (m *MultiClient) Close() {
+	m.closeOnce.Do(func() {
+		close(m.stop)
+		<-m.done
+		for _, e := range m.endpoints {
+			e.rpc.Close()
+		}
+	})
+}
+
+func isHeaderMethod(method string) bool {
+	return method == "eth_getBlockByHash" || method == "eth_getBlockByNumber"
+}
+
+func (m *MultiClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	if m.cfg.Mode == MultiQuorum && isHeaderMethod(method) {
+		return m.quorumCall(ctx, result, method, args...)
+	}
+	return m.failover(ctx, func(e *multiEndpoint) error {
+		return e.rpc.CallContext(ctx, result, method, args...)
+	})
+}
+
+func (m *MultiClient) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	if m.cfg.Mode != MultiQuorum {
+		return m.failover(ctx, func(e *multiEndpoint) error {
+			return e.rpc.BatchCallContext(ctx, batch)
+		})
+	}
+	// Header requests need a quorum, the other requests can be batched.
+	var rest []rpc.BatchElem
+	var restIdx []int
+	for i := range batch {
+		if isHeaderMethod(batch[i].Method) {
+			batch[i].Error = m.quorumCall(ctx, batch[i].Result, batch[i].Method, batch[i].Args...)
+		} else {
+			rest = append(rest, batch[i])
+			restIdx = append(restIdx, i)
+		}
+	}
+	if len(rest) == 0 {
+		return nil
+	}
+	err := m.failover(ctx, func(e *multiEndpoint) error {
+		return e.rpc.BatchCallContext(ctx, rest)
+	})
+	for i, elem := range rest {
+		batch[restIdx[i]] = elem
+	}
+	return err
+}
+
+func (m *MultiClient) Subscribe(ctx context.Context, namespace string, channel any, args ...any) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	err := m.failover(ctx, func(e *multiEndpoint) (err error) {
+		sub, err = e.rpc.Subscribe(ctx, namespace, channel, args...)
+		return err
+	})
+	return sub, err
+}
+
+// quorumHeader identifies a block header response for quorum matching.
+type quorumHeader struct {
+	Number       hexutil.Uint64 `json:"number"`
+	Hash         common.Hash    `json:"hash"`
+	ParentHash   common.Hash    `json:"parentHash"`
+	ReceiptsRoot common.Hash    `json:"receiptsRoot"`
+}
+
+type quorumResponse struct {
+	raw    json.RawMessage
+	header *quorumHeader // nil if the block was not found
+	err    error
+}
+
+// quorumCall sends the header request to all endpoints, and returns the response that a quorum agrees on.
+func (m *MultiClient) quorumCall(ctx context.Context, result any, method string, args ...any) error {
+	if method == "eth_getBlockByNumber" && len(args) > 0 {
+		if label, ok := args[0].(string); ok && !isHexNumber(label) {
+			return m.quorumCallLabel(ctx, result, label, args[1:]...)
+		}
+	}
+	responses := m.callAll(ctx, method, args...)
+	raw, err := m.quorumOf(responses)
+	if err != nil {
+		return fmt.Errorf("%s %v: %w", method, args, err)
+	}
+	return json.Unmarshal(raw, result)
+}
+
+// quorumCallLabel resolves the label to the highest block number that a quorum of endpoints has reached,
+// so that a single endpoint that lags behind, or claims a block that does not exist, does not stall progress.
+func (m *MultiClient) quorumCallLabel(ctx context.Context, result any, label string, rest ...any) error {
+	responses := m.callAll(ctx, "eth_getBlockByNumber", append([]any{label}, rest...)...)
+	var numbers []uint64
+	for _, r := range responses {
+		if r.err == nil && r.header != nil {
+			numbers = append(numbers, uint64(r.header.Number))
+		}
+	}
+	if len(numbers) < m.cfg.Quorum {
+		if raw, err := m.quorumOf(responses); err == nil { // e.g. a quorum agrees the label does not exist yet
+			return json.Unmarshal(raw, result)
+		}
+		return fmt.Errorf("%w: only %d of %d endpoints returned block %q", ErrNoQuorum, len(numbers), len(responses), label)
+	}
+	slices.Sort(numbers)
+	num := numbers[len(numbers)-m.cfg.Quorum]
+	return m.quorumCall(ctx, result, "eth_getBlockByNumber", append([]any{hexutil.Uint64(num)}, rest...)...)
+}
+
+func isHexNumber(s string) bool {
+	_, err := hexutil.DecodeUint64(s)
+	return err == nil
+}
+
+func (m *MultiClient) callAll(ctx context.Context, method string, args ...any) []quorumResponse {
+	responses := make([]quorumResponse, len(m.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range m.endpoints {
+		wg.Add(1)
+		go func(i int, e *multiEndpoint) {
+			defer wg.Done()
+			var raw json.RawMessage
+			err := e.rpc.CallContext(ctx, &raw, method, args...)
+			if err != nil {
+				if isEndpointFailure(ctx, err) {
+					m.markUnhealthy(e, err)
+				}
+				responses[i] = quorumResponse{err: err}
+				return
+			}
+			var header *quorumHeader
+			if err := json.Unmarshal(raw, &header); err != nil {
+				responses[i] = quorumResponse{err: fmt.Errorf("invalid header from %s: %w", e.name, err)}
+				return
+			}
+			responses[i] = quorumResponse{raw: raw, header: header}
+		}(i, e)
+	}
+	wg.Wait()
+	return responses
+}
+
+// quorumOf returns the raw response that a quorum of the responses agree on.
+func (m *MultiClient) quorumOf(responses []quorumResponse) (json.RawMessage, error) {
+	votes := make(map[quorumHeader]int)
+	notFound := 0
+	var errs []error
+	for _, r := range responses {
+		if r.err != nil {
+			errs = append(errs, r.err)
+		} else if r.header == nil {
+			notFound++
+		} else {
+			votes[*r.header]++
+		}
+	}
+	for _, r := range responses {
+		if r.err == nil && r.header != nil && votes[*r.header] >= m.cfg.Quorum {
+			return r.raw, nil
+		}
+	}
+	if notFound >= m.cfg.Quorum {
+		return json.RawMessage("null"), nil
+	}
+	err := fmt.Errorf("%w: %d endpoints returned no matching header, %d did not find it, %d failed",
+		ErrNoQuorum, len(responses)-len(errs)-notFound, notFound, len(errs))
+	if len(errs) > 0 {
+		err = fmt.Errorf("%w: %w", err, errors.Join(errs...))
+	}
+	return nil, err
+}
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// chainRPC serves the headers of a chain up to its head.
+// The salt changes the hashes, to simulate an endpoint that serves a different chain.
+type chainRPC struct {
+	head  uint64
+	salt  byte
+	down  bool
+	calls int
+}
+
+func (c *chainRPC) header(num uint64) *quorumHeader {
+	if num > c.head {
+		return nil
+	}
+	return &quorumHeader{
+		Number:     hexutil.Uint64(num),
+		Hash:       common.Hash{c.salt, byte(num)},
+		ParentHash: common.Hash{c.salt, byte(num - 1)},
+	}
+}
+
+func (c *chainRPC) Close() {}
+
+func (c *chainRPC) CallContext(_ context.Context, result any, method string, args ...any) error {
+	c.calls++
+	if c.down {
+		return errors.New("connection refused")
+	}
+	var out any
+	switch method {
+	case "eth_blockNumber":
+		out = hexutil.Uint64(c.head)
+	case "eth_getBlockByNumber":
+		num := c.head
+		if s, ok := args[0].(string); !ok || s != "latest" {
+			data, _ := json.Marshal(args[0])
+			var n hexutil.Uint64
+			if err := json.Unmarshal(data, &n); err != nil {
+				return err
+			}
+			num = uint64(n)
+		}
+		out = c.header(num)
+	case "eth_getBlockByHash":
+		hash := args[0].(common.Hash)
+		out = c.header(uint64(hash[1]))
+		if hash[0] != c.salt {
+			out = nil
+		}
+	default:
+		return &rpcError{msg: "method not found"}
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (c *chainRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	if c.down {
+		return errors.New("connection refused")
+	}
+	for i := range b {
+		b[i].Error = c.CallContext(ctx, b[i].Result, b[i].Method, b[i].Args...)
+	}
+	return nil
+}
+
+func (c *chainRPC) Subscribe(context.Context, string, any, ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+type rpcError struct{ msg string }
+
+func (e *rpcError) Error() string  { return e.msg }
+func (e *rpcError) ErrorCode() int { return -32601 }
+
+func newTestMultiClient(t *testing.T, cfg MultiConfig, rpcs ...*chainRPC) *MultiClient {
+	names := make([]string, len(rpcs))
+	clients := make([]RPC, len(rpcs))
+	for i, r := range rpcs {
+		names[i] = string(rune('a' + i))
+		clients[i] = r
+	}
+	require.NoError(t, cfg.Check(len(rpcs)))
+	m := NewMultiClient(testlog.Logger(t, log.LevelDebug), cfg, names, clients)
+	t.Cleanup(m.Close)
+	return m
+}
+
+func TestMultiClientFailover(t *testing.T) {
+	ctx := context.Background()
+	a, b := &chainRPC{head: 10, salt: 1}, &chainRPC{head: 10, salt: 1}
+	m := newTestMultiClient(t, MultiConfig{Mode: MultiFailover}, a, b)
+
+	var head hexutil.Uint64
+	require.NoError(t, m.CallContext(ctx, &head, "eth_blockNumber"))
+	require.Equal(t, 1, a.calls)
+	require.Zero(t, b.calls)
+
+	// JSON-RPC errors are responses, not endpoint failures
+	require.Error(t, m.CallContext(ctx, &head, "foo_bar"))
+	require.Equal(t, 2, a.calls)
+	require.Zero(t, b.calls)
+
+	a.down = true
+	require.NoError(t, m.CallContext(ctx, &head, "eth_blockNumber"))
+	require.Equal(t, 3, a.calls)
+	require.Equal(t, 1, b.calls)
+	// the failed endpoint is avoided
+	batch := []rpc.BatchElem{{Method: "eth_blockNumber", Result: &head}}
+	require.NoError(t, m.BatchCallContext(ctx, batch))
+	require.Equal(t, 3, a.calls)
+
+	// lagging endpoints are unhealthy, recovered endpoints healthy again
+	a.down = false
+	b.head = 5
+	m.CheckHealth()
+	require.NoError(t, m.CallContext(ctx, &head, "eth_blockNumber"))
+	require.Equal(t, hexutil.Uint64(10), head)
+}
+
+func TestMultiClientQuorum(t *testing.T) {
+	ctx := context.Background()
+	cfg := MultiConfig{Mode: MultiQuorum, Quorum: 2}
+
+	t.Run("lying endpoint", func(t *testing.T) {
+		m := newTestMultiClient(t, cfg, &chainRPC{head: 12, salt: 1}, &chainRPC{head: 100, salt: 2}, &chainRPC{head: 12, salt: 1})
+		var header *quorumHeader
+		require.NoError(t, m.CallContext(ctx, &header, "eth_getBlockByNumber", "latest", false))
+		require.Equal(t, hexutil.Uint64(12), header.Number)
+		require.Equal(t, common.Hash{1, 12}, header.Hash)
+		require.NoError(t, m.CallContext(ctx, &header, "eth_getBlockByNumber", hexutil.Uint64(5), false))
+		require.Equal(t, common.Hash{1, 5}, header.Hash)
+		require.NoError(t, m.CallContext(ctx, &header, "eth_getBlockByHash", common.Hash{2, 5}, false))
+		require.Nil(t, header, "block of the lying endpoint is not found")
+	})
+	t.Run("lagging endpoint", func(t *testing.T) {
+		m := newTestMultiClient(t, cfg, &chainRPC{head: 10, salt: 1}, &chainRPC{head: 11, salt: 1}, &chainRPC{head: 12, salt: 1})
+		var header *quorumHeader
+		require.NoError(t, m.CallContext(ctx, &header, "eth_getBlockByNumber", "latest", false))
+		require.Equal(t, hexutil.Uint64(11), header.Number)
+	})
+	t.Run("no quorum", func(t *testing.T) {
+		m := newTestMultiClient(t, cfg, &chainRPC{head: 10, salt: 1}, &chainRPC{head: 10, salt: 2}, &chainRPC{head: 10, salt: 3, down: true})
+		var header *quorumHeader
+		batch := []rpc.BatchElem{
+			{Method: "eth_getBlockByNumber", Args: []any{hexutil.Uint64(5), false}, Result: &header},
+			{Method: "eth_blockNumber", Result: new(hexutil.Uint64)},
+		}
+		require.NoError(t, m.BatchCallContext(ctx, batch))
+		require.ErrorIs(t, batch[0].Error, ErrNoQuorum)
+		require.NoError(t, batch[1].Error)
+	})
+}
+
+func TestMultiConfigCheck(t *testing.T) {
+	require.NoError(t, MultiConfig{Mode: MultiFailover}.Check(2))
+	require.ErrorContains(t, MultiConfig{Mode: "foo"}.Check(2), "unknown")
+	require.ErrorContains(t, MultiConfig{Mode: MultiQuorum, Quorum: 2}.Check(2), "at least 3 endpoints")
+	require.ErrorContains(t, MultiConfig{Mode: MultiQuorum, Quorum: 1}.Check(3), "majority")
+	require.ErrorContains(t, MultiConfig{Mode: MultiQuorum, Quorum: 2}.Check(4), "majority")
+	require.ErrorContains(t, MultiConfig{Mode: MultiQuorum, Quorum: 3}.Check(3), "single faulty endpoint")
+	for n := 3; n <= 7; n++ {
+		require.NoError(t, MultiConfig{Mode: MultiQuorum, Quorum: DefaultQuorum(n)}.Check(n), "default quorum of %d endpoints", n)
+	}
+}
@@ -0,0 +1,233 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrCallNotRecorded is returned by the ReplayClient when no recorded response is left for a call.
+var ErrCallNotRecorded = errors.New("call was not recorded")
+
+// RecordedCall is a recorded RPC call and its response.
+type RecordedCall struct {
+	Method string          `json:"method"`
+	Args   json.RawMessage `json:"args"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// CallRecorder records the RPC calls of a named source, e.g. "l1" or "engine".
+type CallRecorder interface {
+	RecordCall(source string, call RecordedCall)
+}
+
+// RecordingClient is a wrapper around an RPC that records all calls and their responses.
+// Subscriptions are not recorded.
+type RecordingClient struct {
+	c      RPC
+	source string
+	rec    CallRecorder
+}
+
+var _ RPC = (*RecordingClient)(nil)
+
+func NewRecordingClient(c RPC, source string, rec CallRecorder) *RecordingClient {
+	return &RecordingClient{c: c, source: source, rec: rec}
+}
+
+func (r *RecordingClient) Close() {
+	r.c.Close()
+}
+
+func (r *RecordingClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	err := r.c.CallContext(ctx, result, method, args...)
+	recordCall(r.rec, r.source, method, args, result, err)
+	return err
+}
+
+func (r *RecordingClient) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	if err := r.c.BatchCallContext(ctx, batch); err != nil {
+		// The batch as a whole failed, and will be retried as a whole, so there are no responses to record.
+		return err
+	}
+	for _, elem := range batch {
+		recordCall(r.rec, r.source, elem.Method, elem.Args, elem.Result, elem.Error)
+	}
+	return nil
+}
+
+func (r *RecordingClient) Subscribe(ctx context.Context, namespace string, channel any, args ...any) (ethereum.Subscription, error) {
+	return r.c.Subscribe(ctx, namespace, channel, args...)
+}
+
+func recordCall(rec CallRecorder, source string, method string, args []any, result any, err error) {
+	call := RecordedCall{Method: method}
+	call.Args, _ = json.Marshal(args)
+	if err != nil {
+		call.Error = err.Error()
+	} else if data, mErr := json.Marshal(result); mErr != nil {
+		call.Error = fmt.Sprintf("failed to record result: %v", mErr)
+	} else {
+		call.Result = data
+	}
+	rec.RecordCall(source, call)
+}
+
+// recordedHTTPResponse is the recorded response to an HTTP GET request.
+type recordedHTTPResponse struct {
+	StatusCode int    `json:"statusCode"`
+	Body       []byte `json:"body"`
+}
+
+// httpMethod returns the method name that HTTP GET requests of the given path are recorded with.
+func httpMethod(path string) string {
+	return http.MethodGet + " " + path
+}
+
+// RecordingHTTP is a wrapper around an HTTP client that records all requests and their responses,
+// as calls of the method "GET <path>" with the query as argument.
+type RecordingHTTP struct {
+	c      HTTP
+	source string
+	rec    CallRecorder
+}
+
+var _ HTTP = (*RecordingHTTP)(nil)
+
+func NewRecordingHTTP(c HTTP, source string, rec CallRecorder) *RecordingHTTP {
+	return &RecordingHTTP{c: c, source: source, rec: rec}
+}
+
+func (r *RecordingHTTP) Get(ctx context.Context, path string, query url.Values, headers http.Header) (*http.Response, error) {
+	args := []any{query}
+	resp, err := r.c.Get(ctx, path, query, headers)
+	if err != nil {
+		recordCall(r.rec, r.source, httpMethod(path), args, nil, err)
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		err = fmt.Errorf("failed to read response body: %w", err)
+		recordCall(r.rec, r.source, httpMethod(path), args, nil, err)
+		return nil, err
+	}
+	recordCall(r.rec, r.source, httpMethod(path), args, &recordedHTTPResponse{StatusCode: resp.StatusCode, Body: body}, nil)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// ReplayClient is an RPC that serves recorded responses.
+// Calls with the same method and arguments are answered in the order they were recorded.
+type ReplayClient struct {
+	mu    sync.Mutex
+	calls map[string][]RecordedCall
+}
+
+var _ RPC = (*ReplayClient)(nil)
+
+func NewReplayClient(calls []RecordedCall) *ReplayClient {
+	r := &ReplayClient{calls: make(map[string][]RecordedCall)}
+	for _, call := range calls {
+		key := callKey(call.Method, call.Args)
+		r.calls[key] = append(r.calls[key], call)
+	}
+	return r
+}
+
+func callKey(method string, args json.RawMessage) string {
+	return method + string(args)
+}
+
+func (r *ReplayClient) Close() {}
+
+func (r *ReplayClient) CallContext(_ context.Context, result any, method string, args ...any) error {
+	argsData, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("failed to encode arguments of %s: %w", method, err)
+	}
+	r.mu.Lock()
+	key := callKey(method, argsData)
+	calls := r.calls[key]
+	if len(calls) == 0 {
+		r.mu.Unlock()
+		return fmt.Errorf("%w: %s %s", ErrCallNotRecorded, method, argsData)
+	}
+	call := calls[0]
+	r.calls[key] = calls[1:]
+	r.mu.Unlock()
+
+	if call.Error != "" {
+		// Restore the not-found error, since callers check for it.
+		if call.Error == ethereum.NotFound.Error() {
+			return ethereum.NotFound
+		}
+		return errors.New(call.Error)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(call.Result, result)
+}
+
+func (r *ReplayClient) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	for i := range batch {
+		batch[i].Error = r.CallContext(ctx, batch[i].Result, batch[i].Method, batch[i].Args...)
+	}
+	return nil
+}
+
+func (r *ReplayClient) Subscribe(_ context.Context, namespace string, _ any, _ ...any) (ethereum.Subscription, error) {
+	return nil, fmt.Errorf("subscriptions are not replayed: %s", namespace)
+}
+
+// Remaining returns the number of recorded responses that were not replayed.
+func (r *ReplayClient) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, calls := range r.calls {
+		n += len(calls)
+	}
+	return n
+}
+
+// ReplayHTTP is an HTTP client that serves the responses recorded by a RecordingHTTP.
+// Requests with the same path and query are answered in the order they were recorded.
+type ReplayHTTP struct {
+	calls *ReplayClient
+}
+
+var _ HTTP = (*ReplayHTTP)(nil)
+
+func NewReplayHTTP(calls []RecordedCall) *ReplayHTTP {
+	return &ReplayHTTP{calls: NewReplayClient(calls)}
+}
+
+func (r *ReplayHTTP) Get(ctx context.Context, path string, query url.Values, _ http.Header) (*http.Response, error) {
+	var resp recordedHTTPResponse
+	if err := r.calls.CallContext(ctx, &resp, httpMethod(path), query); err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:     http.StatusText(resp.StatusCode),
+		StatusCode: resp.StatusCode,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewReader(resp.Body)),
+	}, nil
+}
+
+// Remaining returns the number of recorded responses that were not replayed.
+func (r *ReplayHTTP) Remaining() int {
+	return r.calls.Remaining()
+}
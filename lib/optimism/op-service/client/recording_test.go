@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+type callsRecorder []RecordedCall
+
+func (c *callsRecorder) RecordCall(source string, call RecordedCall) {
+	*c = append(*c, call)
+}
+
+// echoRPC answers calls with their first argument, or not-found if there is none.
+type echoRPC struct{}
+
+func (echoRPC) Close() {}
+
+func (echoRPC) CallContext(_ context.Context, result any, method string, args ...any) error {
+	if len(args) == 0 {
+		return ethereum.NotFound
+	}
+	*(result.(*string)) = args[0].(string)
+	return nil
+}
+
+func (e echoRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	for i := range b {
+		b[i].Error = e.CallContext(ctx, b[i].Result, b[i].Method, b[i].Args...)
+	}
+	return nil
+}
+
+func (echoRPC) Subscribe(context.Context, string, any, ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestRecordReplayClient(t *testing.T) {
+	ctx := context.Background()
+	var rec callsRecorder
+	cl := NewRecordingClient(echoRPC{}, "test", &rec)
+	var a, b string
+	require.NoError(t, cl.CallContext(ctx, &a, "echo", "a"))
+	require.ErrorIs(t, cl.CallContext(ctx, &a, "echo"), ethereum.NotFound)
+	batch := []rpc.BatchElem{
+		{Method: "echo", Args: []any{"a"}, Result: &a},
+		{Method: "echo", Args: []any{"b"}, Result: &b},
+	}
+	require.NoError(t, cl.BatchCallContext(ctx, batch))
+	require.Len(t, rec, 4)
+
+	replay := NewReplayClient(rec)
+	var out string
+	require.NoError(t, replay.CallContext(ctx, &out, "echo", "b"))
+	require.Equal(t, "b", out)
+	require.ErrorIs(t, replay.CallContext(ctx, &out, "echo"), ethereum.NotFound)
+	batch = []rpc.BatchElem{
+		{Method: "echo", Args: []any{"a"}, Result: &a},
+		{Method: "echo", Args: []any{"a"}, Result: &b},
+	}
+	require.NoError(t, replay.BatchCallContext(ctx, batch))
+	require.NoError(t, batch[0].Error)
+	require.NoError(t, batch[1].Error)
+	require.Equal(t, "a", b)
+	require.Zero(t, replay.Remaining())
+	require.ErrorIs(t, replay.CallContext(ctx, &out, "echo", "a"), ErrCallNotRecorded)
+}
+
+func TestRecordReplayHTTP(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/echo" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(r.URL.Query().Get("v")))
+	}))
+	t.Cleanup(server.Close)
+
+	var rec callsRecorder
+	cl := NewRecordingHTTP(NewBasicHTTPClient(server.URL, log.Root()), "test", &rec)
+	get := func(cl HTTP, path string, v string) (int, string) {
+		resp, err := cl.Get(ctx, path, url.Values{"v": {v}}, nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+	status, body := get(cl, "echo", "a")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "a", body)
+	status, _ = get(cl, "missing", "a")
+	require.Equal(t, http.StatusNotFound, status)
+	get(cl, "echo", "b")
+	require.Len(t, rec, 3)
+
+	replay := NewReplayHTTP(rec)
+	status, body = get(replay, "echo", "b")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "b", body)
+	status, _ = get(replay, "missing", "a")
+	require.Equal(t, http.StatusNotFound, status)
+	require.Equal(t, 1, replay.Remaining())
+	_, err := replay.Get(ctx, "echo", url.Values{"v": {"c"}}, nil)
+	require.ErrorIs(t, err, ErrCallNotRecorded)
+}
@@ -32,6 +32,16 @@ func DialEthClientWithTimeout(ctx context.Context, timeout time.Duration, log lo
 	return ethclient.NewClient(c), nil
 }
 
+// DialMultiEthClientWithTimeout dials all given URLs, and returns an ethclient.Client that spreads
+// its requests over them, see client.DialMultiEthClient. If the dial doesn't complete within timeout,
+// this method will return an error. The returned MultiClient must be closed by the caller.
+func DialMultiEthClientWithTimeout(ctx context.Context, timeout time.Duration, log log.Logger, urls []string, cfg client.MultiConfig) (*ethclient.Client, *client.MultiClient, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return client.DialMultiEthClient(ctx, log, urls, cfg, client.WithDialAttempts(defaultRetryCount))
+}
+
 // DialRollupClientWithTimeout attempts to dial the RPC provider using the provided URL.
 // If the dial doesn't complete within timeout seconds, this method will return an error.
 func DialRollupClientWithTimeout(ctx context.Context, timeout time.Duration, log log.Logger, url string, callerOpts ...client.RPCOption) (*sources.RollupClient, error) {
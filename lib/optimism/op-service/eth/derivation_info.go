@@ -0,0 +1,30 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	SingularBatchKind = "singular"
+	SpanBatchKind     = "span"
+)
+
+// DerivationFrame identifies a channel frame and the batcher transaction it was included in.
+type DerivationFrame struct {
+	FrameNumber    uint16      `json:"frameNumber"`
+	InclusionBlock BlockID     `json:"inclusionBlock"`
+	TxHash         common.Hash `json:"txHash"`
+	// BlobIndex is the index of the blob within the inclusion block, if the frame was posted in a blob.
+	BlobIndex *uint64 `json:"blobIndex,omitempty"`
+}
+
+// DerivationInfo describes the L1 data that a safe L2 block was derived from.
+type DerivationInfo struct {
+	L2Block BlockID `json:"l2Block"`
+	// L1Block is the L1 block the derivation pipeline was at when the L2 block was derived.
+	L1Block   BlockID           `json:"l1Block"`
+	ChannelID hexutil.Bytes     `json:"channelId"`
+	BatchKind string            `json:"batchKind"` // SingularBatchKind or SpanBatchKind
+	Frames    []DerivationFrame `json:"frames"`
+}
@@ -0,0 +1,11 @@
+package eth
+
+// EventTraceResult describes a completed capture of the op-node event system.
+type EventTraceResult struct {
+	// Path is the file the trace was written to.
+	Path string `json:"path"`
+	// Events is the number of trace events in the file.
+	Events uint64 `json:"events"`
+	// Dropped is the number of trace events that were dropped, since the capture was full.
+	Dropped uint64 `json:"dropped"`
+}
@@ -0,0 +1,39 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SequencerStopTarget identifies the last L2 block a sequencer seals before a scheduled stop.
+// Exactly one of Number and Timestamp must be set.
+type SequencerStopTarget struct {
+	// Number is the number of the last L2 block to seal.
+	Number hexutil.Uint64 `json:"number,omitempty"`
+	// Timestamp makes the first L2 block with a timestamp at or after it the last block to seal.
+	Timestamp hexutil.Uint64 `json:"timestamp,omitempty"`
+}
+
+func (t SequencerStopTarget) Check() error {
+	if (t.Number == 0) == (t.Timestamp == 0) {
+		return errors.New("exactly one of the stop target number and timestamp must be set")
+	}
+	return nil
+}
+
+// Reached returns true if the given L2 block is, or is past, the last block to seal.
+func (t SequencerStopTarget) Reached(ref L2BlockRef) bool {
+	if t.Number != 0 {
+		return ref.Number >= uint64(t.Number)
+	}
+	return ref.Time >= uint64(t.Timestamp)
+}
+
+func (t SequencerStopTarget) String() string {
+	if t.Number != 0 {
+		return fmt.Sprintf("block %d", uint64(t.Number))
+	}
+	return fmt.Sprintf("timestamp %d", uint64(t.Timestamp))
+}
@@ -13,6 +13,7 @@ import (
 const (
 	RollupConfigFlagName               = "rollup.config"
 	NetworkFlagName                    = "network"
+	NetworksDirFlagName                = "networks.dir"
 	CanyonOverrideFlagName             = "override.canyon"
 	DeltaOverrideFlagName              = "override.delta"
 	EcotoneOverrideFlagName            = "override.ecotone"
@@ -74,6 +75,7 @@ func CLIFlags(envPrefix string, category string) []cli.Flag {
 			Category: category,
 		},
 		CLINetworkFlag(envPrefix, category),
+		CLINetworksDirFlag(envPrefix, category),
 		CLIRollupConfigFlag(envPrefix, category),
 	}
 }
@@ -87,6 +89,16 @@ func CLINetworkFlag(envPrefix string, category string) cli.Flag {
 	}
 }
 
+func CLINetworksDirFlag(envPrefix string, category string) cli.Flag {
+	return &cli.StringFlag{
+		Name: NetworksDirFlagName,
+		Usage: "Directory of custom networks, with a subdirectory per network, named after the network or its chain ID. " +
+			"Each network directory has a rollup.json, and optionally a genesis.json or chain-config.json. The networks are reloaded on change.",
+		EnvVars:  opservice.PrefixEnvVar(envPrefix, "NETWORKS_DIR"),
+		Category: category,
+	}
+}
+
 func CLIRollupConfigFlag(envPrefix string, category string) cli.Flag {
 	return &cli.StringFlag{
 		Name:     RollupConfigFlagName,
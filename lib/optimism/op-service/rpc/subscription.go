@@ -8,28 +8,68 @@ import (
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 )
 
+// Source is a source of values for an RPC subscription, usually backed by a bounded queue.
+// Err receives an error, or is closed, when the source stops delivering values,
+// e.g. because the subscriber lagged behind.
+type Source[T any] interface {
+	Values() <-chan T
+	Err() <-chan error
+	Unsubscribe()
+}
+
+// feedSource is a Source of the values sent to an event feed.
+type feedSource[T any] struct {
+	ch  chan T
+	sub event.Subscription
+}
+
+func (s *feedSource[T]) Values() <-chan T { return s.ch }
+
+func (s *feedSource[T]) Err() <-chan error { return s.sub.Err() }
+
+func (s *feedSource[T]) Unsubscribe() { s.sub.Unsubscribe() }
+
+// SubscribeRPC forwards the values sent to the given feed to an RPC subscription.
 func SubscribeRPC[T any](ctx context.Context, logger log.Logger, feed *event.FeedOf[T]) (*gethrpc.Subscription, error) {
+	return SubscribeRPCSource(ctx, logger, func() (Source[T], error) {
+		ch := make(chan T, 10)
+		return &feedSource[T]{ch: ch, sub: feed.Subscribe(ch)}, nil
+	})
+}
+
+// SubscribeRPCSource forwards the values of the source, opened with the given subscribe function,
+// to an RPC subscription. The source is only opened if the RPC connection supports subscriptions.
+// The values are forwarded from a separate goroutine, and the RPC subscription is closed
+// when the source stops delivering values.
+func SubscribeRPCSource[T any](ctx context.Context, logger log.Logger, subscribe func() (Source[T], error)) (*gethrpc.Subscription, error) {
 	notifier, supported := gethrpc.NotifierFromContext(ctx)
 	if !supported {
 		return &gethrpc.Subscription{}, gethrpc.ErrNotificationsUnsupported
 	}
+	src, err := subscribe()
+	if err != nil {
+		return nil, err
+	}
 	logger.Info("Opening subscription via RPC")
 
 	rpcSub := notifier.CreateSubscription()
-	ch := make(chan T, 10)
-	feedSub := feed.Subscribe(ch)
 
 	go func() {
 		defer logger.Info("Closing RPC subscription")
-		defer feedSub.Unsubscribe()
+		defer src.Unsubscribe()
 
 		for {
 			select {
-			case v := <-ch:
+			case v := <-src.Values():
 				if err := notifier.Notify(rpcSub.ID, v); err != nil {
 					logger.Warn("Failed to notify RPC subscription", "err", err)
 					return
 				}
+			case err, ok := <-src.Err():
+				if ok {
+					logger.Warn("Subscription source dropped", "err", err)
+				}
+				return
 			case err, ok := <-rpcSub.Err():
 				if !ok {
 					logger.Debug("Exiting subscription")
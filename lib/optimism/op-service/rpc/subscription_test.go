@@ -2,7 +2,9 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -18,8 +20,22 @@ type testSubscribeAPI struct {
 
 	foo gethevent.FeedOf[int]
 	bar gethevent.FeedOf[string]
+	baz *testSource
 }
 
+// testSource is a Source that records when it's unsubscribed.
+type testSource struct {
+	values       chan int
+	err          chan error
+	unsubscribed chan struct{}
+}
+
+func (s *testSource) Values() <-chan int { return s.values }
+
+func (s *testSource) Err() <-chan error { return s.err }
+
+func (s *testSource) Unsubscribe() { close(s.unsubscribed) }
+
 func (api *testSubscribeAPI) Foo(ctx context.Context) (*rpc.Subscription, error) {
 	return SubscribeRPC(ctx, api.log, &api.foo)
 }
@@ -28,6 +44,12 @@ func (api *testSubscribeAPI) Bar(ctx context.Context) (*rpc.Subscription, error)
 	return SubscribeRPC(ctx, api.log, &api.bar)
 }
 
+func (api *testSubscribeAPI) Baz(ctx context.Context) (*rpc.Subscription, error) {
+	return SubscribeRPCSource(ctx, api.log, func() (Source[int], error) {
+		return api.baz, nil
+	})
+}
+
 func (api *testSubscribeAPI) GreetName(ctx context.Context, name string) (*rpc.Subscription, error) {
 	return nil, &rpc.JsonError{
 		Code:    -100_000,
@@ -107,3 +129,41 @@ func TestSubscribeRPC(t *testing.T) {
 
 	greetCancel()
 }
+
+func TestSubscribeRPCSource(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	server := rpc.NewServer()
+	src := &testSource{
+		values:       make(chan int, 10),
+		err:          make(chan error, 1),
+		unsubscribed: make(chan struct{}),
+	}
+	api := &testSubscribeAPI{log: logger, baz: src}
+	require.NoError(t, server.RegisterName("custom", api))
+	cl := rpc.DialInProc(server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan int, 10)
+	sub, err := cl.Subscribe(ctx, "custom", ch, "baz")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	src.values <- 1
+	src.values <- 2
+	for _, v := range []int{1, 2} {
+		select {
+		case x := <-ch:
+			require.Equal(t, v, x)
+		case err := <-sub.Err():
+			require.NoError(t, err)
+		}
+	}
+
+	// an error of the source closes the subscription, and unsubscribes from the source
+	src.err <- errors.New("lagging")
+	select {
+	case <-src.unsubscribed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("source was not unsubscribed")
+	}
+}
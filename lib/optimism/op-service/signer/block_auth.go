@@ -2,8 +2,10 @@ package signer
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -48,21 +50,90 @@ type OPStackP2PBlockAuth interface {
 // This domain is a fully zeroed 32 bytes.
 var SigningDomainBlocksV1 = [32]byte{}
 
+// AuthorizedSigner is an address that is allowed to sign P2P blocks,
+// optionally only for blocks within a window of L2 block timestamps.
+// Overlapping windows of an old and a new signer allow the signing key to be rotated without downtime.
+type AuthorizedSigner struct {
+	Address common.Address `json:"address"`
+	// ActivationTime is the first L2 block timestamp that the signer is allowed to sign. Unbounded if nil.
+	ActivationTime *uint64 `json:"activationTime,omitempty"`
+	// ExpiryTime is the first L2 block timestamp that the signer is no longer allowed to sign. Unbounded if nil.
+	ExpiryTime *uint64 `json:"expiryTime,omitempty"`
+}
+
+// ActiveAt returns whether the signer is allowed to sign blocks with the given L2 timestamp.
+func (s *AuthorizedSigner) ActiveAt(timestamp uint64) bool {
+	if s.ActivationTime != nil && timestamp < *s.ActivationTime {
+		return false
+	}
+	if s.ExpiryTime != nil && timestamp >= *s.ExpiryTime {
+		return false
+	}
+	return true
+}
+
+// Check verifies the signer configuration is sane.
+func (s *AuthorizedSigner) Check() error {
+	if s.Address == (common.Address{}) {
+		return errors.New("missing signer address")
+	}
+	if s.ActivationTime != nil && s.ExpiryTime != nil && *s.ExpiryTime <= *s.ActivationTime {
+		return fmt.Errorf("signer %s expires at %d, before it activates at %d", s.Address, *s.ExpiryTime, *s.ActivationTime)
+	}
+	return nil
+}
+
+// LoadAuthorizedSigners reads a JSON list of authorized signers from the given file.
+func LoadAuthorizedSigners(path string) ([]AuthorizedSigner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signers file: %w", err)
+	}
+	var signers []AuthorizedSigner
+	if err := json.Unmarshal(data, &signers); err != nil {
+		return nil, fmt.Errorf("failed to decode signers file: %w", err)
+	}
+	for i := range signers {
+		if err := signers[i].Check(); err != nil {
+			return nil, fmt.Errorf("invalid signer %d: %w", i, err)
+		}
+	}
+	return signers, nil
+}
+
 // OPStackP2PBlockAuthV1 provides the V1 OP-Stack P2P block authentication context.
 type OPStackP2PBlockAuthV1 struct {
+	// Allowed is a signer that is allowed to sign blocks of any timestamp. Optional if Signers is set.
 	Allowed common.Address
-	Chain   eth.ChainID
+	// Signers are allowed to sign blocks with a Timestamp within their activation window.
+	Signers []AuthorizedSigner
+	// Timestamp is the L2 block timestamp of the block that is authenticated.
+	Timestamp uint64
+	Chain     eth.ChainID
 }
 
 var _ OPStackP2PBlockAuth = (*OPStackP2PBlockAuthV1)(nil)
 
 func (a *OPStackP2PBlockAuthV1) Check(signer common.Address) error {
-	if a.Allowed == (common.Address{}) {
+	if a.Allowed == (common.Address{}) && len(a.Signers) == 0 {
 		return errors.New("missing signer address configuration")
 	}
-	if a.Allowed == signer {
+	if a.Allowed != (common.Address{}) && a.Allowed == signer {
 		return nil
 	}
+	known := false
+	for i := range a.Signers {
+		if a.Signers[i].Address != signer {
+			continue
+		}
+		if a.Signers[i].ActiveAt(a.Timestamp) {
+			return nil
+		}
+		known = true
+	}
+	if known {
+		return fmt.Errorf("signer %s is not active at timestamp %d", signer, a.Timestamp)
+	}
 	return errors.New("unrecognized signer")
 }
 
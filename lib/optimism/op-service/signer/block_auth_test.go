@@ -2,6 +2,8 @@ package signer
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -61,3 +63,77 @@ func TestBlockAuth(t *testing.T) {
 		require.NoError(t, v1Auth.VerifyP2PBlockSignature(payloadHash, sigA))
 	})
 }
+
+func TestBlockAuthSigners(t *testing.T) {
+	oldKey, newKey := common.Address{0: 1}, common.Address{0: 2}
+	ts := func(v uint64) *uint64 { return &v }
+	auth := OPStackP2PBlockAuthV1{
+		Signers: []AuthorizedSigner{
+			{Address: oldKey, ExpiryTime: ts(200)},
+			{Address: newKey, ActivationTime: ts(100)},
+		},
+		Chain: eth.ChainIDFromUInt64(42),
+	}
+	for _, tc := range []struct {
+		timestamp uint64
+		signer    common.Address
+		err       string
+	}{
+		{timestamp: 50, signer: oldKey},
+		{timestamp: 50, signer: newKey, err: "not active"},
+		{timestamp: 150, signer: oldKey},
+		{timestamp: 150, signer: newKey},
+		{timestamp: 200, signer: oldKey, err: "not active"},
+		{timestamp: 200, signer: newKey},
+		{timestamp: 150, signer: common.Address{0: 3}, err: "unrecognized"},
+	} {
+		auth.Timestamp = tc.timestamp
+		err := auth.Check(tc.signer)
+		if tc.err == "" {
+			require.NoError(t, err, "signer %s at %d", tc.signer, tc.timestamp)
+		} else {
+			require.ErrorContains(t, err, tc.err, "signer %s at %d", tc.signer, tc.timestamp)
+		}
+	}
+
+	t.Run("allowed signer is always active", func(t *testing.T) {
+		auth := auth
+		auth.Allowed = common.Address{0: 3}
+		auth.Timestamp = 1000
+		require.NoError(t, auth.Check(common.Address{0: 3}))
+		require.ErrorContains(t, auth.Check(oldKey), "not active")
+	})
+}
+
+func TestLoadAuthorizedSigners(t *testing.T) {
+	write := func(t *testing.T, data string) string {
+		path := filepath.Join(t.TempDir(), "signers.json")
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		return path
+	}
+	t.Run("valid", func(t *testing.T) {
+		signers, err := LoadAuthorizedSigners(write(t, `[
+			{"address": "0x0000000000000000000000000000000000000001", "expiryTime": 200},
+			{"address": "0x0000000000000000000000000000000000000002", "activationTime": 100}
+		]`))
+		require.NoError(t, err)
+		require.Len(t, signers, 2)
+		require.Equal(t, common.Address{19: 1}, signers[0].Address)
+		require.Nil(t, signers[0].ActivationTime)
+		require.Equal(t, uint64(200), *signers[0].ExpiryTime)
+		require.Equal(t, uint64(100), *signers[1].ActivationTime)
+		require.Nil(t, signers[1].ExpiryTime)
+	})
+	t.Run("missing address", func(t *testing.T) {
+		_, err := LoadAuthorizedSigners(write(t, `[{"activationTime": 100}]`))
+		require.ErrorContains(t, err, "missing signer address")
+	})
+	t.Run("empty window", func(t *testing.T) {
+		_, err := LoadAuthorizedSigners(write(t, `[{"address": "0x0000000000000000000000000000000000000001", "activationTime": 100, "expiryTime": 100}]`))
+		require.ErrorContains(t, err, "before it activates")
+	})
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadAuthorizedSigners(filepath.Join(t.TempDir(), "missing.json"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
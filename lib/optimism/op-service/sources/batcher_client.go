@@ -0,0 +1,67 @@
+package sources
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+type BatcherAdminClient struct {
+	rpc client.RPC
+}
+
+var _ apis.BatcherAdminClient = (*BatcherAdminClient)(nil)
+
+func NewBatcherAdminClient(rpc client.RPC) *BatcherAdminClient {
+	return &BatcherAdminClient{rpc}
+}
+
+func (c *BatcherAdminClient) StartBatcher(ctx context.Context) error {
+	return c.rpc.CallContext(ctx, nil, "admin_startBatcher")
+}
+
+func (c *BatcherAdminClient) StopBatcher(ctx context.Context) error {
+	return c.rpc.CallContext(ctx, nil, "admin_stopBatcher")
+}
+
+func (c *BatcherAdminClient) ChannelManagerState(ctx context.Context) (apis.ChannelManagerState, error) {
+	var output apis.ChannelManagerState
+	err := c.rpc.CallContext(ctx, &output, "admin_channelManagerState")
+	return output, err
+}
+
+func (c *BatcherAdminClient) ChannelCosts(ctx context.Context) ([]apis.ChannelCost, error) {
+	var output []apis.ChannelCost
+	err := c.rpc.CallContext(ctx, &output, "admin_channelCosts")
+	return output, err
+}
+
+func (c *BatcherAdminClient) AltDAState(ctx context.Context) (apis.AltDAState, error) {
+	var output apis.AltDAState
+	err := c.rpc.CallContext(ctx, &output, "admin_altDAState")
+	return output, err
+}
+
+func (c *BatcherAdminClient) UpdateChannelConfig(ctx context.Context, update apis.ChannelConfigUpdate) error {
+	return c.rpc.CallContext(ctx, nil, "admin_updateChannelConfig", update)
+}
+
+func (c *BatcherAdminClient) ChannelConfigOverrides(ctx context.Context) (apis.ChannelConfigUpdate, error) {
+	var output apis.ChannelConfigUpdate
+	err := c.rpc.CallContext(ctx, &output, "admin_channelConfigOverrides")
+	return output, err
+}
+
+func (c *BatcherAdminClient) PinDAType(ctx context.Context, daType string, durationSecs uint64) error {
+	return c.rpc.CallContext(ctx, nil, "admin_pinDAType", daType, durationSecs)
+}
+
+func (c *BatcherAdminClient) SetLogLevel(ctx context.Context, lvl slog.Level) error {
+	return c.rpc.CallContext(ctx, nil, "admin_setLogLevel", lvl.String())
+}
+
+func (c *BatcherAdminClient) Close() {
+	c.rpc.Close()
+}
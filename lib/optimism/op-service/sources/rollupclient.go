@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
@@ -35,12 +36,36 @@ func (r *RollupClient) SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (
 	return output, err
 }
 
+func (r *RollupClient) SafeHeadsInRange(ctx context.Context, start uint64, end uint64) ([]eth.SafeHeadResponse, error) {
+	var output []eth.SafeHeadResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_safeHeadsInRange", hexutil.Uint64(start), hexutil.Uint64(end))
+	return output, err
+}
+
+func (r *RollupClient) L1BlockAtSafeHead(ctx context.Context, l2BlockNum uint64) (*eth.SafeHeadResponse, error) {
+	var output *eth.SafeHeadResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_l1BlockAtSafeHead", hexutil.Uint64(l2BlockNum))
+	return output, err
+}
+
+func (r *RollupClient) DerivationInfoAtBlock(ctx context.Context, blockNum uint64) (*eth.DerivationInfo, error) {
+	var output *eth.DerivationInfo
+	err := r.rpc.CallContext(ctx, &output, "optimism_derivationInfoAtBlock", hexutil.Uint64(blockNum))
+	return output, err
+}
+
 func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	var output *eth.SyncStatus
 	err := r.rpc.CallContext(ctx, &output, "optimism_syncStatus")
 	return output, err
 }
 
+// SubscribeHeads subscribes to the L2 head changes of the given topic, e.g. "unsafe" or "safe".
+// This requires a websocket connection to the rollup node.
+func (r *RollupClient) SubscribeHeads(ctx context.Context, topic string, ch chan<- eth.L2BlockRef) (ethereum.Subscription, error) {
+	return r.rpc.Subscribe(ctx, "optimism", ch, topic)
+}
+
 func (r *RollupClient) RollupConfig(ctx context.Context) (*rollup.Config, error) {
 	var output *rollup.Config
 	err := r.rpc.CallContext(ctx, &output, "optimism_rollupConfig")
@@ -69,6 +94,20 @@ func (r *RollupClient) SequencerActive(ctx context.Context) (bool, error) {
 	return result, err
 }
 
+func (r *RollupClient) StopSequencerAt(ctx context.Context, target eth.SequencerStopTarget) (common.Hash, error) {
+	var result common.Hash
+	err := r.rpc.CallContext(ctx, &result, "admin_stopSequencerAt", target)
+	return result, err
+}
+
+func (r *RollupClient) StartSequencerAt(ctx context.Context, unsafeHead common.Hash) error {
+	return r.rpc.CallContext(ctx, nil, "admin_startSequencerAt", unsafeHead)
+}
+
+func (r *RollupClient) CancelSequencerHandoff(ctx context.Context) error {
+	return r.rpc.CallContext(ctx, nil, "admin_cancelSequencerHandoff")
+}
+
 func (r *RollupClient) PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
 	return r.rpc.CallContext(ctx, nil, "admin_postUnsafePayload", payload)
 }
@@ -83,6 +122,16 @@ func (r *RollupClient) ConductorEnabled(ctx context.Context) (bool, error) {
 	return result, err
 }
 
+func (r *RollupClient) StartEventTrace(ctx context.Context, name string, maxEvents uint64) error {
+	return r.rpc.CallContext(ctx, nil, "admin_startEventTrace", name, maxEvents)
+}
+
+func (r *RollupClient) StopEventTrace(ctx context.Context) (*eth.EventTraceResult, error) {
+	var result *eth.EventTraceResult
+	err := r.rpc.CallContext(ctx, &result, "admin_stopEventTrace")
+	return result, err
+}
+
 func (r *RollupClient) SetLogLevel(ctx context.Context, lvl slog.Level) error {
 	return r.rpc.CallContext(ctx, nil, "admin_setLogLevel", lvl.String())
 }
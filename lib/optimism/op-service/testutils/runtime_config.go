@@ -1,11 +1,24 @@
 package testutils
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+)
 
 type MockRuntimeConfig struct {
 	P2PSeqAddress common.Address
+	P2PSigners    []opsigner.AuthorizedSigner
 }
 
 func (m *MockRuntimeConfig) P2PSequencerAddress() common.Address {
 	return m.P2PSeqAddress
 }
+
+func (m *MockRuntimeConfig) P2PSequencerSigners() []opsigner.AuthorizedSigner {
+	var out []opsigner.AuthorizedSigner
+	if m.P2PSeqAddress != (common.Address{}) {
+		out = append(out, opsigner.AuthorizedSigner{Address: m.P2PSeqAddress})
+	}
+	return append(out, m.P2PSigners...)
+}
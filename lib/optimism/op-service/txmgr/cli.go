@@ -9,6 +9,7 @@ import (
 	"time"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	opcrypto "github.com/ethereum-optimism/optimism/op-service/crypto"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
@@ -22,6 +23,10 @@ import (
 const (
 	// Duplicated L1 RPC flag
 	L1RPCFlagName = "l1-eth-rpc"
+	// Duplicated optional multi-endpoint L1 RPC flags, only defined by services that support them
+	L1ExtraRPCsFlagName = "l1-eth-extra-rpcs"
+	L1MultiModeFlagName = "l1-multi-mode"
+	L1QuorumFlagName    = "l1-quorum"
 	// Key Management Flags (also have signer client flags)
 	MnemonicFlagName   = "mnemonic"
 	HDPathFlagName     = "hd-path"
@@ -213,7 +218,12 @@ func CLIFlagsWithDefaults(envPrefix string, defaults DefaultFlagValues) []cli.Fl
 }
 
 type CLIConfig struct {
-	L1RPCURL                   string
+	L1RPCURL string
+	// L1ExtraRPCURLs are additional L1 RPC endpoints of the same chain, used together with L1RPCURL.
+	// See client.MultiClient for how L1MultiMode and L1Quorum are used.
+	L1ExtraRPCURLs             []string
+	L1MultiMode                client.MultiMode
+	L1Quorum                   int
 	Mnemonic                   string
 	HDPath                     string
 	SequencerHDPath            string
@@ -239,6 +249,7 @@ type CLIConfig struct {
 func NewCLIConfig(l1RPCURL string, defaults DefaultFlagValues) CLIConfig {
 	return CLIConfig{
 		L1RPCURL:                  l1RPCURL,
+		L1MultiMode:               client.MultiFailover,
 		NumConfirmations:          defaults.NumConfirmations,
 		SafeAbortNonceTooLowCount: defaults.SafeAbortNonceTooLowCount,
 		FeeLimitMultiplier:        defaults.FeeLimitMultiplier,
@@ -258,6 +269,11 @@ func (m CLIConfig) Check() error {
 	if m.L1RPCURL == "" {
 		return errors.New("must provide a L1 RPC url")
 	}
+	if len(m.L1ExtraRPCURLs) > 0 {
+		if err := m.l1MultiConfig().Check(1 + len(m.L1ExtraRPCURLs)); err != nil {
+			return fmt.Errorf("invalid L1 RPC endpoints: %w", err)
+		}
+	}
 	if m.NumConfirmations == 0 {
 		return errors.New("NumConfirmations must not be 0")
 	}
@@ -289,9 +305,20 @@ func (m CLIConfig) Check() error {
 	return nil
 }
 
+func (m CLIConfig) l1MultiConfig() client.MultiConfig {
+	return client.NewMultiConfig(m.L1MultiMode, m.L1Quorum, 1+len(m.L1ExtraRPCURLs))
+}
+
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	l1MultiMode := client.MultiFailover
+	if mode, ok := ctx.Generic(L1MultiModeFlagName).(*client.MultiMode); ok && mode != nil {
+		l1MultiMode = *mode
+	}
 	return CLIConfig{
 		L1RPCURL:                   ctx.String(L1RPCFlagName),
+		L1ExtraRPCURLs:             ctx.StringSlice(L1ExtraRPCsFlagName),
+		L1MultiMode:                l1MultiMode,
+		L1Quorum:                   ctx.Int(L1QuorumFlagName),
 		Mnemonic:                   ctx.String(MnemonicFlagName),
 		HDPath:                     ctx.String(HDPathFlagName),
 		SequencerHDPath:            ctx.String(SequencerHDPathFlag.Name),
@@ -322,7 +349,7 @@ func NewConfig(cfg CLIConfig, l log.Logger) (*Config, error) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.NetworkTimeout)
 	defer cancel()
-	l1, err := ethclient.DialContext(ctx, cfg.L1RPCURL)
+	l1, err := dialL1(ctx, cfg, l)
 	if err != nil {
 		return nil, fmt.Errorf("could not dial eth client: %w", err)
 	}
@@ -406,6 +433,36 @@ func NewConfig(cfg CLIConfig, l log.Logger) (*Config, error) {
 	return &res, nil
 }
 
+// multiEthClient is an ethclient.Client over a client.MultiClient, that also closes the MultiClient.
+type multiEthClient struct {
+	*ethclient.Client
+	multi *client.MultiClient
+}
+
+func (c *multiEthClient) Close() {
+	c.Client.Close()
+	c.multi.Close()
+}
+
+// l1Client is the L1 client of a SimpleTxManager.
+type l1Client interface {
+	ETHBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// dialL1 dials the L1 RPC, and spreads the requests over the extra L1 RPC endpoints too, if there are any.
+func dialL1(ctx context.Context, cfg CLIConfig, l log.Logger) (l1Client, error) {
+	if len(cfg.L1ExtraRPCURLs) == 0 {
+		return ethclient.DialContext(ctx, cfg.L1RPCURL)
+	}
+	addrs := append([]string{cfg.L1RPCURL}, cfg.L1ExtraRPCURLs...)
+	l1, multi, err := client.DialMultiEthClient(ctx, l, addrs, cfg.l1MultiConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &multiEthClient{Client: l1, multi: multi}, nil
+}
+
 // Config houses parameters for altering the behavior of a SimpleTxManager.
 type Config struct {
 	Backend ETHBackend
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
 )
 
 var (
@@ -39,3 +41,13 @@ func configForArgs(args ...string) CLIConfig {
 	_ = app.Run(args)
 	return config
 }
+
+func TestL1ExtraRPCURLs(t *testing.T) {
+	cfg := NewCLIConfig(l1EthRpcValue, DefaultBatcherFlagValues)
+	cfg.L1ExtraRPCURLs = []string{"http://localhost:9547"}
+	require.NoError(t, cfg.Check())
+	cfg.L1MultiMode = client.MultiQuorum
+	require.ErrorContains(t, cfg.Check(), "at least 3 endpoints")
+	cfg.L1ExtraRPCURLs = append(cfg.L1ExtraRPCURLs, "http://localhost:9548")
+	require.NoError(t, cfg.Check())
+}
@@ -226,6 +226,9 @@ type TxCandidate struct {
 	GasLimit uint64
 	// Value is the value to be used in the constructed tx.
 	Value *big.Int
+	// OnPublished is called with every version of the tx that is successfully published,
+	// including fee-bumped replacements (optional). It is called synchronously, so it must not block.
+	OnPublished func(tx *types.Transaction)
 }
 
 // Send is used to publish a transaction with incrementally higher gas prices
@@ -259,7 +262,7 @@ func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*typ
 		m.resetNonce()
 		return nil, err
 	}
-	receipt, err := m.sendTx(ctx, tx)
+	receipt, err := m.sendTxNotify(ctx, tx, candidate.OnPublished)
 	if err != nil {
 		m.resetNonce()
 		return nil, err
@@ -304,7 +307,7 @@ func (m *SimpleTxManager) SendAsync(ctx context.Context, candidate TxCandidate,
 	go func() {
 		defer func() { m.metr.RecordPendingTx(m.pending.Add(-1)) }()
 		defer cancel()
-		receipt, err := m.sendTx(ctx, tx)
+		receipt, err := m.sendTxNotify(ctx, tx, candidate.OnPublished)
 		if err != nil {
 			m.resetNonce()
 		}
@@ -552,6 +555,11 @@ func (m *SimpleTxManager) resetNonce() {
 // send submits the same transaction several times with increasing gas prices as necessary.
 // It waits for the transaction to be confirmed on chain.
 func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	return m.sendTxNotify(ctx, tx, nil)
+}
+
+// sendTxNotify is sendTx, calling the optional onPublished function with every published version of the tx.
+func (m *SimpleTxManager) sendTxNotify(ctx context.Context, tx *types.Transaction, onPublished func(*types.Transaction)) (*types.Receipt, error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	ctx, cancel := context.WithCancel(ctx)
@@ -572,6 +580,9 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 			}
 			var published bool
 			if tx, published = m.publishTx(ctx, tx, sendState); published {
+				if onPublished != nil {
+					onPublished(tx)
+				}
 				wg.Add(1)
 				go func() {
 					defer wg.Done()
@@ -501,6 +501,37 @@ func TestTxMgrConfirmsAtHigherGasPrice(t *testing.T) {
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
 }
 
+// TestTxMgrOnPublished asserts that the OnPublished function of a candidate is called
+// with every published version of the tx, including fee bumps.
+func TestTxMgrOnPublished(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		if h.gasPricer.shouldMine(tx.GasFeeCap()) {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap(), nil)
+		}
+		return nil
+	}
+	h.backend.setTxSender(sendTx)
+
+	var published []common.Hash
+	candidate := h.createTxCandidate()
+	candidate.OnPublished = func(tx *types.Transaction) {
+		published = append(published, tx.Hash())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	receipt, err := h.mgr.Send(ctx, candidate)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.Greater(t, len(published), 1, "fee bumps must be published")
+	require.Equal(t, receipt.TxHash, published[len(published)-1])
+}
+
 // TestTxMgrConfirmsBlobTxAtHigherGasPrice asserts that Send properly returns the max gas price
 // receipt if none of the lower gas price txs were mined when attempting to send a blob tx.
 func TestTxMgrConfirmsBlobTxAtHigherGasPrice(t *testing.T) {